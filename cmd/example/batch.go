@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// batchFlags флаги команды batch
+type batchFlags struct {
+	format        *string
+	lang          *string
+	verbose       *bool
+	suggestions   *bool
+	region        *string
+	batchFormat   *string
+	phoneColumn   *string
+	countryColumn *string
+	noHeader      *bool
+	columns       *string
+	output        *string
+}
+
+// runBatch выполняет команду batch: проверяет номера файла потоком и завершается
+// с кодом 1, если хотя бы один номер невалиден
+//
+//	mnv batch [flags] <file|->
+func runBatch(args []string) int {
+	fs := newFlagSet("batch", "<file|->",
+		"Validate numbers from a file or stdin (-): one number per line, or CSV/TSV rows\n"+
+			"enriched with validation columns. Exits with 1 if any number is invalid.")
+	flags := batchFlags{
+		format:        formatFlag(fs, resultFormats...),
+		lang:          fs.String("lang", "en", "Language for country names: en, ru, kg, kz, uz"),
+		verbose:       fs.Bool("verbose", false, "Print every result (text format)"),
+		suggestions:   fs.Bool("suggestions", false, "Include correction suggestions for invalid numbers"),
+		region:        fs.String("region", "", "Default country for numbers in national format"),
+		batchFormat:   fs.String("batch-format", "auto", "Input format: auto (by extension), lines, csv, tsv"),
+		phoneColumn:   fs.String("phone-column", "", "CSV/TSV phone column name or number starting at 1 (default: phone, tel, mobile...)"),
+		countryColumn: fs.String("country-column", "", "CSV/TSV country column name or number (optional)"),
+		noHeader:      fs.Bool("no-header", false, "CSV/TSV file has no header row"),
+		columns:       fs.String("columns", strings.Join(mnv.EnrichColumns, ","), "Columns added to CSV/TSV rows and written by -format=csv"),
+		output:        fs.String("output", "", "Output file for CSV/TSV input and ndjson, csv, table formats (default stdout)"),
+	}
+	preset := configFlag(fs)
+	positional, code, ok := parseFlags(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+	if code, ok := checkFormat(fs, *flags.format, resultFormats...); !ok {
+		return code
+	}
+	if code, ok := applyConfig(fs, *preset); !ok {
+		return code
+	}
+	filename := positional[0]
+
+	comma, table, err := tableSeparator(filename, *flags.batchFormat)
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+	if table && isFlagSet(fs, "format") {
+		return usageError(fs, "-format applies to files with one number per line; CSV/TSV rows are written enriched")
+	}
+	for _, column := range splitColumns(*flags.columns) {
+		if _, ok := mnv.ColumnValue(&mnv.ValidationResult{}, column); !ok {
+			return usageError(fs, "unknown column %q (available: %s)", column, strings.Join(mnv.EnrichColumns, ", "))
+		}
+	}
+
+	input, err := openInput(filename)
+	if err != nil {
+		return ioError("error opening file: %v", err)
+	}
+	defer input.Close()
+
+	if table {
+		return processTableFile(input, filename, comma, flags)
+	}
+	return processBatchFile(input, filename, flags)
+}
+
+// phoneLines отправляет в канал непустые строки r, кроме комментариев (#), пока не
+// отменен ctx. Функция err возвращает ошибку чтения после закрытия канала
+func phoneLines(ctx context.Context, r io.Reader) (phones <-chan mnv.BatchItem, err func() error) {
+	items := make(chan mnv.BatchItem)
+	var readErr error
+	go func() {
+		defer close(items)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			select {
+			case items <- mnv.BatchItem{Phone: line}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr = scanner.Err()
+	}()
+	return items, func() error { return readErr }
+}
+
+// batchExitCode возвращает код завершения по итоговой статистике
+func batchExitCode(stats *mnv.BatchStats) int {
+	if stats != nil && stats.Invalid+stats.Errors > 0 {
+		return exitInvalid
+	}
+	return exitValid
+}
+
+// processBatchFile проверяет файл с одним номером в строке
+func processBatchFile(input io.Reader, filename string, flags batchFlags) int {
+	// Номера читаются и проверяются потоком, поэтому размер файла не ограничен памятью.
+	// Отмена ctx останавливает чтение и проверку, например после ошибки записи
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	phones, readErr := phoneLines(ctx, input)
+
+	startTime := time.Now()
+	results := mnv.ValidateStream(ctx, phones, &mnv.StreamOptions{
+		Parallel:      10,
+		Ordered:       true,
+		DefaultRegion: *flags.region,
+		Options: &mnv.ValidationOptions{
+			ReturnSuggestions: *flags.suggestions,
+			MaxSuggestions:    3,
+		},
+	})
+
+	var stats *mnv.BatchStats
+	switch *flags.format {
+	case "json":
+		stats = writeBatchJSON(results, startTime)
+	case "text":
+		stats = writeBatchText(results, filename, startTime, flags)
+	default:
+		out, err := createOutput(*flags.output)
+		if err != nil {
+			return ioError("error creating output file: %v", err)
+		}
+		defer out.Close()
+
+		writer, err := newResultWriter(out, *flags.format, splitColumns(*flags.columns))
+		if err != nil {
+			return ioError("error writing results: %v", err)
+		}
+		if stats, err = writeBatchLines(results, writer); err != nil {
+			return ioError("error writing results: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Total: %d | Valid: %d | Invalid: %d\n", stats.Total, stats.Valid, stats.Invalid)
+	}
+
+	if err := readErr(); err != nil {
+		return ioError("error reading file: %v", err)
+	}
+	return batchExitCode(stats)
+}
+
+// processTableFile дополняет строки CSV/TSV колонками результата проверки.
+// Таблица пишется в -output или stdout, итоговая статистика - в stderr
+func processTableFile(input io.Reader, filename string, comma rune, flags batchFlags) int {
+	out, err := createOutput(*flags.output)
+	if err != nil {
+		return ioError("error creating output file: %v", err)
+	}
+	defer out.Close()
+	writer := bufio.NewWriter(out)
+
+	stats, err := mnv.EnrichCSV(context.Background(), input, writer, &mnv.CSVOptions{
+		Comma:         comma,
+		NoHeader:      *flags.noHeader,
+		PhoneColumn:   *flags.phoneColumn,
+		CountryColumn: *flags.countryColumn,
+		DefaultRegion: *flags.region,
+		Columns:       splitColumns(*flags.columns),
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		return processingError("batch", filename, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Total: %d | Valid: %d | Invalid: %d\n", stats.Total, stats.Valid, stats.Invalid)
+	return batchExitCode(stats)
+}
+
+// splitColumns разбирает список колонок через запятую
+func splitColumns(list string) []string {
+	var columns []string
+	for _, column := range strings.Split(list, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// writeBatchLines выводит результаты потока построчно и возвращает итоговую статистику.
+// При ошибке записи (например, EPIPE после | head) возвращается сразу, не дочитывая поток;
+// вызывающий останавливает поток отменой его контекста
+func writeBatchLines(results <-chan mnv.StreamResult, writer resultWriter) (*mnv.BatchStats, error) {
+	stats := &mnv.BatchStats{ByCountry: map[string]int{}}
+	for message := range results {
+		if message.Stats != nil {
+			stats = message.Stats
+			continue
+		}
+		if err := writer.write(message.Result); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// writeBatchJSON выводит результаты потока документом BatchValidationResponse,
+// не накапливая их в памяти, и возвращает итоговую статистику
+func writeBatchJSON(results <-chan mnv.StreamResult, startTime time.Time) *mnv.BatchStats {
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	stats := &mnv.BatchStats{ByCountry: map[string]int{}}
+	fmt.Fprint(out, "{\n  \"results\": [")
+	count := 0
+	for message := range results {
+		if message.Stats != nil {
+			stats = message.Stats
+			continue
+		}
+
+		data, _ := json.MarshalIndent(message.Result, "    ", "  ")
+		if count > 0 {
+			fmt.Fprint(out, ",")
+		}
+		fmt.Fprintf(out, "\n    %s", data)
+		count++
+	}
+	if count > 0 {
+		fmt.Fprint(out, "\n  ")
+	}
+
+	statsData, _ := json.MarshalIndent(stats, "  ", "  ")
+	timeData, _ := json.Marshal(time.Since(startTime).String())
+	fmt.Fprintf(out, "],\n  \"stats\": %s,\n  \"processing_time\": %s\n}\n", statsData, timeData)
+	return stats
+}
+
+// writeBatchText выводит текстовый отчет и возвращает итоговую статистику;
+// при -verbose результаты печатаются по мере проверки
+func writeBatchText(results <-chan mnv.StreamResult, filename string, startTime time.Time, flags batchFlags) *mnv.BatchStats {
+	verbose := *flags.verbose
+	fmt.Printf("Processing phone numbers from %s...\n\n", filename)
+	if verbose {
+		fmt.Printf("Detailed Results:\n")
+		fmt.Printf("-----------------\n")
+	}
+
+	var stats *mnv.BatchStats
+	for message := range results {
+		if message.Stats != nil {
+			stats = message.Stats
+			continue
+		}
+		if !verbose {
+			continue
+		}
+
+		result := message.Result
+		status := "❌"
+		if result.IsValid {
+			status = "✅"
+		}
+		fmt.Printf("%d. %s %s", message.Index+1, status, result.OriginalNumber)
+
+		if result.CountryCode != "" {
+			fmt.Printf(" (%s)", strings.ToUpper(result.CountryCode))
+		}
+
+		if !result.IsValid && result.ErrorMessage != "" {
+			fmt.Printf(" - %s", result.ErrorMessage)
+		}
+
+		fmt.Println()
+	}
+	if verbose {
+		fmt.Println()
+	}
+
+	if stats == nil || stats.Total == 0 {
+		fmt.Println("No phone numbers found in file")
+		return stats
+	}
+
+	// Текстовый отчет
+	fmt.Printf("Batch Validation Results:\n")
+	fmt.Printf("========================\n")
+	fmt.Printf("Total: %d | Valid: %d | Invalid: %d | Processing Time: %s\n\n",
+		stats.Total,
+		stats.Valid,
+		stats.Invalid,
+		time.Since(startTime))
+
+	// Статистика по странам
+	if len(stats.ByCountry) > 0 {
+		fmt.Printf("By Country:\n")
+		for country, count := range stats.ByCountry {
+			if _, exists := mnv.GetCountryInfo(country); exists {
+				fmt.Printf("  %s (%s): %d\n", mnv.CountryName(country, *flags.lang), strings.ToUpper(country), count)
+			} else {
+				fmt.Printf("  %s: %d\n", strings.ToUpper(country), count)
+			}
+		}
+		fmt.Println()
+	}
+	return stats
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// runCountries выполняет команду countries: выводит список поддерживаемых стран
+//
+//	mnv countries [flags]
+func runCountries(args []string) int {
+	fs := newFlagSet("countries", "", "List supported countries.")
+	format := formatFlag(fs, "text", "json")
+	lang := fs.String("lang", "en", "Language for country names: en, ru, kg, kz, uz")
+	if _, code, ok := parseFlags(fs, args, 0, 0); !ok {
+		return code
+	}
+	if code, ok := checkFormat(fs, *format, "text", "json"); !ok {
+		return code
+	}
+
+	listSupportedCountries(os.Stdout, *format, *lang)
+	return exitValid
+}
+
+func listSupportedCountries(w io.Writer, format, lang string) {
+	countries := mnv.GetSupportedCountries()
+
+	if format == "json" {
+		countriesInfo := make(map[string]mnv.PhoneCodeInfo)
+		for _, code := range countries {
+			if info, exists := mnv.LocalizedCountryInfo(code, lang); exists {
+				countriesInfo[code] = info
+			}
+		}
+
+		writeJSON(w, map[string]interface{}{
+			"countries": countries,
+			"count":     len(countries),
+			"language":  mnv.NormalizeLanguage(lang),
+			"details":   countriesInfo,
+		})
+		return
+	}
+
+	fmt.Fprintf(w, "Supported countries (%d total):\n\n", len(countries))
+	fmt.Fprintf(w, "%-4s %-20s %-10s %s\n", "Code", "Country", "Prefix", "Description")
+	fmt.Fprintln(w, strings.Repeat("-", 60))
+
+	for _, code := range countries {
+		if info, exists := mnv.LocalizedCountryInfo(code, lang); exists {
+			fmt.Fprintf(w, "%-4s %-20s %-10s %s\n",
+				strings.ToUpper(code),
+				info.CountryName,
+				info.Prefix,
+				info.Description)
+		}
+	}
+}
+
+// runConfig выполняет команду config: выводит настройки предустановки конфигурации
+//
+//	mnv config [flags] [preset]
+func runConfig(args []string) int {
+	fs := newFlagSet("config", "[preset]", "Show the settings of a configuration preset (default \"default\") and the list of presets.")
+	format := formatFlag(fs, "text", "json")
+	positional, code, ok := parseFlags(fs, args, 0, 1)
+	if !ok {
+		return code
+	}
+	if code, ok := checkFormat(fs, *format, "text", "json"); !ok {
+		return code
+	}
+
+	preset := "default"
+	if len(positional) > 0 {
+		preset = positional[0]
+	}
+	config, exists := mnv.GetPresetConfig(preset)
+	if !exists {
+		return usageError(fs, "unknown config preset %q (available: %s)", preset, strings.Join(presetNames(), ", "))
+	}
+
+	if *format == "json" {
+		writeJSON(os.Stdout, map[string]interface{}{
+			"preset":  preset,
+			"config":  config,
+			"presets": presetNames(),
+		})
+		return exitValid
+	}
+
+	fmt.Println("Available presets:", strings.Join(presetNames(), ", "))
+	fmt.Printf("Preset: %s\n", preset)
+	printConfig(os.Stdout, config)
+	return exitValid
+}
+
+func printConfig(w io.Writer, config mnv.ValidatorConfig) {
+	fmt.Fprintf(w, "Configuration:\n")
+	fmt.Fprintf(w, "  Allow Spaces: %t\n", config.AllowSpaces)
+	fmt.Fprintf(w, "  Allow Dashes: %t\n", config.AllowDashes)
+	fmt.Fprintf(w, "  Allow Parentheses: %t\n", config.AllowParentheses)
+	fmt.Fprintf(w, "  Strict Mode: %t\n", config.StrictMode)
+	fmt.Fprintf(w, "  Require Plus Sign: %t\n", config.RequirePlusSign)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// runDedupe выполняет команду dedupe: группирует записи файла по каноническому номеру
+// и выводит таблицу соответствия записей группам
+//
+//	mnv dedupe [flags] <file|->
+func runDedupe(args []string) int {
+	fs := newFlagSet("dedupe", "<file|->",
+		"Group records of a file or stdin (-) by canonical number and print a mapping table:\n"+
+			"row, phone, cluster, representative, match, duplicate.")
+	dedupeColumn := fs.String("column", "", "CSV/TSV phone column name or number starting at 1")
+	dedupeCountryColumn := fs.String("country-column", "", "CSV/TSV country column name or number (optional)")
+	dedupeRegion := fs.String("region", "", "Default country for numbers in national format")
+	dedupeExtensions := fs.Bool("extensions", false, "Treat numbers with different extensions as different")
+	dedupeLevel := fs.String("level", "exact", "Match level: exact, nsn")
+	dedupeBatchFormat := fs.String("batch-format", "auto", "Input format: auto (by extension), lines, csv, tsv")
+	dedupeNoHeader := fs.Bool("no-header", false, "CSV/TSV file has no header row")
+	dedupeOutput := fs.String("output", "", "Mapping file (default stdout)")
+	positional, code, ok := parseFlags(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+	filename := positional[0]
+
+	levels := map[string]mnv.MatchType{"exact": mnv.MatchTypeExact, "nsn": mnv.MatchTypeNSN}
+	level, ok := levels[strings.ToLower(*dedupeLevel)]
+	if !ok {
+		return usageError(fs, "unknown match level %q", *dedupeLevel)
+	}
+	comma, table, err := tableSeparator(filename, *dedupeBatchFormat)
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	dedupe, err := mnv.NewDeduplicator(mnv.DedupeOptions{
+		DefaultRegion: *dedupeRegion,
+		Extensions:    *dedupeExtensions,
+		Level:         level,
+	})
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	input, err := openInput(filename)
+	if err != nil {
+		return ioError("error opening file: %v", err)
+	}
+	defer input.Close()
+
+	var phones []string
+	add := func(item mnv.BatchItem) {
+		phones = append(phones, item.Phone)
+		dedupe.AddItem(item)
+	}
+
+	if table {
+		items := mnv.CSVItems(input, &mnv.CSVOptions{
+			Comma:         comma,
+			NoHeader:      *dedupeNoHeader,
+			PhoneColumn:   *dedupeColumn,
+			CountryColumn: *dedupeCountryColumn,
+		})
+		for item, err := range items {
+			if err != nil {
+				return processingError("dedupe", filename, err)
+			}
+			add(item)
+		}
+	} else {
+		items, readErr := phoneLines(context.Background(), input)
+		for item := range items {
+			add(item)
+		}
+		if err := readErr(); err != nil {
+			return ioError("error reading file: %v", err)
+		}
+	}
+
+	out, err := createOutput(*dedupeOutput)
+	if err != nil {
+		return ioError("error creating output file: %v", err)
+	}
+	defer out.Close()
+
+	clusters := dedupe.Clusters()
+	if err := writeDedupeMapping(out, phones, clusters); err != nil {
+		return ioError("error writing mapping: %v", err)
+	}
+
+	duplicates := 0
+	for _, cluster := range clusters {
+		duplicates += len(cluster.Indices) - 1
+	}
+	fmt.Fprintf(os.Stderr, "Rows: %d, clusters: %d, duplicates: %d, unmatched: %d\n",
+		dedupe.Len(), len(clusters), duplicates, len(dedupe.Unmatched()))
+	return exitValid
+}
+
+// writeDedupeMapping пишет таблицу соответствия в порядке исходных записей:
+// номер строки, исходный номер, номер группы, представитель группы, уровень совпадения
+// и признак повтора. У записей, не разобранных как номер, группа пустая
+func writeDedupeMapping(w io.Writer, phones []string, clusters []mnv.DedupeCluster) error {
+	type membership struct {
+		cluster   int
+		duplicate bool
+	}
+	members := make(map[int]membership, len(phones))
+	for i, cluster := range clusters {
+		for j, index := range cluster.Indices {
+			members[index] = membership{cluster: i, duplicate: j > 0}
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"row", "phone", "cluster", "representative", "match", "duplicate"})
+	for index, phone := range phones {
+		record := []string{strconv.Itoa(index + 1), phone, "", "", "", "false"}
+		if member, ok := members[index]; ok {
+			cluster := clusters[member.cluster]
+			record[2] = strconv.Itoa(member.cluster + 1)
+			record[3] = cluster.Representative
+			record[4] = string(cluster.Match)
+			record[5] = strconv.FormatBool(member.duplicate)
+		}
+		_ = writer.Write(record)
+	}
+	writer.Flush()
+	return writer.Error()
+}
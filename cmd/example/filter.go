@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// filterLine строка входа команды filter
+type filterLine struct {
+	// raw строка без пробелов по краям
+	raw string
+
+	// json строка является объектом JSON
+	json bool
+
+	// phone положение строкового значения поля с номером в raw (для объекта JSON)
+	phone jsonField
+}
+
+// jsonField строковое значение поля верхнего уровня объекта JSON и его положение
+// в строке: raw[start:end] - значение вместе с кавычками
+type jsonField struct {
+	value      string
+	start, end int
+}
+
+// filterMatch условия отбора команды filter
+type filterMatch struct {
+	valid     bool
+	invalid   bool
+	countries map[string]bool
+	phoneType mnv.PhoneType
+}
+
+// matches проверяет, подходит ли результат проверки под условия. Страна невалидного
+// номера - только подсказка, поэтому условию по странам подходят только валидные номера
+func (m filterMatch) matches(result *mnv.ValidationResult) bool {
+	if m.valid && !result.IsValid || m.invalid && result.IsValid {
+		return false
+	}
+	if len(m.countries) > 0 && (!result.IsValid || !m.countries[result.CountryCode]) {
+		return false
+	}
+	return m.phoneType == "" || result.Type == m.phoneType
+}
+
+// runFilter выполняет команду filter: читает номера или строки JSON и выводит только
+// подходящие строки, как grep для номеров телефонов
+//
+//	mnv filter [flags] [file...]
+func runFilter(args []string) int {
+	fs := newFlagSet("filter", "[file...]",
+		"Read phone numbers or JSON lines from files or stdin and print only the matching lines,\n"+
+			"in input order, as soon as they are checked. Exits with 1 if no line matched.")
+	valid := fs.Bool("valid", false, "Only valid numbers")
+	invalid := fs.Bool("invalid", false, "Only invalid numbers")
+	countries := fs.String("country", "", "Only valid numbers of these countries (comma-separated codes or names); a single country is also the default region")
+	phoneType := fs.String("type", "", "Only numbers of this type: mobile, landline, toll_free, premium, voip")
+	region := fs.String("region", "", "Default country for numbers in national format")
+	e164 := fs.Bool("e164", false, "Rewrite matching valid numbers to E.164")
+	field := fs.String("field", "phone", "JSON lines: field with the phone number")
+	countryField := fs.String("country-field", "country", "JSON lines: field with the country hint")
+	preset := configFlag(fs)
+	files, code, ok := parseFlags(fs, args, 0, -1)
+	if !ok {
+		return code
+	}
+	if code, ok := applyConfig(fs, *preset); !ok {
+		return code
+	}
+
+	match := filterMatch{valid: *valid, invalid: *invalid, phoneType: mnv.PhoneType(strings.ToLower(*phoneType))}
+	if match.valid && match.invalid {
+		return usageError(fs, "-valid and -invalid are mutually exclusive")
+	}
+	if match.invalid && *countries != "" {
+		return usageError(fs, "-country matches only valid numbers; use -region to set the default country for -invalid")
+	}
+	switch match.phoneType {
+	case "", mnv.PhoneTypeMobile, mnv.PhoneTypeLandline, mnv.PhoneTypeTollFree, mnv.PhoneTypePremium, mnv.PhoneTypeVoip, mnv.PhoneTypeUnknown:
+	default:
+		return usageError(fs, "unknown phone type %q", *phoneType)
+	}
+	for _, name := range splitColumns(*countries) {
+		code, found := mnv.ResolveCountry(name)
+		if !found {
+			return usageError(fs, "unknown country %q", name)
+		}
+		if match.countries == nil {
+			match.countries = make(map[string]bool)
+		}
+		match.countries[code] = true
+	}
+	defaultRegion := *region
+	if defaultRegion == "" && len(match.countries) == 1 {
+		for code := range match.countries {
+			defaultRegion = code
+		}
+	}
+
+	inputs := make([]io.Reader, 0, len(files))
+	for _, filename := range files {
+		input, err := openInput(filename)
+		if err != nil {
+			return ioError("error opening file: %v", err)
+		}
+		defer input.Close()
+		inputs = append(inputs, input)
+	}
+	if len(inputs) == 0 {
+		inputs = append(inputs, os.Stdin)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Строки хранятся только до вывода результата, то есть не больше окна потока
+	var mu sync.Mutex
+	lines := make(map[int]filterLine)
+
+	items := make(chan mnv.BatchItem)
+	var readErr error
+	go func() {
+		defer close(items)
+		// bufio.Reader вместо bufio.Scanner: длина строки (например, большого объекта JSON)
+		// не ограничена размером буфера
+		reader := bufio.NewReader(io.MultiReader(inputs...))
+		for index := 0; ; {
+			text, err := reader.ReadString('\n')
+			if line, item, ok := parseFilterLine(text, *field, *countryField); ok {
+				mu.Lock()
+				lines[index] = line
+				mu.Unlock()
+				index++
+
+				select {
+				case items <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+
+	results := mnv.ValidateStream(ctx, items, &mnv.StreamOptions{
+		Ordered:       true,
+		DefaultRegion: defaultRegion,
+	})
+
+	code = exitInvalid
+	for message := range results {
+		if message.Result == nil {
+			continue
+		}
+
+		mu.Lock()
+		line := lines[message.Index]
+		delete(lines, message.Index)
+		mu.Unlock()
+
+		if !match.matches(message.Result) {
+			continue
+		}
+		// Каждая строка выводится сразу, чтобы фильтр работал в конвейерах с tail -f
+		if _, err := fmt.Fprintln(os.Stdout, formatFilterLine(line, message.Result, *e164)); err != nil {
+			return ioError("error writing output: %v", err)
+		}
+		code = exitValid
+	}
+
+	if readErr != nil {
+		return ioError("error reading input: %v", readErr)
+	}
+	return code
+}
+
+// parseFilterLine разбирает строку входа: объект JSON с номером в поле field
+// и подсказкой страны в поле countryField или номер. Пустые строки и комментарии (#)
+// пропускаются
+func parseFilterLine(text, field, countryField string) (filterLine, mnv.BatchItem, bool) {
+	line := filterLine{raw: strings.TrimSpace(text)}
+	if line.raw == "" || strings.HasPrefix(line.raw, "#") {
+		return line, mnv.BatchItem{}, false
+	}
+
+	if !strings.HasPrefix(line.raw, "{") {
+		return line, mnv.BatchItem{Phone: line.raw}, true
+	}
+
+	// Строка, не являющаяся объектом JSON, проверяется как номер и будет отклонена валидатором
+	fields, ok := scanJSONObject(line.raw, field, countryField)
+	if !ok {
+		return line, mnv.BatchItem{Phone: line.raw}, true
+	}
+	line.json = true
+	line.phone = fields[field]
+	return line, mnv.BatchItem{Phone: line.phone.value, Country: fields[countryField].value}, true
+}
+
+// scanJSONObject находит строковые поля верхнего уровня names объекта JSON text.
+// Поля с нестроковым значением не возвращаются; при повторе поля действует последнее
+// значение, как в encoding/json. false - text не является объектом JSON
+func scanJSONObject(text string, names ...string) (map[string]jsonField, bool) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, false
+	}
+
+	fields := make(map[string]jsonField)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false
+		}
+		name, _ := token.(string)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, false
+		}
+		if !slices.Contains(names, name) {
+			continue
+		}
+
+		var field jsonField
+		if err := json.Unmarshal(value, &field.value); err != nil {
+			delete(fields, name)
+			continue
+		}
+		field.end = int(decoder.InputOffset())
+		field.start = field.end - len(value)
+		fields[name] = field
+	}
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('}') {
+		return nil, false
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, false
+	}
+	return fields, true
+}
+
+// formatFilterLine возвращает строку вывода: исходную строку или, при rewrite,
+// строку с номером в формате E.164 (только для валидных номеров). В строке JSON
+// заменяется только значение поля с номером, остальные байты строки сохраняются
+func formatFilterLine(line filterLine, result *mnv.ValidationResult, rewrite bool) string {
+	e164, _ := mnv.ColumnValue(result, mnv.ColumnE164)
+	if !rewrite || e164 == "" {
+		return line.raw
+	}
+	if !line.json {
+		return e164
+	}
+
+	value, _ := json.Marshal(e164)
+	return line.raw[:line.phone.start] + string(value) + line.raw[line.phone.end:]
+}
@@ -1,359 +1,332 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/jaman-bala/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnv"
 )
 
-var (
-	phone         = flag.String("phone", "", "Phone number to validate")
-	country       = flag.String("country", "", "Country code (optional)")
-	config        = flag.String("config", "default", "Configuration preset (default, strict, relaxed)")
-	interactive   = flag.Bool("interactive", false, "Run in interactive mode")
-	batch         = flag.String("batch", "", "File with phone numbers for batch validation")
-	listCountries = flag.Bool("list-countries", false, "List all supported countries")
-	info          = flag.Bool("info", false, "Show detailed phone information")
-	suggestions   = flag.Bool("suggestions", false, "Show correction suggestions for invalid numbers")
-	format        = flag.String("format", "text", "Output format: text, json")
-	verbose       = flag.Bool("verbose", false, "Verbose output")
-)
+// Коды завершения CLI
+const (
+	// exitValid все проверенные номера валидны (или команда выполнена успешно)
+	exitValid = 0
 
-func main() {
-	flag.Parse()
+	// exitInvalid хотя бы один номер невалиден или не распознан
+	exitInvalid = 1
 
-	// Применяем конфигурацию
-	if err := mnv.SetPresetConfig(*config); err != nil {
-		log.Printf("Warning: Invalid config preset '%s', using default\n", *config)
-		mnv.SetPresetConfig("default")
-	}
+	// exitUsage ошибка в аргументах командной строки
+	exitUsage = 2
 
-	if *verbose {
-		fmt.Printf("Using configuration: %s\n", *config)
-		printConfig()
-		fmt.Println()
-	}
+	// exitIO ошибка чтения входных данных или записи результата
+	exitIO = 3
+)
 
-	// Обработка команд
-	switch {
-	case *listCountries:
-		listSupportedCountries()
-	case *batch != "":
-		processBatchFile(*batch)
-	case *interactive:
-		runInteractiveMode()
-	case *phone != "":
-		validateSinglePhone(*phone, *country)
-	default:
-		printUsage()
-	}
+// command подкоманда CLI
+type command struct {
+	// name имя команды
+	name string
+
+	// summary краткое описание для списка команд
+	summary string
+
+	// run выполняет команду и возвращает код завершения
+	run func(args []string) int
 }
 
-func printUsage() {
-	fmt.Println("Mobile Number Validator (MNV) CLI Tool")
-	fmt.Println("Usage examples:")
-	fmt.Println("  mnv -phone=\"+996700123456\" -country=\"kg\"")
-	fmt.Println("  mnv -phone=\"+79991234567\" -info")
-	fmt.Println("  mnv -list-countries")
-	fmt.Println("  mnv -interactive")
-	fmt.Println("  mnv -batch=\"phones.txt\"")
-	fmt.Println()
-	flag.PrintDefaults()
+// commands подкоманды в порядке вывода справки
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "validate", summary: "Validate phone numbers", run: runValidate},
+		{name: "parse", summary: "Parse a number into E.164, country and formats", run: runParse},
+		{name: "format", summary: "Format a number (e164, international, national, rfc3966)", run: runFormat},
+		{name: "info", summary: "Show detailed phone information", run: runInfo},
+		{name: "detect", summary: "Detect the country of a number", run: runDetect},
+		{name: "batch", summary: "Validate numbers from a file or stdin (lines, CSV, TSV)", run: runBatch},
+		{name: "filter", summary: "Print only matching numbers or JSON lines (grep for phone numbers)", run: runFilter},
+		{name: "report", summary: "Build a data-quality report for a file", run: runReport},
+		{name: "dedupe", summary: "Group duplicate numbers of a file", run: runDedupe},
+		{name: "countries", summary: "List supported countries", run: runCountries},
+		{name: "config", summary: "Show configuration presets", run: runConfig},
+		{name: "repl", summary: "Run interactive mode", run: runREPL},
+		{name: "help", summary: "Show help for a command", run: runHelp},
+	}
 }
 
-func printConfig() {
-	config := mnv.GetConfig()
-	fmt.Printf("Configuration:\n")
-	fmt.Printf("  Allow Spaces: %t\n", config.AllowSpaces)
-	fmt.Printf("  Allow Dashes: %t\n", config.AllowDashes)
-	fmt.Printf("  Allow Parentheses: %t\n", config.AllowParentheses)
-	fmt.Printf("  Strict Mode: %t\n", config.StrictMode)
-	fmt.Printf("  Require Plus Sign: %t\n", config.RequirePlusSign)
+func main() {
+	os.Exit(run(os.Args[1:]))
 }
 
-func listSupportedCountries() {
-	countries := mnv.GetSupportedCountries()
+// run выполняет команду args[0] и возвращает код завершения
+func run(args []string) int {
+	if len(args) == 0 {
+		printUsage(os.Stderr)
+		return exitUsage
+	}
 
-	if *format == "json" {
-		countriesInfo := make(map[string]mnv.PhoneCodeInfo)
-		for _, code := range countries {
-			if info, exists := mnv.GetCountryInfo(code); exists {
-				countriesInfo[code] = info
-			}
-		}
+	switch args[0] {
+	case "-h", "-help", "--help":
+		printUsage(os.Stdout)
+		return exitValid
+	}
 
-		data, _ := json.MarshalIndent(map[string]interface{}{
-			"countries": countries,
-			"count":     len(countries),
-			"details":   countriesInfo,
-		}, "", "  ")
-		fmt.Println(string(data))
-		return
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "mnv: unknown command %q\n\n", args[0])
+		printUsage(os.Stderr)
+		return exitUsage
 	}
+	return cmd.run(args[1:])
+}
 
-	fmt.Printf("Supported countries (%d total):\n\n", len(countries))
-	fmt.Printf("%-4s %-20s %-10s %s\n", "Code", "Country", "Prefix", "Description")
-	fmt.Println(strings.Repeat("-", 60))
-
-	for _, code := range countries {
-		if info, exists := mnv.GetCountryInfo(code); exists {
-			fmt.Printf("%-4s %-20s %-10s %s\n",
-				strings.ToUpper(code),
-				info.CountryName,
-				info.Prefix,
-				info.Description)
+// findCommand ищет подкоманду по имени
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
 		}
 	}
+	return command{}, false
 }
 
-func validateSinglePhone(phone, country string) {
-	options := &mnv.ValidationOptions{
-		ReturnSuggestions: *suggestions,
-		MaxSuggestions:    5,
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Mobile Number Validator (MNV) CLI Tool")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage: mnv <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
 	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit codes:")
+	fmt.Fprintln(w, "  0  all numbers are valid")
+	fmt.Fprintln(w, "  1  at least one number is invalid")
+	fmt.Fprintln(w, "  2  usage error")
+	fmt.Fprintln(w, "  3  I/O error")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Examples:")
+	fmt.Fprintln(w, "  mnv validate -country=kg +996700123456")
+	fmt.Fprintln(w, "  mnv format -style=national +996700123456")
+	fmt.Fprintln(w, "  mnv batch -region=kg phones.txt")
+	fmt.Fprintln(w, "  mnv batch -format=ndjson - < phones.txt")
+	fmt.Fprintln(w, "  tail -f signups.log | mnv filter -valid -country=kg -e164")
+	fmt.Fprintln(w, "  mnv batch -phone-column=Mobile -region=kg -output=checked.csv contacts.csv")
+	fmt.Fprintln(w, "  mnv report -format=html -output=report.html contacts.csv")
+	fmt.Fprintln(w, "  mnv dedupe contacts.csv --column phone -region=kg -output=mapping.csv")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'mnv help <command>' for command flags.")
+}
 
-	var result *mnv.ValidationResult
-
-	if country != "" {
-		result = mnv.ValidatePhone(phone, country, options)
-	} else {
-		// Пытаемся определить страну автоматически
-		detectedCountry, found := mnv.GetCountryByPhone(phone)
-		if found {
-			result = mnv.ValidatePhone(phone, detectedCountry, options)
-		} else {
-			result = &mnv.ValidationResult{
-				IsValid:        false,
-				OriginalNumber: phone,
-				ErrorMessage:   "Cannot determine country for phone number",
-			}
-		}
+// runHelp выполняет команду help
+//
+//	mnv help [command]
+func runHelp(args []string) int {
+	if len(args) == 0 {
+		printUsage(os.Stdout)
+		return exitValid
 	}
 
-	if *format == "json" {
-		data, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(data))
-		return
+	cmd, ok := findCommand(args[0])
+	if !ok || cmd.name == "help" {
+		fmt.Fprintf(os.Stderr, "mnv: unknown command %q\n", args[0])
+		return exitUsage
 	}
+	return cmd.run([]string{"-h"})
+}
 
-	// Текстовый вывод
-	fmt.Printf("Phone Number: %s\n", phone)
-	if result.CountryCode != "" {
-		fmt.Printf("Country: %s (%s)\n", result.CountryName, strings.ToUpper(result.CountryCode))
+// newFlagSet создает набор флагов команды. Справка выводится в stdout при -h
+// и в stderr при ошибке в аргументах
+func newFlagSet(name, arguments, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s\n\n%s\n", strings.TrimSpace("mnv "+name+" [flags] "+arguments), description)
+		if hasFlags(fs) {
+			fmt.Fprintln(fs.Output(), "\nFlags:")
+			fs.PrintDefaults()
+		}
 	}
+	return fs
+}
 
-	if result.IsValid {
-		fmt.Printf("Status: ✅ VALID\n")
-		if result.FormattedNumber != phone {
-			fmt.Printf("Formatted: %s\n", result.FormattedNumber)
-		}
+// hasFlags проверяет, определены ли у команды флаги
+func hasFlags(fs *flag.FlagSet) bool {
+	found := false
+	fs.VisitAll(func(*flag.Flag) { found = true })
+	return found
+}
 
-		if *info {
-			phoneInfo := mnv.GetPhoneInfo(phone)
-			fmt.Printf("Type: %s\n", phoneInfo.Type)
-			fmt.Printf("Prefix: %s\n", phoneInfo.Prefix)
-			fmt.Printf("Local Number: %s\n", phoneInfo.LocalNumber)
+// parseFlags разбирает флаги команды, в том числе указанные после позиционных
+// аргументов (mnv dedupe file.csv --column phone), и проверяет количество позиционных
+// аргументов (max < 0 - без ограничения). ok = false означает, что команда должна
+// завершиться с кодом code (после -h или ошибки в аргументах)
+func parseFlags(fs *flag.FlagSet, args []string, min, max int) (positional []string, code int, ok bool) {
+	fs.SetOutput(io.Discard)
+	for {
+		err := fs.Parse(args)
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(os.Stdout)
+			fs.Usage()
+			return nil, exitValid, false
 		}
-	} else {
-		fmt.Printf("Status: ❌ INVALID\n")
-		if result.ErrorMessage != "" {
-			fmt.Printf("Error: %s\n", result.ErrorMessage)
+		if err != nil {
+			fs.SetOutput(os.Stderr)
+			fmt.Fprintf(os.Stderr, "mnv %s: %v\n", fs.Name(), err)
+			fs.Usage()
+			return nil, exitUsage, false
 		}
 
-		if len(result.Suggestions) > 0 {
-			fmt.Printf("Suggestions:\n")
-			for _, suggestion := range result.Suggestions {
-				fmt.Printf("  - %s\n", suggestion)
-			}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
 		}
+		positional = append(positional, args[0])
+		args = args[1:]
 	}
-}
 
-func processBatchFile(filename string) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatalf("Error opening file: %v", err)
+	if len(positional) < min || max >= 0 && len(positional) > max {
+		fs.SetOutput(os.Stderr)
+		fs.Usage()
+		return nil, exitUsage, false
 	}
-	defer file.Close()
-
-	var phones []string
-	scanner := bufio.NewScanner(file)
+	fs.SetOutput(os.Stderr)
+	return positional, exitValid, true
+}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" && !strings.HasPrefix(line, "#") {
-			phones = append(phones, line)
+// isFlagSet проверяет, указан ли флаг name в командной строке
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
 		}
-	}
+	})
+	return set
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatalf("Error reading file: %v", err)
-	}
+// usageError сообщает об ошибке в аргументах команды
+func usageError(fs *flag.FlagSet, format string, args ...any) int {
+	fmt.Fprintf(os.Stderr, "mnv %s: %s\n", fs.Name(), fmt.Sprintf(format, args...))
+	return exitUsage
+}
 
-	if len(phones) == 0 {
-		fmt.Println("No phone numbers found in file")
-		return
+// ioError сообщает об ошибке ввода-вывода
+func ioError(format string, args ...any) int {
+	fmt.Fprintf(os.Stderr, "mnv: %s\n", fmt.Sprintf(format, args...))
+	return exitIO
+}
+
+// processingError сообщает об ошибке обработки файла filename командой command:
+// неверно выбранная колонка таблицы - ошибка в аргументах, остальное - ошибка ввода-вывода
+func processingError(command, filename string, err error) int {
+	var columnErr *mnv.CSVColumnError
+	if errors.As(err, &columnErr) {
+		fmt.Fprintf(os.Stderr, "mnv %s: %v\n", command, err)
+		return exitUsage
 	}
+	return ioError("error processing %s: %v", filename, err)
+}
 
-	fmt.Printf("Processing %d phone numbers from %s...\n\n", len(phones), filename)
+// configFlag добавляет флаг -config с предустановкой конфигурации
+func configFlag(fs *flag.FlagSet) *string {
+	return fs.String("config", "", "Configuration preset: "+strings.Join(presetNames(), ", ")+" (default \"default\")")
+}
 
-	request := &mnv.BatchValidationRequest{
-		Phones:   phones,
-		Parallel: 10,
-		Options: &mnv.ValidationOptions{
-			ReturnSuggestions: *suggestions,
-			MaxSuggestions:    3,
-		},
+// applyConfig устанавливает предустановку конфигурации, выбранную флагом -config.
+// Без флага конфигурация не меняется (в режиме repl действует выбранная командой config)
+func applyConfig(fs *flag.FlagSet, preset string) (int, bool) {
+	if preset == "" {
+		return exitValid, true
 	}
+	if err := mnv.SetPresetConfig(preset); err != nil {
+		return usageError(fs, "invalid config preset %q (available: %s)", preset, strings.Join(presetNames(), ", ")), false
+	}
+	return exitValid, true
+}
 
-	response := mnv.BatchValidatePhones(request)
+// presetNames возвращает отсортированные имена предустановок конфигурации
+func presetNames() []string {
+	names := mnv.ListPresets()
+	sort.Strings(names)
+	return names
+}
 
-	if *format == "json" {
-		data, _ := json.MarshalIndent(response, "", "  ")
-		fmt.Println(string(data))
-		return
-	}
+// formatFlag добавляет флаг -format с форматами вывода formats (первый - по умолчанию)
+func formatFlag(fs *flag.FlagSet, formats ...string) *string {
+	return fs.String("format", formats[0], "Output format: "+strings.Join(formats, ", "))
+}
 
-	// Текстовый отчет
-	fmt.Printf("Batch Validation Results:\n")
-	fmt.Printf("========================\n")
-	fmt.Printf("Total: %d | Valid: %d | Invalid: %d | Processing Time: %s\n\n",
-		response.Stats.Total,
-		response.Stats.Valid,
-		response.Stats.Invalid,
-		response.ProcessingTime)
-
-	// Статистика по странам
-	if len(response.Stats.ByCountry) > 0 {
-		fmt.Printf("By Country:\n")
-		for country, count := range response.Stats.ByCountry {
-			if info, exists := mnv.GetCountryInfo(country); exists {
-				fmt.Printf("  %s (%s): %d\n", info.CountryName, strings.ToUpper(country), count)
-			} else {
-				fmt.Printf("  %s: %d\n", strings.ToUpper(country), count)
-			}
+// checkFormat проверяет значение флага -format
+func checkFormat(fs *flag.FlagSet, format string, formats ...string) (int, bool) {
+	for _, known := range formats {
+		if format == known {
+			return exitValid, true
 		}
-		fmt.Println()
 	}
+	return usageError(fs, "unknown output format %q (available: %s)", format, strings.Join(formats, ", ")), false
+}
 
-	// Детальные результаты
-	if *verbose {
-		fmt.Printf("Detailed Results:\n")
-		fmt.Printf("-----------------\n")
-		for i, result := range response.Results {
-			status := "❌"
-			if result.IsValid {
-				status = "✅"
-			}
-			fmt.Printf("%d. %s %s", i+1, status, result.OriginalNumber)
-
-			if result.CountryCode != "" {
-				fmt.Printf(" (%s)", strings.ToUpper(result.CountryCode))
-			}
-
-			if !result.IsValid && result.ErrorMessage != "" {
-				fmt.Printf(" - %s", result.ErrorMessage)
-			}
-
-			fmt.Println()
-		}
+// openInput открывает файл для чтения; "-" означает stdin
+func openInput(filename string) (io.ReadCloser, error) {
+	if filename == "-" {
+		return io.NopCloser(os.Stdin), nil
 	}
+	return os.Open(filename)
 }
 
-func runInteractiveMode() {
-	fmt.Println("Mobile Number Validator - Interactive Mode")
-	fmt.Println("Commands:")
-	fmt.Println("  validate <phone> [country] - Validate a phone number")
-	fmt.Println("  info <phone>              - Get phone information")
-	fmt.Println("  countries                 - List supported countries")
-	fmt.Println("  config <preset>           - Change configuration")
-	fmt.Println("  help                      - Show this help")
-	fmt.Println("  quit                      - Exit")
-	fmt.Println()
-
-	scanner := bufio.NewScanner(os.Stdin)
-
-	for {
-		fmt.Print("mnv> ")
-		if !scanner.Scan() {
-			break
-		}
+// nopWriteCloser io.WriteCloser, не закрывающий stdout
+type nopWriteCloser struct {
+	io.Writer
+}
 
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
+func (nopWriteCloser) Close() error {
+	return nil
+}
 
-		parts := strings.Fields(line)
-		command := parts[0]
-
-		switch command {
-		case "validate":
-			if len(parts) < 2 {
-				fmt.Println("Usage: validate <phone> [country]")
-				continue
-			}
-
-			phone := parts[1]
-			country := ""
-			if len(parts) > 2 {
-				country = parts[2]
-			}
-
-			validateSinglePhone(phone, country)
-
-		case "info":
-			if len(parts) < 2 {
-				fmt.Println("Usage: info <phone>")
-				continue
-			}
-
-			phoneInfo := mnv.GetPhoneInfo(parts[1])
-			data, _ := json.MarshalIndent(phoneInfo, "", "  ")
-			fmt.Println(string(data))
-
-		case "countries":
-			listSupportedCountries()
-
-		case "config":
-			if len(parts) < 2 {
-				fmt.Println("Available presets:", strings.Join(mnv.ListPresets(), ", "))
-				fmt.Println("Current config:")
-				printConfig()
-				continue
-			}
-
-			preset := parts[1]
-			if err := mnv.SetPresetConfig(preset); err != nil {
-				fmt.Printf("Error: %v\n", err)
-			} else {
-				fmt.Printf("Configuration changed to: %s\n", preset)
-				printConfig()
-			}
-
-		case "help":
-			fmt.Println("Commands:")
-			fmt.Println("  validate <phone> [country] - Validate a phone number")
-			fmt.Println("  info <phone>              - Get phone information")
-			fmt.Println("  countries                 - List supported countries")
-			fmt.Println("  config <preset>           - Change configuration")
-			fmt.Println("  help                      - Show this help")
-			fmt.Println("  quit                      - Exit")
-
-		case "quit", "exit":
-			fmt.Println("Goodbye!")
-			return
+// createOutput создает файл результата; пустое имя означает stdout
+func createOutput(filename string) (io.WriteCloser, error) {
+	if filename == "" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return os.Create(filename)
+}
 
+// tableSeparator определяет разделитель полей табличного файла по -batch-format или
+// расширению файла; false - файл содержит по одному номеру в строке. При "auto" файлы
+// с расширениями, отличными от .csv и .tsv, читаются по строкам
+func tableSeparator(filename, format string) (rune, bool, error) {
+	if format == "auto" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".csv", ".tsv", ".tab":
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
 		default:
-			fmt.Printf("Unknown command: %s. Type 'help' for available commands.\n", command)
+			format = "lines"
 		}
+	}
 
-		fmt.Println()
+	switch format {
+	case "csv":
+		return ',', true, nil
+	case "tsv", "tab":
+		return '\t', true, nil
+	case "lines", "txt", "":
+		return 0, false, nil
+	default:
+		return 0, false, fmt.Errorf("unknown batch format %q", format)
 	}
 }
+
+// writeJSON выводит значение с отступами
+func writeJSON(w io.Writer, value any) {
+	data, _ := json.MarshalIndent(value, "", "  ")
+	fmt.Fprintln(w, string(data))
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableSeparator(t *testing.T) {
+	tests := []struct {
+		filename string
+		format   string
+		comma    rune
+		table    bool
+		err      bool
+	}{
+		{"phones.csv", "auto", ',', true, false},
+		{"PHONES.TSV", "auto", '\t', true, false},
+		{"phones.txt", "auto", 0, false, false},
+		{"phones.list", "auto", 0, false, false},
+		{"phones", "auto", 0, false, false},
+		{"-", "auto", 0, false, false},
+		{"phones.list", "csv", ',', true, false},
+		{"phones.csv", "lines", 0, false, false},
+		{"phones.csv", "tsv", '\t', true, false},
+		{"phones.csv", "xls", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename+"/"+tt.format, func(t *testing.T) {
+			comma, table, err := tableSeparator(tt.filename, tt.format)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.comma, comma)
+			assert.Equal(t, tt.table, table)
+		})
+	}
+}
+
+// cliResult результат запуска CLI в тесте
+type cliResult struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// runCLI выполняет run(args) со stdin из строки input и перехватывает stdout и stderr.
+// При readOnlyStdout запись в stdout завершается ошибкой
+func runCLI(t *testing.T, input string, readOnlyStdout bool, args ...string) cliResult {
+	t.Helper()
+	t.Cleanup(func() { mnv.SetConfig(mnv.DefaultConfig()) })
+
+	dir := t.TempDir()
+	stdinPath := filepath.Join(dir, "stdin")
+	require.NoError(t, os.WriteFile(stdinPath, []byte(input), 0o600))
+	stdin, err := os.Open(stdinPath)
+	require.NoError(t, err)
+	defer stdin.Close()
+
+	stdoutPath := filepath.Join(dir, "stdout")
+	stdout, err := os.Create(stdoutPath)
+	require.NoError(t, err)
+	if readOnlyStdout {
+		require.NoError(t, stdout.Close())
+		stdout, err = os.Open(stdoutPath)
+		require.NoError(t, err)
+	}
+	defer stdout.Close()
+
+	stderr, err := os.Create(filepath.Join(dir, "stderr"))
+	require.NoError(t, err)
+	defer stderr.Close()
+
+	savedStdin, savedStdout, savedStderr := os.Stdin, os.Stdout, os.Stderr
+	os.Stdin, os.Stdout, os.Stderr = stdin, stdout, stderr
+	defer func() { os.Stdin, os.Stdout, os.Stderr = savedStdin, savedStdout, savedStderr }()
+
+	code := run(args)
+
+	out, err := os.ReadFile(stdoutPath)
+	require.NoError(t, err)
+	errOut, err := os.ReadFile(filepath.Join(dir, "stderr"))
+	require.NoError(t, err)
+	return cliResult{code: code, stdout: string(out), stderr: string(errOut)}
+}
+
+// writeTestFile записывает файл во временный каталог теста
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestRunExitCodes(t *testing.T) {
+	validLines := writeTestFile(t, "valid.txt", "+996700123456\n# comment\n\n+79991234567\n")
+	mixedLines := writeTestFile(t, "mixed.list", "+996700123456\nnot a phone\n")
+	validCSV := writeTestFile(t, "valid.csv", "name,phone\nAnna,+996700123456\nBob,+79991234567\n")
+	mixedCSV := writeTestFile(t, "mixed.csv", "name,phone\nAnna,+996700123456\nBob,123\n")
+	duplicates := writeTestFile(t, "dupes.csv", "phone\n+996700123456\n0700123456\n")
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	unwritable := filepath.Join(t.TempDir(), "missing", "out.txt")
+
+	tests := []struct {
+		name  string
+		args  []string
+		input string
+		code  int
+	}{
+		// Команды и справка
+		{"no command", nil, "", exitUsage},
+		{"unknown command", []string{"check"}, "", exitUsage},
+		{"usage", []string{"-h"}, "", exitValid},
+		{"help command", []string{"help", "batch"}, "", exitValid},
+		{"help unknown command", []string{"help", "check"}, "", exitUsage},
+		{"command help", []string{"validate", "-h"}, "", exitValid},
+
+		// validate
+		{"validate valid", []string{"validate", "+996700123456", "+79991234567"}, "", exitValid},
+		{"validate invalid", []string{"validate", "+996700123456", "+996123"}, "", exitInvalid},
+		{"validate with country", []string{"validate", "-country=kg", "+996700123456"}, "", exitValid},
+		{"validate wrong country", []string{"validate", "-country=ru", "+996700123456"}, "", exitInvalid},
+		{"validate no phone", []string{"validate"}, "", exitUsage},
+		{"validate unknown flag", []string{"validate", "-strict", "+996700123456"}, "", exitUsage},
+		{"validate unknown format", []string{"validate", "-format=xml", "+996700123456"}, "", exitUsage},
+		{"validate unknown preset", []string{"validate", "-config=lenient", "+996700123456"}, "", exitUsage},
+
+		// parse, format, info, detect
+		{"parse valid", []string{"parse", "-region=kg", "0700123456"}, "", exitValid},
+		{"parse invalid", []string{"parse", "abc"}, "", exitInvalid},
+		{"format valid", []string{"format", "-style=national", "+996700123456"}, "", exitValid},
+		{"format unknown style", []string{"format", "-style=short", "+996700123456"}, "", exitUsage},
+		{"info valid", []string{"info", "+996700123456"}, "", exitValid},
+		{"info invalid", []string{"info", "+000"}, "", exitInvalid},
+		{"detect valid", []string{"detect", "+996700123456"}, "", exitValid},
+		{"detect invalid", []string{"detect", "+000"}, "", exitInvalid},
+		{"detect two phones", []string{"detect", "+996700123456", "+79991234567"}, "", exitUsage},
+
+		// batch
+		{"batch valid lines", []string{"batch", validLines}, "", exitValid},
+		{"batch invalid lines", []string{"batch", mixedLines}, "", exitInvalid},
+		{"batch stdin", []string{"batch", "-format=ndjson", "-"}, "+996700123456\n", exitValid},
+		{"batch valid csv", []string{"batch", validCSV}, "", exitValid},
+		{"batch invalid csv", []string{"batch", "-phone-column=phone", mixedCSV}, "", exitInvalid},
+		{"batch csv column out of range", []string{"batch", "-phone-column=5", validCSV}, "", exitUsage},
+		{"batch csv unknown column", []string{"batch", "-phone-column=mobile", validCSV}, "", exitUsage},
+		{"batch format for csv", []string{"batch", "-format=json", validCSV}, "", exitUsage},
+		{"batch unknown batch format", []string{"batch", "-batch-format=xls", validLines}, "", exitUsage},
+		{"batch no file", []string{"batch"}, "", exitUsage},
+		{"batch missing file", []string{"batch", missing}, "", exitIO},
+		{"batch unwritable output", []string{"batch", "-format=csv", "-output", unwritable, validLines}, "", exitIO},
+
+		// filter
+		{"filter match", []string{"filter", "-valid"}, "+996700123456\nbad\n", exitValid},
+		{"filter no match", []string{"filter", "-valid", "-country=ru"}, "+996700123456\n", exitInvalid},
+		{"filter file", []string{"filter", "-invalid", mixedLines}, "", exitValid},
+		{"filter valid and invalid", []string{"filter", "-valid", "-invalid"}, "", exitUsage},
+		{"filter country of invalid", []string{"filter", "-country=kg"}, "bad\n0700\n", exitInvalid},
+		{"filter invalid and country", []string{"filter", "-invalid", "-country=kg"}, "", exitUsage},
+		{"filter unknown country", []string{"filter", "-country=atlantis"}, "", exitUsage},
+		{"filter unknown type", []string{"filter", "-type=pager"}, "", exitUsage},
+		{"filter missing file", []string{"filter", missing}, "", exitIO},
+
+		// report
+		{"report lines", []string{"report", mixedLines}, "", exitValid},
+		{"report csv", []string{"report", "-format=json", validCSV}, "", exitValid},
+		{"report csv column out of range", []string{"report", "-phone-column=3", validCSV}, "", exitUsage},
+		{"report unknown format", []string{"report", "-format=pdf", validLines}, "", exitUsage},
+		{"report missing file", []string{"report", missing}, "", exitIO},
+		{"report unwritable output", []string{"report", "-output", unwritable, validLines}, "", exitIO},
+
+		// dedupe
+		{"dedupe csv", []string{"dedupe", duplicates, "--column", "phone", "-region=kg"}, "", exitValid},
+		{"dedupe lines", []string{"dedupe", "-level=nsn", mixedLines}, "", exitValid},
+		{"dedupe unknown level", []string{"dedupe", "-level=fuzzy", duplicates}, "", exitUsage},
+		{"dedupe unknown column", []string{"dedupe", "-column=mobile", duplicates}, "", exitUsage},
+		{"dedupe missing file", []string{"dedupe", missing}, "", exitIO},
+		{"dedupe unwritable output", []string{"dedupe", "-output", unwritable, duplicates}, "", exitIO},
+
+		// countries, config, repl
+		{"countries", []string{"countries", "-format=json"}, "", exitValid},
+		{"countries extra argument", []string{"countries", "kg"}, "", exitUsage},
+		{"config", []string{"config", "strict"}, "", exitValid},
+		{"config unknown preset", []string{"config", "lenient"}, "", exitUsage},
+		{"repl", []string{"repl"}, "validate +996700123456\nquit\n", exitValid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runCLI(t, tt.input, false, tt.args...)
+			assert.Equal(t, tt.code, result.code, "stdout:\n%s\nstderr:\n%s", result.stdout, result.stderr)
+			if tt.code == exitUsage || tt.code == exitIO {
+				assert.NotEmpty(t, result.stderr, "errors are reported on stderr")
+			}
+		})
+	}
+}
+
+func TestRunWriteErrors(t *testing.T) {
+	validLines := writeTestFile(t, "valid.txt", "+996700123456\n+79991234567\n")
+
+	for _, args := range [][]string{
+		{"validate", "-format=ndjson", "+996700123456"},
+		{"batch", "-format=ndjson", validLines},
+		{"batch", "-format=csv", validLines},
+		{"filter", "-valid", validLines},
+		{"report", validLines},
+		{"dedupe", validLines},
+	} {
+		t.Run(strings.Join(args[:2], " "), func(t *testing.T) {
+			result := runCLI(t, "", true, args...)
+			assert.Equal(t, exitIO, result.code, result.stderr)
+		})
+	}
+}
+
+// failingWriter вывод результатов, запись в который всегда завершается ошибкой
+type failingWriter struct{}
+
+func (failingWriter) write(*mnv.ValidationResult) error {
+	return errors.New("broken pipe")
+}
+
+func TestWriteBatchLinesStopsOnWriteError(t *testing.T) {
+	results := make(chan mnv.StreamResult)
+	done := make(chan struct{})
+	sent := make(chan int)
+	go func() {
+		count := 0
+		defer func() { sent <- count }()
+		for i := 0; i < 100; i++ {
+			select {
+			case results <- mnv.StreamResult{Index: i, Result: &mnv.ValidationResult{}}:
+				count++
+			case <-done:
+				return
+			}
+		}
+		close(results)
+	}()
+
+	_, err := writeBatchLines(results, failingWriter{})
+	close(done)
+	assert.EqualError(t, err, "broken pipe")
+	assert.Equal(t, 1, <-sent, "the stream is not drained after a write error")
+}
+
+func TestRunOutput(t *testing.T) {
+	result := runCLI(t, "", false, "validate", "-format=ndjson", "+996700123456", "+996123")
+	require.Equal(t, exitInvalid, result.code)
+	lines := strings.Split(strings.TrimSpace(result.stdout), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"is_valid":true`)
+	assert.Contains(t, lines[1], `"is_valid":false`)
+
+	result = runCLI(t, "+996700123456\nbad\n0555123456\n", false, "filter", "-valid", "-region=kg", "-e164")
+	require.Equal(t, exitValid, result.code)
+	assert.Equal(t, "+996700123456\n+996555123456\n", result.stdout)
+
+	result = runCLI(t, "bad\n", false, "filter", "-country=kg")
+	require.Equal(t, exitInvalid, result.code)
+	assert.Empty(t, result.stdout, "the region hint of an invalid number is not its country")
+
+	input := `{"id":12345678901234567,"phone":"0700123456","amount":1e2,"note":"<a&b>"}` + "\n" +
+		`{ "phone" : "+7 999 123 45 67" , "tags" : [ 1, 2 ] }` + "\n" +
+		`{"phone":1,"phone":"0555123456"}` + "\n"
+	result = runCLI(t, input, false, "filter", "-valid", "-region=kg", "-e164")
+	require.Equal(t, exitValid, result.code, result.stderr)
+	assert.Equal(t,
+		`{"id":12345678901234567,"phone":"+996700123456","amount":1e2,"note":"<a&b>"}`+"\n"+
+			`{ "phone" : "+79991234567" , "tags" : [ 1, 2 ] }`+"\n"+
+			`{"phone":1,"phone":"+996555123456"}`+"\n",
+		result.stdout, "only the phone value is rewritten")
+
+	// Строка длиннее буфера bufio.Scanner по умолчанию и прежнего предела в 1 МиБ
+	long := `{"note":"` + strings.Repeat("x", 2<<20) + `","phone":"0700123456"}`
+	result = runCLI(t, long+"\n", false, "filter", "-valid", "-region=kg", "-e164")
+	require.Equal(t, exitValid, result.code, result.stderr)
+	assert.Equal(t, strings.Replace(long, "0700123456", "+996700123456", 1)+"\n", result.stdout)
+
+	result = runCLI(t, "", false, "format", "-style=e164", "+996 700 123 456")
+	require.Equal(t, exitValid, result.code)
+	assert.Equal(t, "+996700123456", strings.TrimSpace(result.stdout))
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// resultFormats форматы вывода результатов проверки; text и json выводятся командами,
+// остальные - построчно через resultWriter
+var resultFormats = []string{"text", "json", "ndjson", "csv", "table"}
+
+// resultWriter построчный вывод результатов проверки. Каждая строка записывается
+// сразу, поэтому вывод можно читать по мере проверки (tail -f, конвейеры)
+type resultWriter interface {
+	// write выводит результат
+	write(result *mnv.ValidationResult) error
+}
+
+// newResultWriter создает вывод в формате ndjson, csv или table.
+// Колонки columns используются форматом csv
+func newResultWriter(w io.Writer, format string, columns []string) (resultWriter, error) {
+	switch format {
+	case "ndjson":
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case "csv":
+		for _, column := range columns {
+			if _, ok := mnv.ColumnValue(&mnv.ValidationResult{}, column); !ok {
+				return nil, fmt.Errorf("unknown column %q", column)
+			}
+		}
+		writer := &csvResultWriter{writer: csv.NewWriter(w), columns: columns}
+		return writer, writer.record(append([]string{"phone"}, columns...))
+	case "table":
+		writer := &tableWriter{w: w}
+		return writer, writer.row([]string{"PHONE", "VALID", "E164", "COUNTRY", "TYPE", "ERROR"})
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// ndjsonWriter выводит результаты объектами JSON по одному в строке
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonWriter) write(result *mnv.ValidationResult) error {
+	return n.encoder.Encode(result)
+}
+
+// csvResultWriter выводит результаты строками CSV: исходный номер и колонки результата
+type csvResultWriter struct {
+	writer  *csv.Writer
+	columns []string
+}
+
+func (c *csvResultWriter) write(result *mnv.ValidationResult) error {
+	record := make([]string, 0, len(c.columns)+1)
+	record = append(record, result.OriginalNumber)
+	for _, column := range c.columns {
+		value, _ := mnv.ColumnValue(result, column)
+		record = append(record, value)
+	}
+	return c.record(record)
+}
+
+// record записывает строку и сразу сбрасывает буфер
+func (c *csvResultWriter) record(record []string) error {
+	if err := c.writer.Write(record); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// tableColumnWidths ширина колонок таблицы; последняя колонка не выравнивается.
+// Ширина фиксирована, чтобы строки выводились сразу, без ожидания всей таблицы
+var tableColumnWidths = []int{20, 5, 16, 7, 10}
+
+// tableWriter выводит результаты выровненной текстовой таблицей
+type tableWriter struct {
+	w io.Writer
+}
+
+func (t *tableWriter) write(result *mnv.ValidationResult) error {
+	e164, _ := mnv.ColumnValue(result, mnv.ColumnE164)
+	phoneType, _ := mnv.ColumnValue(result, mnv.ColumnType)
+	return t.row([]string{
+		result.OriginalNumber,
+		yesNo(result.IsValid),
+		e164,
+		strings.ToUpper(result.CountryCode),
+		phoneType,
+		result.ErrorMessage,
+	})
+}
+
+// row выводит строку таблицы
+func (t *tableWriter) row(cells []string) error {
+	var line strings.Builder
+	for i, cell := range cells {
+		if i < len(tableColumnWidths) {
+			fmt.Fprintf(&line, "%-*s  ", tableColumnWidths[i], cell)
+		} else {
+			line.WriteString(cell)
+		}
+	}
+	_, err := fmt.Fprintln(t.w, strings.TrimRight(line.String(), " "))
+	return err
+}
+
+// yesNo возвращает "yes" или "no"
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// replHelp список команд интерактивного режима
+const replHelp = `Commands:
+  validate <phone> [country] - Validate a phone number
+  parse <phone> [region]     - Parse a number into E.164 and formats
+  format <phone> [style]     - Format a number (e164, international, national, rfc3966)
+  info <phone>               - Get phone information
+  detect <phone>             - Detect the country of a number
+  countries                  - List supported countries
+  config [preset]            - Show or change configuration
+  help                       - Show this help
+  quit                       - Exit`
+
+// runREPL выполняет команду repl: интерактивный режим. Команды выполняются
+// так же, как одноименные подкоманды CLI
+//
+//	mnv repl [flags]
+func runREPL(args []string) int {
+	fs := newFlagSet("repl", "", "Run interactive mode.")
+	lang := fs.String("lang", "en", "Language for country names: en, ru, kg, kz, uz")
+	preset := configFlag(fs)
+	if _, code, ok := parseFlags(fs, args, 0, 0); !ok {
+		return code
+	}
+	if code, ok := applyConfig(fs, *preset); !ok {
+		return code
+	}
+
+	fmt.Println("Mobile Number Validator - Interactive Mode")
+	fmt.Println(replHelp)
+	fmt.Println()
+
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for {
+		fmt.Print("mnv> ")
+		if !scanner.Scan() {
+			break
+		}
+
+		parts := strings.Fields(scanner.Text())
+		if len(parts) == 0 {
+			continue
+		}
+
+		command, arguments := parts[0], parts[1:]
+		langArgs := []string{"-lang", *lang}
+
+		switch command {
+		case "validate":
+			if len(arguments) < 1 || len(arguments) > 2 {
+				fmt.Println("Usage: validate <phone> [country]")
+				continue
+			}
+			if len(arguments) == 2 {
+				langArgs = append(langArgs, "-country", arguments[1])
+			}
+			runValidate(append(langArgs, arguments[0]))
+
+		case "parse":
+			if len(arguments) < 1 || len(arguments) > 2 {
+				fmt.Println("Usage: parse <phone> [region]")
+				continue
+			}
+			if len(arguments) == 2 {
+				langArgs = append(langArgs, "-region", arguments[1])
+			}
+			runParse(append(langArgs, arguments[0]))
+
+		case "format":
+			if len(arguments) < 1 || len(arguments) > 2 {
+				fmt.Println("Usage: format <phone> [style]")
+				continue
+			}
+			styleArgs := []string{arguments[0]}
+			if len(arguments) == 2 {
+				styleArgs = append([]string{"-style", arguments[1]}, styleArgs...)
+			}
+			runFormat(styleArgs)
+
+		case "info":
+			if len(arguments) != 1 {
+				fmt.Println("Usage: info <phone>")
+				continue
+			}
+			runInfo(append(langArgs, arguments[0]))
+
+		case "detect":
+			if len(arguments) != 1 {
+				fmt.Println("Usage: detect <phone>")
+				continue
+			}
+			runDetect(append(langArgs, arguments[0]))
+
+		case "countries":
+			runCountries(langArgs)
+
+		case "config":
+			if len(arguments) == 0 {
+				fmt.Println("Available presets:", strings.Join(presetNames(), ", "))
+				fmt.Println("Current config:")
+				printConfig(os.Stdout, mnv.GetConfig())
+				continue
+			}
+
+			preset := arguments[0]
+			if err := mnv.SetPresetConfig(preset); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			} else {
+				fmt.Printf("Configuration changed to: %s\n", preset)
+				printConfig(os.Stdout, mnv.GetConfig())
+			}
+
+		case "help":
+			fmt.Println(replHelp)
+
+		case "quit", "exit":
+			fmt.Println("Goodbye!")
+			return exitValid
+
+		default:
+			fmt.Printf("Unknown command: %s. Type 'help' for available commands.\n", command)
+		}
+
+		fmt.Println()
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ioError("error reading input: %v", err)
+	}
+	return exitValid
+}
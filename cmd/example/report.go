@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// runReport выполняет команду report: проверяет номера файла и выводит отчет о качестве данных
+//
+//	mnv report [flags] <file|->
+func runReport(args []string) int {
+	fs := newFlagSet("report", "<file|->", "Validate numbers from a file or stdin (-) and print a data-quality report.")
+	reportFormat := fs.String("format", string(mnv.ReportFormatMarkdown), "Report format: json, markdown, html")
+	reportOutput := fs.String("output", "", "Output file (default stdout)")
+	reportRegion := fs.String("region", "", "Default country for numbers in national format")
+	reportBatchFormat := fs.String("batch-format", "auto", "Input format: auto (by extension), lines, csv, tsv")
+	reportPhoneColumn := fs.String("phone-column", "", "CSV/TSV phone column name or number starting at 1")
+	reportCountryColumn := fs.String("country-column", "", "CSV/TSV country column name or number (optional)")
+	reportNoHeader := fs.Bool("no-header", false, "CSV/TSV file has no header row")
+	reportConfig := configFlag(fs)
+	positional, code, ok := parseFlags(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+	filename := positional[0]
+
+	format := mnv.ReportFormat(strings.ToLower(*reportFormat))
+	switch format {
+	case "md":
+		format = mnv.ReportFormatMarkdown
+	case mnv.ReportFormatJSON, mnv.ReportFormatMarkdown, mnv.ReportFormatHTML:
+	default:
+		return usageError(fs, "unknown report format %q", format)
+	}
+	if code, ok := applyConfig(fs, *reportConfig); !ok {
+		return code
+	}
+	comma, table, err := tableSeparator(filename, *reportBatchFormat)
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	input, err := openInput(filename)
+	if err != nil {
+		return ioError("error opening file: %v", err)
+	}
+	defer input.Close()
+
+	builder := mnv.NewReportBuilder()
+	if table {
+		_, err := mnv.EnrichCSV(context.Background(), input, io.Discard, &mnv.CSVOptions{
+			Comma:         comma,
+			NoHeader:      *reportNoHeader,
+			PhoneColumn:   *reportPhoneColumn,
+			CountryColumn: *reportCountryColumn,
+			DefaultRegion: *reportRegion,
+			Columns:       []string{mnv.ColumnIsValid},
+			OnResult:      builder.Add,
+		})
+		if err != nil {
+			return processingError("report", filename, err)
+		}
+	} else {
+		phones, readErr := phoneLines(context.Background(), input)
+		results := mnv.ValidateStream(context.Background(), phones, &mnv.StreamOptions{DefaultRegion: *reportRegion})
+		for message := range results {
+			if message.Result != nil {
+				builder.Add(message.Result)
+			}
+		}
+		if err := readErr(); err != nil {
+			return ioError("error reading file: %v", err)
+		}
+	}
+
+	out, err := createOutput(*reportOutput)
+	if err != nil {
+		return ioError("error creating output file: %v", err)
+	}
+	defer out.Close()
+	if err := builder.Report().Render(out, format); err != nil {
+		return ioError("error writing report: %v", err)
+	}
+	return exitValid
+}
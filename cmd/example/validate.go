@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// runValidate выполняет команду validate: проверяет номера и завершается с кодом 1,
+// если хотя бы один номер невалиден
+//
+//	mnv validate [flags] <phone>...
+func runValidate(args []string) int {
+	fs := newFlagSet("validate", "<phone>...", "Validate phone numbers. Exits with 1 if any number is invalid.")
+	country := fs.String("country", "", "Country code or name (default: detected from the number)")
+	suggestions := fs.Bool("suggestions", false, "Show correction suggestions for invalid numbers")
+	quiet := fs.Bool("quiet", false, "Print nothing, only set the exit code")
+	format := formatFlag(fs, resultFormats...)
+	lang := fs.String("lang", "en", "Language for country names: en, ru, kg, kz, uz")
+	preset := configFlag(fs)
+	phones, code, ok := parseFlags(fs, args, 1, -1)
+	if !ok {
+		return code
+	}
+	if code, ok := checkFormat(fs, *format, resultFormats...); !ok {
+		return code
+	}
+	if code, ok := applyConfig(fs, *preset); !ok {
+		return code
+	}
+
+	options := &mnv.ValidationOptions{
+		ReturnSuggestions: *suggestions,
+		MaxSuggestions:    5,
+	}
+
+	results := make([]*mnv.ValidationResult, 0, len(phones))
+	code = exitValid
+	for _, phone := range phones {
+		result := validatePhone(phone, *country, options)
+		if !result.IsValid {
+			code = exitInvalid
+		}
+		results = append(results, result)
+	}
+
+	switch {
+	case *quiet:
+	case *format == "ndjson" || *format == "csv" || *format == "table":
+		writer, err := newResultWriter(os.Stdout, *format, mnv.EnrichColumns)
+		for i := 0; err == nil && i < len(results); i++ {
+			err = writer.write(results[i])
+		}
+		if err != nil {
+			return ioError("error writing results: %v", err)
+		}
+	case *format == "json" && len(results) == 1:
+		writeJSON(os.Stdout, results[0])
+	case *format == "json":
+		writeJSON(os.Stdout, results)
+	default:
+		for i, result := range results {
+			if i > 0 {
+				fmt.Println()
+			}
+			writeValidationText(os.Stdout, result, *lang)
+		}
+	}
+	return code
+}
+
+// validatePhone проверяет номер; без страны она определяется по номеру
+func validatePhone(phone, country string, options *mnv.ValidationOptions) *mnv.ValidationResult {
+	if country != "" {
+		return mnv.ValidatePhone(phone, country, options)
+	}
+
+	// Пытаемся определить страну автоматически
+	detectedCountry, found := mnv.GetCountryByPhone(phone)
+	if !found {
+		return &mnv.ValidationResult{
+			IsValid:        false,
+			OriginalNumber: phone,
+			ErrorMessage:   "Cannot determine country for phone number",
+		}
+	}
+	return mnv.ValidatePhone(phone, detectedCountry, options)
+}
+
+// writeValidationText выводит результат проверки в текстовом виде
+func writeValidationText(w io.Writer, result *mnv.ValidationResult, lang string) {
+	fmt.Fprintf(w, "Phone Number: %s\n", result.OriginalNumber)
+	if result.CountryCode != "" {
+		fmt.Fprintf(w, "Country: %s (%s)\n", mnv.CountryName(result.CountryCode, lang), strings.ToUpper(result.CountryCode))
+	}
+
+	if result.IsValid {
+		fmt.Fprintf(w, "Status: ✅ VALID\n")
+		if result.FormattedNumber != result.OriginalNumber {
+			fmt.Fprintf(w, "Formatted: %s\n", result.FormattedNumber)
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "Status: ❌ INVALID\n")
+	if result.ErrorMessage != "" {
+		fmt.Fprintf(w, "Error: %s\n", result.ErrorMessage)
+	}
+	if len(result.Suggestions) > 0 {
+		fmt.Fprintf(w, "Suggestions:\n")
+		for _, suggestion := range result.Suggestions {
+			fmt.Fprintf(w, "  - %s\n", suggestion)
+		}
+	}
+}
+
+// parsedNumber результат команды parse
+type parsedNumber struct {
+	E164          string `json:"e164"`
+	CountryCode   string `json:"country_code"`
+	CountryName   string `json:"country_name"`
+	International string `json:"international"`
+	National      string `json:"national"`
+	RFC3966       string `json:"rfc3966"`
+}
+
+// runParse выполняет команду parse: разбирает и проверяет номер
+//
+//	mnv parse [flags] <phone>
+func runParse(args []string) int {
+	fs := newFlagSet("parse", "<phone>", "Parse and validate a number, print it in E.164 and other formats.")
+	region := fs.String("region", "", "Default country for numbers in national format")
+	format := formatFlag(fs, "text", "json")
+	lang := fs.String("lang", "en", "Language for country names: en, ru, kg, kz, uz")
+	preset := configFlag(fs)
+	positional, code, ok := parseFlags(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+	if code, ok := checkFormat(fs, *format, "text", "json"); !ok {
+		return code
+	}
+	if code, ok := applyConfig(fs, *preset); !ok {
+		return code
+	}
+
+	number, err := mnv.ParsePhoneNumber(positional[0], *region)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mnv parse: %v\n", err)
+		return exitInvalid
+	}
+
+	parsed := parsedNumber{
+		E164:          number.E164(),
+		CountryCode:   number.Country(),
+		CountryName:   mnv.CountryName(number.Country(), *lang),
+		International: number.Formatted(mnv.FormatInternational),
+		National:      number.Formatted(mnv.FormatNational),
+		RFC3966:       number.Formatted(mnv.FormatRFC3966),
+	}
+	if *format == "json" {
+		writeJSON(os.Stdout, parsed)
+		return exitValid
+	}
+
+	fmt.Printf("E.164: %s\n", parsed.E164)
+	fmt.Printf("Country: %s (%s)\n", parsed.CountryName, strings.ToUpper(parsed.CountryCode))
+	fmt.Printf("International: %s\n", parsed.International)
+	fmt.Printf("National: %s\n", parsed.National)
+	fmt.Printf("RFC 3966: %s\n", parsed.RFC3966)
+	return exitValid
+}
+
+// runFormat выполняет команду format: выводит номер в заданном формате
+//
+//	mnv format [flags] <phone>...
+func runFormat(args []string) int {
+	fs := newFlagSet("format", "<phone>...", "Print numbers in the given format, one per line.")
+	style := fs.String("style", string(mnv.FormatE164), "Number format: e164, international, national, rfc3966")
+	region := fs.String("region", "", "Default country for numbers in national format")
+	preset := configFlag(fs)
+	phones, code, ok := parseFlags(fs, args, 1, -1)
+	if !ok {
+		return code
+	}
+	if code, ok := applyConfig(fs, *preset); !ok {
+		return code
+	}
+
+	numberFormat := mnv.NumberFormat(strings.ToLower(*style))
+	switch numberFormat {
+	case mnv.FormatE164, mnv.FormatInternational, mnv.FormatNational, mnv.FormatRFC3966:
+	default:
+		return usageError(fs, "unknown number format %q", *style)
+	}
+
+	code = exitValid
+	for _, phone := range phones {
+		formatted, err := mnv.FormatNumber(phone, *region, numberFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "mnv format: %v\n", err)
+			code = exitInvalid
+			continue
+		}
+		fmt.Println(formatted)
+	}
+	return code
+}
+
+// runInfo выполняет команду info: выводит подробную информацию о номере
+//
+//	mnv info [flags] <phone>
+func runInfo(args []string) int {
+	fs := newFlagSet("info", "<phone>", "Show detailed phone information. Exits with 1 if the number is invalid.")
+	format := formatFlag(fs, "text", "json")
+	lang := fs.String("lang", "en", "Language for country names: en, ru, kg, kz, uz")
+	preset := configFlag(fs)
+	positional, code, ok := parseFlags(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+	if code, ok := checkFormat(fs, *format, "text", "json"); !ok {
+		return code
+	}
+	if code, ok := applyConfig(fs, *preset); !ok {
+		return code
+	}
+
+	phoneInfo := mnv.GetPhoneInfo(positional[0])
+	if phoneInfo.CountryCode != "" {
+		phoneInfo.CountryName = mnv.CountryName(phoneInfo.CountryCode, *lang)
+	}
+
+	if *format == "json" {
+		writeJSON(os.Stdout, phoneInfo)
+	} else {
+		fmt.Printf("Phone Number: %s\n", phoneInfo.Number)
+		if phoneInfo.CountryCode != "" {
+			fmt.Printf("Country: %s (%s)\n", phoneInfo.CountryName, strings.ToUpper(phoneInfo.CountryCode))
+		}
+		fmt.Printf("Valid: %t\n", phoneInfo.IsValid)
+		fmt.Printf("Type: %s\n", phoneInfo.Type)
+		fmt.Printf("Prefix: %s\n", phoneInfo.Prefix)
+		fmt.Printf("Local Number: %s\n", phoneInfo.LocalNumber)
+	}
+
+	if !phoneInfo.IsValid {
+		return exitInvalid
+	}
+	return exitValid
+}
+
+// detectedCountry результат команды detect
+type detectedCountry struct {
+	Phone       string `json:"phone"`
+	Found       bool   `json:"found"`
+	CountryCode string `json:"country_code,omitempty"`
+	CountryName string `json:"country_name,omitempty"`
+}
+
+// runDetect выполняет команду detect: определяет страну номера
+//
+//	mnv detect [flags] <phone>
+func runDetect(args []string) int {
+	fs := newFlagSet("detect", "<phone>", "Print the country code of a number. Exits with 1 if the country is unknown.")
+	expected := fs.String("expected", "", "Preferred country for shared codes (+1, +7)")
+	format := formatFlag(fs, "text", "json")
+	lang := fs.String("lang", "en", "Language for country names: en, ru, kg, kz, uz")
+	preset := configFlag(fs)
+	positional, code, ok := parseFlags(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+	if code, ok := checkFormat(fs, *format, "text", "json"); !ok {
+		return code
+	}
+	if code, ok := applyConfig(fs, *preset); !ok {
+		return code
+	}
+
+	detected := detectedCountry{Phone: positional[0]}
+	detected.CountryCode, detected.Found = mnv.DetectCountry(positional[0], &mnv.ValidationOptions{ExpectedCountry: *expected})
+	if detected.Found {
+		detected.CountryName = mnv.CountryName(detected.CountryCode, *lang)
+	}
+
+	switch {
+	case *format == "json":
+		writeJSON(os.Stdout, detected)
+	case detected.Found:
+		fmt.Printf("%s\t%s\n", detected.CountryCode, detected.CountryName)
+	default:
+		fmt.Fprintf(os.Stderr, "mnv detect: cannot determine country of %s\n", positional[0])
+	}
+
+	if !detected.Found {
+		return exitInvalid
+	}
+	return exitValid
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envPrefix префикс переменных окружения сервера
+const envPrefix = "MNV_"
+
+// duration длительность, записываемая в файле конфигурации строкой ("5s", "1m")
+type duration time.Duration
+
+// UnmarshalJSON разбирает длительность из строки или числа наносекунд
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var nanos int64
+		if err := json.Unmarshal(data, &nanos); err != nil {
+			return fmt.Errorf("invalid duration %s", data)
+		}
+		*d = duration(nanos)
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+// serverConfig конфигурация сервера. Источники в порядке приоритета:
+// флаги командной строки, переменные окружения MNV_*, файл JSON (-config-file), значения по умолчанию
+type serverConfig struct {
+	// Addr адрес прослушивания
+	Addr string `json:"addr"`
+
+	// Preset пресет конфигурации валидатора (default, strict, relaxed)
+	Preset string `json:"preset"`
+
+	// ReadTimeout таймаут чтения запроса
+	ReadTimeout duration `json:"read_timeout"`
+
+	// WriteTimeout таймаут записи ответа
+	WriteTimeout duration `json:"write_timeout"`
+
+	// RequestTimeout таймаут обработки запроса API
+	RequestTimeout duration `json:"request_timeout"`
+
+	// ShutdownTimeout время на завершение активных запросов при остановке
+	ShutdownTimeout duration `json:"shutdown_timeout"`
+
+	// MaxBatchSize максимальное количество номеров в пакетном запросе
+	MaxBatchSize int `json:"max_batch_size"`
+
+	// MaxBodyBytes максимальный размер тела запроса
+	MaxBodyBytes int64 `json:"max_body_bytes"`
+
+	// AllowedCountries разрешенные страны или группы стран
+	AllowedCountries []string `json:"allowed_countries"`
+
+	// ForbiddenCountries запрещенные страны или группы стран
+	ForbiddenCountries []string `json:"forbidden_countries"`
+
+	// CacheSize размер кеша результатов в памяти процесса (0 - отключен)
+	CacheSize int `json:"cache_size"`
+
+	// RedisAddr адрес Redis для общего кеша результатов между экземплярами сервиса
+	RedisAddr string `json:"redis_addr"`
+
+	// RedisPassword пароль Redis
+	RedisPassword string `json:"redis_password"`
+}
+
+// defaultServerConfig возвращает конфигурацию по умолчанию
+func defaultServerConfig() serverConfig {
+	return serverConfig{
+		Addr:            ":8080",
+		Preset:          "default",
+		ReadTimeout:     duration(10 * time.Second),
+		WriteTimeout:    duration(30 * time.Second),
+		RequestTimeout:  duration(15 * time.Second),
+		ShutdownTimeout: duration(20 * time.Second),
+		MaxBatchSize:    1000,
+		MaxBodyBytes:    1 << 20,
+	}
+}
+
+// loadConfig собирает конфигурацию из файла, окружения и флагов
+func loadConfig(args []string, getenv func(string) string) (serverConfig, error) {
+	// Первый проход: только путь к файлу конфигурации
+	probe := defaultServerConfig()
+	var configFile string
+	if err := newFlagSet(&probe, &configFile).Parse(args); err != nil {
+		return serverConfig{}, err
+	}
+	if configFile == "" {
+		configFile = getenv(envPrefix + "CONFIG_FILE")
+	}
+
+	cfg := defaultServerConfig()
+	if configFile != "" {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			return serverConfig{}, fmt.Errorf("read config file: %w", err)
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return serverConfig{}, fmt.Errorf("parse config file %s: %w", configFile, err)
+		}
+	}
+
+	if err := applyEnv(&cfg, getenv); err != nil {
+		return serverConfig{}, err
+	}
+
+	// Второй проход: флаги имеют наивысший приоритет
+	if err := newFlagSet(&cfg, &configFile).Parse(args); err != nil {
+		return serverConfig{}, err
+	}
+	return cfg, nil
+}
+
+// newFlagSet создает набор флагов, записывающих значения в cfg
+func newFlagSet(cfg *serverConfig, configFile *string) *flag.FlagSet {
+	fs := flag.NewFlagSet("mnv-server", flag.ContinueOnError)
+	fs.StringVar(configFile, "config-file", *configFile, "JSON configuration file (env "+envPrefix+"CONFIG_FILE)")
+	fs.StringVar(&cfg.Addr, "addr", cfg.Addr, "Listen address (env "+envPrefix+"ADDR)")
+	fs.StringVar(&cfg.Preset, "preset", cfg.Preset, "Validator preset: default, strict, relaxed (env "+envPrefix+"PRESET)")
+	fs.Var((*durationFlag)(&cfg.ReadTimeout), "read-timeout", "Request read timeout (env "+envPrefix+"READ_TIMEOUT)")
+	fs.Var((*durationFlag)(&cfg.WriteTimeout), "write-timeout", "Response write timeout (env "+envPrefix+"WRITE_TIMEOUT)")
+	fs.Var((*durationFlag)(&cfg.RequestTimeout), "request-timeout", "API request timeout (env "+envPrefix+"REQUEST_TIMEOUT)")
+	fs.Var((*durationFlag)(&cfg.ShutdownTimeout), "shutdown-timeout", "Graceful shutdown timeout (env "+envPrefix+"SHUTDOWN_TIMEOUT)")
+	fs.IntVar(&cfg.MaxBatchSize, "max-batch-size", cfg.MaxBatchSize, "Maximum phones per batch request (env "+envPrefix+"MAX_BATCH_SIZE)")
+	fs.Int64Var(&cfg.MaxBodyBytes, "max-body-bytes", cfg.MaxBodyBytes, "Maximum request body size (env "+envPrefix+"MAX_BODY_BYTES)")
+	fs.Var((*listFlag)(&cfg.AllowedCountries), "allowed-countries", "Comma-separated allowed countries or groups (env "+envPrefix+"ALLOWED_COUNTRIES)")
+	fs.Var((*listFlag)(&cfg.ForbiddenCountries), "forbidden-countries", "Comma-separated forbidden countries or groups (env "+envPrefix+"FORBIDDEN_COUNTRIES)")
+	fs.IntVar(&cfg.CacheSize, "cache-size", cfg.CacheSize, "In-process result cache size, 0 disables (env "+envPrefix+"CACHE_SIZE)")
+	fs.StringVar(&cfg.RedisAddr, "redis-addr", cfg.RedisAddr, "Redis address for the shared result cache (env "+envPrefix+"REDIS_ADDR)")
+	fs.StringVar(&cfg.RedisPassword, "redis-password", cfg.RedisPassword, "Redis password (env "+envPrefix+"REDIS_PASSWORD)")
+	return fs
+}
+
+// applyEnv применяет переменные окружения MNV_*
+func applyEnv(cfg *serverConfig, getenv func(string) string) error {
+	strs := map[string]*string{
+		"ADDR":           &cfg.Addr,
+		"PRESET":         &cfg.Preset,
+		"REDIS_ADDR":     &cfg.RedisAddr,
+		"REDIS_PASSWORD": &cfg.RedisPassword,
+	}
+	for name, target := range strs {
+		if value := getenv(envPrefix + name); value != "" {
+			*target = value
+		}
+	}
+
+	durations := map[string]*duration{
+		"READ_TIMEOUT":     &cfg.ReadTimeout,
+		"WRITE_TIMEOUT":    &cfg.WriteTimeout,
+		"REQUEST_TIMEOUT":  &cfg.RequestTimeout,
+		"SHUTDOWN_TIMEOUT": &cfg.ShutdownTimeout,
+	}
+	for name, target := range durations {
+		if value := getenv(envPrefix + name); value != "" {
+			if err := (*durationFlag)(target).Set(value); err != nil {
+				return fmt.Errorf("%s%s: %w", envPrefix, name, err)
+			}
+		}
+	}
+
+	if value := getenv(envPrefix + "MAX_BATCH_SIZE"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%sMAX_BATCH_SIZE: %w", envPrefix, err)
+		}
+		cfg.MaxBatchSize = parsed
+	}
+	if value := getenv(envPrefix + "CACHE_SIZE"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%sCACHE_SIZE: %w", envPrefix, err)
+		}
+		cfg.CacheSize = parsed
+	}
+	if value := getenv(envPrefix + "MAX_BODY_BYTES"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%sMAX_BODY_BYTES: %w", envPrefix, err)
+		}
+		cfg.MaxBodyBytes = parsed
+	}
+
+	if value := getenv(envPrefix + "ALLOWED_COUNTRIES"); value != "" {
+		_ = (*listFlag)(&cfg.AllowedCountries).Set(value)
+	}
+	if value := getenv(envPrefix + "FORBIDDEN_COUNTRIES"); value != "" {
+		_ = (*listFlag)(&cfg.ForbiddenCountries).Set(value)
+	}
+	return nil
+}
+
+// durationFlag флаг длительности
+type durationFlag duration
+
+// String реализует flag.Value
+func (d *durationFlag) String() string {
+	return time.Duration(*d).String()
+}
+
+// Set реализует flag.Value
+func (d *durationFlag) Set(value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = durationFlag(parsed)
+	return nil
+}
+
+// listFlag флаг списка значений через запятую
+type listFlag []string
+
+// String реализует flag.Value
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set реализует flag.Value
+func (l *listFlag) Set(value string) error {
+	*l = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile записывает файл конфигурации во временный каталог теста
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "mnv.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	configFile := writeConfigFile(t, `{
+		"addr": ":7000",
+		"preset": "strict",
+		"read_timeout": "3s",
+		"write_timeout": 4000000000,
+		"max_batch_size": 50,
+		"allowed_countries": ["kg"],
+		"redis_addr": "file:6379"
+	}`)
+
+	tests := []struct {
+		name  string
+		args  []string
+		env   map[string]string
+		check func(t *testing.T, cfg serverConfig)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, cfg serverConfig) {
+				assert.Equal(t, defaultServerConfig(), cfg)
+			},
+		},
+		{
+			name: "config file",
+			args: []string{"-config-file", configFile},
+			check: func(t *testing.T, cfg serverConfig) {
+				assert.Equal(t, ":7000", cfg.Addr)
+				assert.Equal(t, "strict", cfg.Preset)
+				assert.Equal(t, duration(3*time.Second), cfg.ReadTimeout)
+				assert.Equal(t, duration(4*time.Second), cfg.WriteTimeout)
+				assert.Equal(t, 50, cfg.MaxBatchSize)
+				assert.Equal(t, []string{"kg"}, cfg.AllowedCountries)
+				assert.Equal(t, duration(15*time.Second), cfg.RequestTimeout, "unset fields keep defaults")
+			},
+		},
+		{
+			name: "config file from env",
+			env:  map[string]string{"MNV_CONFIG_FILE": configFile},
+			check: func(t *testing.T, cfg serverConfig) {
+				assert.Equal(t, ":7000", cfg.Addr)
+			},
+		},
+		{
+			name: "env",
+			env: map[string]string{
+				"MNV_ADDR":                ":9000",
+				"MNV_PRESET":              "relaxed",
+				"MNV_READ_TIMEOUT":        "1s",
+				"MNV_WRITE_TIMEOUT":       "2s",
+				"MNV_REQUEST_TIMEOUT":     "3s",
+				"MNV_SHUTDOWN_TIMEOUT":    "4s",
+				"MNV_MAX_BATCH_SIZE":      "10",
+				"MNV_MAX_BODY_BYTES":      "2048",
+				"MNV_ALLOWED_COUNTRIES":   "kg, kz,,uz",
+				"MNV_FORBIDDEN_COUNTRIES": "ru",
+				"MNV_CACHE_SIZE":          "100",
+				"MNV_REDIS_ADDR":          "env:6379",
+				"MNV_REDIS_PASSWORD":      "secret",
+			},
+			check: func(t *testing.T, cfg serverConfig) {
+				assert.Equal(t, serverConfig{
+					Addr:               ":9000",
+					Preset:             "relaxed",
+					ReadTimeout:        duration(time.Second),
+					WriteTimeout:       duration(2 * time.Second),
+					RequestTimeout:     duration(3 * time.Second),
+					ShutdownTimeout:    duration(4 * time.Second),
+					MaxBatchSize:       10,
+					MaxBodyBytes:       2048,
+					AllowedCountries:   []string{"kg", "kz", "uz"},
+					ForbiddenCountries: []string{"ru"},
+					CacheSize:          100,
+					RedisAddr:          "env:6379",
+					RedisPassword:      "secret",
+				}, cfg)
+			},
+		},
+		{
+			name: "env overrides config file",
+			args: []string{"-config-file", configFile},
+			env:  map[string]string{"MNV_ADDR": ":9000", "MNV_ALLOWED_COUNTRIES": "kz"},
+			check: func(t *testing.T, cfg serverConfig) {
+				assert.Equal(t, ":9000", cfg.Addr)
+				assert.Equal(t, []string{"kz"}, cfg.AllowedCountries)
+				assert.Equal(t, "strict", cfg.Preset, "fields without env keep the file value")
+				assert.Equal(t, "file:6379", cfg.RedisAddr)
+			},
+		},
+		{
+			name: "flags override env and config file",
+			args: []string{"-config-file", configFile, "-addr", ":9100", "-read-timeout", "7s", "-allowed-countries", "uz,tj"},
+			env:  map[string]string{"MNV_ADDR": ":9000", "MNV_READ_TIMEOUT": "1s", "MNV_MAX_BATCH_SIZE": "10"},
+			check: func(t *testing.T, cfg serverConfig) {
+				assert.Equal(t, ":9100", cfg.Addr)
+				assert.Equal(t, duration(7*time.Second), cfg.ReadTimeout)
+				assert.Equal(t, []string{"uz", "tj"}, cfg.AllowedCountries)
+				assert.Equal(t, 10, cfg.MaxBatchSize, "env without a flag keeps its value")
+				assert.Equal(t, "strict", cfg.Preset)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadConfig(tt.args, func(name string) string { return tt.env[name] })
+			require.NoError(t, err)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		err  string
+	}{
+		{"unknown flag", []string{"-unknown"}, nil, "flag provided but not defined"},
+		{"bad flag duration", []string{"-read-timeout", "soon"}, nil, "invalid value"},
+		{"missing config file", []string{"-config-file", filepath.Join(t.TempDir(), "missing.json")}, nil, "read config file"},
+		{"malformed config file", []string{"-config-file", writeConfigFile(t, `{"addr":`)}, nil, "parse config file"},
+		{"bad config file duration", []string{"-config-file", writeConfigFile(t, `{"read_timeout":"soon"}`)}, nil, "parse config file"},
+		{"bad env duration", nil, map[string]string{"MNV_WRITE_TIMEOUT": "soon"}, "MNV_WRITE_TIMEOUT"},
+		{"bad env batch size", nil, map[string]string{"MNV_MAX_BATCH_SIZE": "many"}, "MNV_MAX_BATCH_SIZE"},
+		{"bad env cache size", nil, map[string]string{"MNV_CACHE_SIZE": "-x"}, "MNV_CACHE_SIZE"},
+		{"bad env body size", nil, map[string]string{"MNV_MAX_BODY_BYTES": "1MB"}, "MNV_MAX_BODY_BYTES"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfig(tt.args, func(name string) string { return tt.env[name] })
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
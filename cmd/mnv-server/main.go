@@ -0,0 +1,67 @@
+// Команда mnv-server запускает REST API валидации номеров телефонов как отдельный сервис.
+//
+// API доступно под /api/v1 (см. mnvhttp.NewHandler), документ OpenAPI 3 - по адресу
+// /openapi.json, проверки живости и готовности - /healthz и /readyz.
+// Конфигурация задается флагами, переменными окружения MNV_* или файлом JSON:
+//
+//	mnv-server -addr :8080 -preset strict -allowed-countries cis
+//	MNV_ADDR=:9000 MNV_REQUEST_TIMEOUT=5s mnv-server
+//	mnv-server -config-file /etc/mnv/server.json
+//	mnv-server -cache-size 10000 -redis-addr redis:6379
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvredis"
+)
+
+func main() {
+	cfg, err := loadConfig(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+
+	if err := mnv.SetPresetConfig(cfg.Preset); err != nil {
+		log.Fatal("Invalid validator preset: ", err)
+	}
+	policy := &mnv.ValidationOptions{AllowedCountries: cfg.AllowedCountries, ForbiddenCountries: cfg.ForbiddenCountries}
+	if err := mnv.ValidatePolicy(policy); err != nil {
+		log.Fatal("Invalid country policy: ", err)
+	}
+
+	if cfg.CacheSize > 0 {
+		cacheConfig := mnv.DefaultCacheConfig()
+		cacheConfig.Enabled = true
+		cacheConfig.MaxSize = cfg.CacheSize
+		mnv.SetCacheConfig(cacheConfig)
+	}
+	if cfg.RedisAddr != "" {
+		backend := mnvredis.New(mnvredis.Options{Addr: cfg.RedisAddr, Password: cfg.RedisPassword})
+		defer backend.Close()
+		if err := backend.Ping(); err != nil {
+			log.Printf("Redis cache is unavailable, continuing without it until it recovers: %v", err)
+		}
+		mnv.SetCacheBackend(backend)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := newServer(cfg)
+	log.Printf("mnv-server listening on %s", cfg.Addr)
+	if err := srv.run(ctx); err != nil {
+		log.Fatal("Server failed: ", err)
+	}
+	log.Println("mnv-server stopped")
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvhttp"
+)
+
+// Пути сервера
+const (
+	apiPrefix   = "/api/v1"
+	apiVersion  = "1.0.0"
+	timeoutBody = `{"type":"about:blank","title":"Service Unavailable","status":503,"detail":"request timeout"}`
+)
+
+// server HTTP-сервер валидации с поддержкой проверки готовности
+type server struct {
+	cfg   serverConfig
+	http  *http.Server
+	ready atomic.Bool
+}
+
+// newServer создает сервер по конфигурации
+func newServer(cfg serverConfig) *server {
+	s := &server{cfg: cfg}
+	s.http = &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           s.routes(),
+		ReadTimeout:       time.Duration(cfg.ReadTimeout),
+		ReadHeaderTimeout: time.Duration(cfg.ReadTimeout),
+		WriteTimeout:      time.Duration(cfg.WriteTimeout),
+	}
+	return s
+}
+
+// routes собирает маршруты сервера
+func (s *server) routes() http.Handler {
+	var validationOptions *mnv.ValidationOptions
+	if len(s.cfg.AllowedCountries) > 0 || len(s.cfg.ForbiddenCountries) > 0 {
+		validationOptions = &mnv.ValidationOptions{
+			AllowedCountries:   s.cfg.AllowedCountries,
+			ForbiddenCountries: s.cfg.ForbiddenCountries,
+		}
+	}
+
+	handler := mnvhttp.NewHandler(mnvhttp.Options{
+		MaxBatchSize:      s.cfg.MaxBatchSize,
+		MaxBodyBytes:      s.cfg.MaxBodyBytes,
+		ValidationOptions: validationOptions,
+	})
+	api := handler
+	if timeout := time.Duration(s.cfg.RequestTimeout); timeout > 0 {
+		api = http.TimeoutHandler(api, timeout, timeoutBody)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(apiPrefix+"/", http.StripPrefix(apiPrefix, api))
+	// Потоковая валидация не ограничена RequestTimeout: TimeoutHandler буферизует ответ целиком
+	mux.Handle("POST "+apiPrefix+"/validate/stream", http.StripPrefix(apiPrefix, handler))
+	mux.Handle("GET /openapi.json", mnvhttp.OpenAPIHandler(mnvhttp.OpenAPIInfo{
+		Version:   apiVersion,
+		ServerURL: apiPrefix,
+	}))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, "ok")
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !s.ready.Load() {
+			writeStatus(w, http.StatusServiceUnavailable, "not ready")
+			return
+		}
+		writeStatus(w, http.StatusOK, "ready")
+	})
+	return mux
+}
+
+// writeStatus отправляет состояние сервиса
+func writeStatus(w http.ResponseWriter, code int, status string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": status})
+}
+
+// run обслуживает запросы до отмены ctx, затем завершает активные запросы
+// в течение ShutdownTimeout. На время остановки /readyz возвращает 503
+func (s *server) run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.http.Serve(listener)
+	}()
+	s.ready.Store(true)
+
+	select {
+	case err := <-serveErr:
+		s.ready.Store(false)
+		return err
+	case <-ctx.Done():
+	}
+
+	s.ready.Store(false)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(s.cfg.ShutdownTimeout))
+	defer cancel()
+
+	if err := s.http.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
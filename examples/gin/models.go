@@ -1 +1 @@
-package gin
+package main
@@ -0,0 +1,193 @@
+package mnv
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultBatchParallel количество воркеров пакетной валидации по умолчанию
+const defaultBatchParallel = 10
+
+// BatchValidatePhones выполняет пакетную валидацию номеров телефонов пулом из
+// request.Parallel воркеров. Страна номера берется из подсказки BatchItem.Country,
+// затем из DefaultRegion (для номеров в национальном формате), иначе определяется по номеру
+func BatchValidatePhones(request *BatchValidationRequest) *BatchValidationResponse {
+	response, _ := batchValidate(context.Background(), request, func(_ context.Context, _ batchJob, compute func() *ValidationResult) *ValidationResult {
+		return compute()
+	})
+	return response
+}
+
+// batchJob номер пакета, подготовленный к проверке
+type batchJob struct {
+	// phone номер для проверки; национальные номера с известной страной приводятся к E.164
+	phone string
+
+	// country страна номера или пустая строка для определения по номеру
+	country string
+}
+
+// newBatchJob определяет страну номера пакета и приводит национальный номер к E.164
+func newBatchJob(item BatchItem, defaultRegion string) batchJob {
+	job := batchJob{phone: item.Phone, country: strings.TrimSpace(item.Country)}
+
+	if isInternationalNumber(item.Phone) {
+		return job
+	}
+	if job.country == "" {
+		job.country = strings.TrimSpace(defaultRegion)
+	}
+	if job.country != "" {
+		if e164, err := NormalizeE164(item.Phone, job.country); err == nil {
+			job.phone = e164
+		}
+	}
+	return job
+}
+
+// isInternationalNumber проверяет, записан ли номер в международном формате (+ или 00)
+func isInternationalNumber(phone string) bool {
+	phone = strings.TrimSpace(phone)
+	return strings.HasPrefix(phone, "+") || strings.HasPrefix(phone, "00")
+}
+
+// batchOutcome результат проверки номера пакета
+type batchOutcome struct {
+	index  int
+	result ValidationResult
+}
+
+// batchValidate проверяет номера пакета фиксированным пулом воркеров. validate выполняет
+// проверку одного номера через compute и возвращает nil, если номер не проверен из-за отмены
+// ctx. При отмене ctx новые номера не выдаются воркерам, и, если пакет не завершен,
+// возвращается ошибка контекста
+func batchValidate(ctx context.Context, request *BatchValidationRequest,
+	validate func(ctx context.Context, job batchJob, compute func() *ValidationResult) *ValidationResult) (*BatchValidationResponse, error) {
+	startTime := time.Now()
+
+	items := request.batchItems()
+	jobs := make([]batchJob, len(items))
+	for i, item := range items {
+		jobs[i] = newBatchJob(item, request.DefaultRegion)
+	}
+
+	response := &BatchValidationResponse{
+		Results: make([]ValidationResult, len(items)),
+		Stats: BatchStats{
+			Total:     len(items),
+			ByCountry: make(map[string]int),
+		},
+	}
+
+	done := 0
+	record := func(outcome batchOutcome) {
+		outcome.result.OriginalNumber = items[outcome.index].Phone
+		response.Results[outcome.index] = outcome.result
+		response.Stats.add(&outcome.result)
+
+		done++
+		if request.Progress != nil {
+			request.Progress(done, len(items))
+		}
+	}
+
+	// Результаты из общего бэкенда кеша загружаются одним запросом
+	prefetched, backendChecked := prefetchValidation(jobs, request.Options)
+	for index, result := range prefetched {
+		record(batchOutcome{index: index, result: *result})
+	}
+
+	workers := request.Parallel
+	if workers <= 0 {
+		workers = defaultBatchParallel
+	}
+	if remaining := len(items) - len(prefetched); workers > remaining {
+		workers = remaining
+	}
+
+	indices := make(chan int)
+	outcomes := make(chan batchOutcome, workers)
+
+	// Раздача номеров воркерам прекращается при отмене ctx
+	go func() {
+		defer close(indices)
+		for i := range jobs {
+			if _, ok := prefetched[i]; ok {
+				continue
+			}
+			select {
+			case indices <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				job := jobs[index]
+				result := validate(ctx, job, func() *ValidationResult {
+					if backendChecked {
+						return storeValidation(job.phone, job.country, request.Options)
+					}
+					return ValidatePhone(job.phone, job.country, request.Options)
+				})
+				if result == nil {
+					return
+				}
+
+				select {
+				case outcomes <- batchOutcome{index: index, result: *result}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	for outcome := range outcomes {
+		record(outcome)
+	}
+	if done < len(items) {
+		return nil, contextError(ctx.Err(), "", "")
+	}
+
+	response.ProcessingTime = time.Since(startTime).String()
+	return response, nil
+}
+
+// add учитывает результат в статистике. Номера, которые не удалось проверить
+// (таймаут, перегрузка, внутренняя ошибка), считаются ошибками, а не невалидными
+func (s *BatchStats) add(result *ValidationResult) {
+	switch {
+	case result.IsValid:
+		s.Valid++
+		if result.CountryCode != "" {
+			s.ByCountry[result.CountryCode]++
+		}
+	case isProcessingError(result.ErrorType):
+		s.Errors++
+	default:
+		s.Invalid++
+	}
+}
+
+// isProcessingError проверяет, означает ли тип ошибки невозможность проверки номера
+func isProcessingError(errorType ErrorType) bool {
+	switch errorType {
+	case ErrorTypeTimeout, ErrorTypeOverloaded, ErrorTypeUnknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,447 @@
+package mnv
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxCacheShards максимальное количество шардов кеша
+const maxCacheShards = 16
+
+// minShardCapacity минимальная емкость шарда: маленькие кеши не делятся на шарды,
+// чтобы сохранить точный порядок вытеснения LRU
+const minShardCapacity = 64
+
+// Виды записей кеша
+const (
+	cacheKindValidation = "validate"
+	cacheKindCountry    = "country"
+	cacheKindInfo       = "info"
+)
+
+// registryVersion версия реестра стран; увеличивается при AddCountry,
+// AddCustomCountry и RemoveCountry и сбрасывает отпечаток реестра
+var registryVersion atomic.Uint64
+
+// bumpRegistryVersion отмечает изменение реестра стран
+func bumpRegistryVersion() {
+	registryVersion.Add(1)
+}
+
+// RegistryVersion возвращает текущую версию реестра стран
+func RegistryVersion() uint64 {
+	return registryVersion.Load()
+}
+
+// registrySnapshot отпечаток содержимого реестра стран для версии version
+type registrySnapshot struct {
+	version     uint64
+	fingerprint string
+}
+
+// registryState последний вычисленный отпечаток реестра
+var registryState atomic.Pointer[registrySnapshot]
+
+// registryFingerprint возвращает отпечаток содержимого реестра стран. В отличие от
+// RegistryVersion он совпадает у экземпляров сервиса с одинаковым реестром, поэтому
+// входит в ключ кеша. Пересчитывается только после изменения реестра
+func registryFingerprint() string {
+	version := RegistryVersion()
+	if snapshot := registryState.Load(); snapshot != nil && snapshot.version == version {
+		return snapshot.fingerprint
+	}
+
+	// json.Marshal сортирует ключи карты, поэтому отпечаток не зависит от порядка обхода
+	data, _ := json.Marshal(CountryPhoneCodes)
+	sum := sha256.Sum256(data)
+	fingerprint := hex.EncodeToString(sum[:8])
+	registryState.Store(&registrySnapshot{version: version, fingerprint: fingerprint})
+	return fingerprint
+}
+
+// CacheStats статистика кеша
+type CacheStats struct {
+	// Hits количество попаданий
+	Hits uint64 `json:"hits"`
+
+	// Misses количество промахов
+	Misses uint64 `json:"misses"`
+
+	// Evictions количество вытеснений по размеру
+	Evictions uint64 `json:"evictions"`
+
+	// Expirations количество удалений по истечении TTL
+	Expirations uint64 `json:"expirations"`
+
+	// Size текущее количество записей
+	Size int `json:"size"`
+
+	// HitRate доля попаданий (от 0 до 1)
+	HitRate float64 `json:"hit_rate"`
+
+	// BackendHits количество попаданий в общий бэкенд (SetCacheBackend)
+	BackendHits uint64 `json:"backend_hits,omitempty"`
+
+	// BackendMisses количество промахов общего бэкенда, включая отброшенные несовместимые записи
+	BackendMisses uint64 `json:"backend_misses,omitempty"`
+
+	// BackendErrors количество ошибок общего бэкенда
+	BackendErrors uint64 `json:"backend_errors,omitempty"`
+}
+
+// ResultCache шардированный LRU-кеш результатов с ограниченным размером и временем жизни записей
+type ResultCache struct {
+	shards []*cacheShard
+	ttl    time.Duration
+
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	evictions   atomic.Uint64
+	expirations atomic.Uint64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// cacheShard шард кеша со своим списком LRU
+type cacheShard struct {
+	mu       sync.Mutex
+	items    map[string]*list.Element
+	order    *list.List
+	capacity int
+}
+
+// cacheItem запись шарда
+type cacheItem struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewResultCache создает кеш по конфигурации: MaxSize ограничивает количество записей,
+// TTL задает время жизни записи в секундах (0 - бессрочно). Если CleanupInterval больше
+// нуля, запускается фоновая очистка истекших записей, которую останавливает Close
+func NewResultCache(cfg CacheConfig) *ResultCache {
+	maxSize := cfg.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultCacheConfig().MaxSize
+	}
+
+	shardCount := maxSize / minShardCapacity
+	if shardCount > maxCacheShards {
+		shardCount = maxCacheShards
+	}
+	if shardCount < 1 {
+		shardCount = 1
+	}
+
+	c := &ResultCache{
+		shards: make([]*cacheShard, shardCount),
+		ttl:    time.Duration(cfg.TTL) * time.Second,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	// Емкости шардов в сумме равны maxSize
+	for i := range c.shards {
+		capacity := maxSize / shardCount
+		if i < maxSize%shardCount {
+			capacity++
+		}
+		c.shards[i] = &cacheShard{
+			items:    make(map[string]*list.Element),
+			order:    list.New(),
+			capacity: capacity,
+		}
+	}
+
+	if cfg.CleanupInterval > 0 {
+		go c.cleanupLoop(time.Duration(cfg.CleanupInterval) * time.Second)
+	} else {
+		close(c.done)
+	}
+	return c
+}
+
+// shard возвращает шард ключа
+func (c *ResultCache) shard(key string) *cacheShard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// Get возвращает значение по ключу. Истекшие записи удаляются
+func (c *ResultCache) Get(key string) (interface{}, bool) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, exists := s.items[key]
+	if !exists {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	item := elem.Value.(*cacheItem)
+	if !item.expiresAt.IsZero() && time.Now().After(item.expiresAt) {
+		s.remove(elem)
+		c.expirations.Add(1)
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	s.order.MoveToFront(elem)
+	c.hits.Add(1)
+	return item.value, true
+}
+
+// Set сохраняет значение; при переполнении шарда вытесняется давно не использованная запись
+func (c *ResultCache) Set(key string, value interface{}) {
+	c.setWithTTL(key, value, c.ttl)
+}
+
+// setWithTTL сохраняет значение с собственным временем жизни (0 - бессрочно)
+func (c *ResultCache) setWithTTL(key string, value interface{}, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, exists := s.items[key]; exists {
+		item := elem.Value.(*cacheItem)
+		item.value = value
+		item.expiresAt = expiresAt
+		s.order.MoveToFront(elem)
+		return
+	}
+
+	s.items[key] = s.order.PushFront(&cacheItem{key: key, value: value, expiresAt: expiresAt})
+	for s.order.Len() > s.capacity {
+		s.remove(s.order.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// Delete удаляет запись
+func (c *ResultCache) Delete(key string) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, exists := s.items[key]; exists {
+		s.remove(elem)
+	}
+}
+
+// Len возвращает количество записей
+func (c *ResultCache) Len() int {
+	total := 0
+	for _, s := range c.shards {
+		s.mu.Lock()
+		total += s.order.Len()
+		s.mu.Unlock()
+	}
+	return total
+}
+
+// Purge удаляет все записи
+func (c *ResultCache) Purge() {
+	for _, s := range c.shards {
+		s.mu.Lock()
+		s.items = make(map[string]*list.Element)
+		s.order.Init()
+		s.mu.Unlock()
+	}
+}
+
+// Cleanup удаляет истекшие записи и возвращает их количество
+func (c *ResultCache) Cleanup() int {
+	now := time.Now()
+	removed := 0
+
+	for _, s := range c.shards {
+		s.mu.Lock()
+		for elem := s.order.Back(); elem != nil; {
+			prev := elem.Prev()
+			item := elem.Value.(*cacheItem)
+			if !item.expiresAt.IsZero() && now.After(item.expiresAt) {
+				s.remove(elem)
+				removed++
+			}
+			elem = prev
+		}
+		s.mu.Unlock()
+	}
+
+	c.expirations.Add(uint64(removed))
+	return removed
+}
+
+// Stats возвращает статистику кеша
+func (c *ResultCache) Stats() CacheStats {
+	stats := CacheStats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Evictions:   c.evictions.Load(),
+		Expirations: c.expirations.Load(),
+		Size:        c.Len(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// Close останавливает фоновую очистку и дожидается ее завершения. Повторные вызовы безопасны
+func (c *ResultCache) Close() {
+	c.stopOnce.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+// cleanupLoop периодически удаляет истекшие записи
+func (c *ResultCache) cleanupLoop(interval time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.Cleanup()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// remove удаляет элемент из шарда (вызывается под блокировкой)
+func (s *cacheShard) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.items, elem.Value.(*cacheItem).key)
+}
+
+// глобальный кеш, управляемый SetCacheConfig
+var defaultCache atomic.Pointer[ResultCache]
+
+// activeCache возвращает глобальный кеш или nil, если кеширование отключено
+func activeCache() *ResultCache {
+	return defaultCache.Load()
+}
+
+// configureDefaultCache пересоздает глобальный кеш по конфигурации
+func configureDefaultCache(cfg CacheConfig) {
+	var next *ResultCache
+	if cfg.Enabled {
+		next = NewResultCache(cfg)
+	}
+	if previous := defaultCache.Swap(next); previous != nil {
+		previous.Close()
+	}
+}
+
+// GetCacheStats возвращает статистику глобального кеша и общего бэкенда
+// (нулевую, если кеширование отключено)
+func GetCacheStats() CacheStats {
+	var stats CacheStats
+	if cache := activeCache(); cache != nil {
+		stats = cache.Stats()
+	}
+	stats.BackendHits = backendHits.Load()
+	stats.BackendMisses = backendMisses.Load()
+	stats.BackendErrors = backendErrors.Load()
+	return stats
+}
+
+// ClearCache удаляет все записи глобального кеша
+func ClearCache() {
+	if cache := activeCache(); cache != nil {
+		cache.Purge()
+	}
+}
+
+// fingerprint возвращает отпечаток конфигурации для ключа кеша
+func (cfg ValidatorConfig) fingerprint() string {
+	flags := []bool{
+		cfg.AllowSpaces, cfg.AllowDashes, cfg.AllowParentheses, cfg.AllowDots,
+		cfg.StrictMode, cfg.RequirePlusSign, cfg.CaseSensitiveCountryCode,
+	}
+
+	var b strings.Builder
+	for _, flag := range flags {
+		if flag {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	return b.String()
+}
+
+// cacheKey формирует ключ кеша: вид записи, отпечаток реестра, отпечаток конфигурации,
+// отпечаток опций и входные данные
+func cacheKey(kind string, opts *ValidationOptions, parts ...string) string {
+	cfg := GetConfig()
+	optionsFingerprint := ""
+	if opts != nil {
+		if opts.Config != nil {
+			cfg = *opts.Config
+		}
+		if data, err := json.Marshal(opts); err == nil {
+			optionsFingerprint = string(data)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(kind)
+	b.WriteByte(0)
+	b.WriteString(registryFingerprint())
+	b.WriteByte(0)
+	b.WriteString(cfg.fingerprint())
+	b.WriteByte(0)
+	b.WriteString(optionsFingerprint)
+	for _, part := range parts {
+		b.WriteByte(0)
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// cloneResult копирует результат вместе со срезами, чтобы записи кеша не изменялись вызывающим кодом
+func cloneResult(result *ValidationResult) *ValidationResult {
+	clone := *result
+	if result.Suggestions != nil {
+		clone.Suggestions = append([]string(nil), result.Suggestions...)
+	}
+	if result.SuggestionDetails != nil {
+		clone.SuggestionDetails = append([]Suggestion(nil), result.SuggestionDetails...)
+	}
+	return &clone
+}
+
+// clonePhoneInfo копирует информацию о номере
+func clonePhoneInfo(info *PhoneInfo) *PhoneInfo {
+	clone := *info
+	if info.Carrier != nil {
+		carrier := *info.Carrier
+		clone.Carrier = &carrier
+	}
+	return &clone
+}
+
+// countryLookup результат определения страны для кеша
+type countryLookup struct {
+	Code  string `json:"code"`
+	Found bool   `json:"found"`
+}
@@ -0,0 +1,374 @@
+package mnv
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheBackend общее хранилище кеша валидации (например, Redis), разделяемое
+// несколькими экземплярами сервиса. Значения передаются в сериализованном виде
+type CacheBackend interface {
+	// Get возвращает значение по ключу; found равен false, если ключа нет
+	Get(key string) (value []byte, found bool, err error)
+
+	// MGet возвращает значения по списку ключей в том же порядке; для отсутствующих ключей - nil
+	MGet(keys []string) ([][]byte, error)
+
+	// Set сохраняет значение с временем жизни ttl (0 - бессрочно)
+	Set(key string, value []byte, ttl time.Duration) error
+
+	// Delete удаляет значение
+	Delete(key string) error
+}
+
+// CacheKeyPrefix префикс ключей, которые кеш валидации записывает в общий бэкенд
+const CacheKeyPrefix = "mnv:"
+
+// cacheValueVersion версия формата записей общего бэкенда; увеличивается при
+// несовместимом изменении формата конверта
+const cacheValueVersion = 1
+
+// cacheEnvelope конверт записи общего бэкенда. Shape - сигнатура типа значения:
+// записи, сохраненные другой версией ValidationResult или PhoneInfo, отбрасываются
+type cacheEnvelope struct {
+	Version int             `json:"v"`
+	Shape   string          `json:"s"`
+	Data    json.RawMessage `json:"d"`
+}
+
+// MemoryBackend реализация CacheBackend в памяти процесса с вытеснением по LRU
+type MemoryBackend struct {
+	cache *ResultCache
+}
+
+// NewMemoryBackend создает бэкенд в памяти, хранящий не более maxSize записей
+func NewMemoryBackend(maxSize int) *MemoryBackend {
+	return &MemoryBackend{cache: NewResultCache(CacheConfig{MaxSize: maxSize})}
+}
+
+// Get возвращает значение по ключу
+func (b *MemoryBackend) Get(key string) ([]byte, bool, error) {
+	value, found := b.cache.Get(key)
+	if !found {
+		return nil, false, nil
+	}
+	return value.([]byte), true, nil
+}
+
+// MGet возвращает значения по списку ключей
+func (b *MemoryBackend) MGet(keys []string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		if value, found := b.cache.Get(key); found {
+			values[i] = value.([]byte)
+		}
+	}
+	return values, nil
+}
+
+// Set сохраняет копию значения
+func (b *MemoryBackend) Set(key string, value []byte, ttl time.Duration) error {
+	b.cache.setWithTTL(key, append([]byte(nil), value...), ttl)
+	return nil
+}
+
+// Delete удаляет значение
+func (b *MemoryBackend) Delete(key string) error {
+	b.cache.Delete(key)
+	return nil
+}
+
+// Len возвращает количество записей
+func (b *MemoryBackend) Len() int {
+	return b.cache.Len()
+}
+
+// общий бэкенд кеша и его счетчики
+var (
+	cacheBackend  atomic.Pointer[backendHolder]
+	backendHits   atomic.Uint64
+	backendMisses atomic.Uint64
+	backendErrors atomic.Uint64
+)
+
+// backendHolder обертка для хранения интерфейса в atomic.Pointer
+type backendHolder struct {
+	backend CacheBackend
+}
+
+// SetCacheBackend подключает общий бэкенд кеша валидации (nil - отключить), очищает кеш
+// процесса и сбрасывает счетчики бэкенда. Бэкенд используется вторым уровнем после кеша
+// процесса: промахи локального кеша ищутся в бэкенде, вычисленные результаты записываются
+// в оба уровня с TTL из CacheConfig. Ошибки бэкенда не прерывают валидацию и учитываются
+// в GetCacheStats. В ключ входит отпечаток содержимого реестра стран, поэтому экземпляры
+// с одинаковым реестром разделяют записи, а после AddCountry или RemoveCountry не читают
+// записи, вычисленные по другому реестру
+func SetCacheBackend(backend CacheBackend) {
+	var holder *backendHolder
+	if backend != nil {
+		holder = &backendHolder{backend: backend}
+	}
+	cacheBackend.Store(holder)
+	if cache := activeCache(); cache != nil {
+		cache.Purge()
+	}
+	backendHits.Store(0)
+	backendMisses.Store(0)
+	backendErrors.Store(0)
+}
+
+// activeBackend возвращает общий бэкенд или nil
+func activeBackend() CacheBackend {
+	if holder := cacheBackend.Load(); holder != nil {
+		return holder.backend
+	}
+	return nil
+}
+
+// backendKey преобразует ключ кеша в компактный ключ общего бэкенда
+func backendKey(kind, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return CacheKeyPrefix + kind + ":" + hex.EncodeToString(sum[:16])
+}
+
+// backendTTL возвращает время жизни записей бэкенда из конфигурации кеша
+func backendTTL() time.Duration {
+	return time.Duration(GetCacheConfig().TTL) * time.Second
+}
+
+// encodeCacheValue упаковывает значение в версионированный конверт
+func encodeCacheValue[T any](value T) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(cacheEnvelope{
+		Version: cacheValueVersion,
+		Shape:   shapeOf[T](),
+		Data:    data,
+	})
+}
+
+// decodeCacheValue распаковывает конверт; несовместимые записи отбрасываются
+func decodeCacheValue[T any](raw []byte) (T, bool) {
+	var zero T
+	var envelope cacheEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return zero, false
+	}
+	if envelope.Version != cacheValueVersion || envelope.Shape != shapeOf[T]() {
+		return zero, false
+	}
+
+	var value T
+	if err := json.Unmarshal(envelope.Data, &value); err != nil {
+		return zero, false
+	}
+	return value, true
+}
+
+// loadFromBackend ищет значение в общем бэкенде
+func loadFromBackend[T any](backend CacheBackend, key string) (T, bool) {
+	var zero T
+	raw, found, err := backend.Get(key)
+	if err != nil {
+		backendErrors.Add(1)
+		return zero, false
+	}
+	if !found {
+		backendMisses.Add(1)
+		return zero, false
+	}
+
+	value, ok := decodeCacheValue[T](raw)
+	if !ok {
+		backendMisses.Add(1)
+		return zero, false
+	}
+	backendHits.Add(1)
+	return value, true
+}
+
+// storeInBackend записывает значение в общий бэкенд
+func storeInBackend[T any](backend CacheBackend, key string, value T) {
+	raw, err := encodeCacheValue(value)
+	if err == nil {
+		err = backend.Set(key, raw, backendTTL())
+	}
+	if err != nil {
+		backendErrors.Add(1)
+	}
+}
+
+// cachedValue возвращает значение из кеша процесса или общего бэкенда, а при промахе
+// вычисляет его через compute и сохраняет в оба уровня. clone защищает записи кеша
+// процесса от изменения вызывающим кодом
+func cachedValue[T any](kind string, opts *ValidationOptions, parts []string, clone func(T) T, compute func() T) T {
+	cache, backend := activeCache(), activeBackend()
+	if cache == nil && backend == nil {
+		return compute()
+	}
+
+	key := cacheKey(kind, opts, parts...)
+	if cache != nil {
+		if cached, ok := cache.Get(key); ok {
+			return clone(cached.(T))
+		}
+	}
+
+	var remoteKey string
+	if backend != nil {
+		remoteKey = backendKey(kind, key)
+		if value, ok := loadFromBackend[T](backend, remoteKey); ok {
+			if cache != nil {
+				cache.Set(key, clone(value))
+			}
+			return value
+		}
+	}
+
+	value := compute()
+	if cache != nil {
+		cache.Set(key, clone(value))
+	}
+	if backend != nil {
+		storeInBackend(backend, remoteKey, value)
+	}
+	return value
+}
+
+// prefetchValidation ищет результаты для пакета номеров в кеше процесса, а оставшиеся -
+// в общем бэкенде одним запросом MGet. Возвращает найденные результаты по индексам номеров
+// и признак того, что бэкенд был опрошен: тогда промахи следует вычислять через
+// storeValidation без повторного обращения к бэкенду
+func prefetchValidation(jobs []batchJob, opts *ValidationOptions) (map[int]*ValidationResult, bool) {
+	backend := activeBackend()
+	if backend == nil || len(jobs) == 0 {
+		return nil, false
+	}
+
+	cache := activeCache()
+	found := make(map[int]*ValidationResult)
+	var missing []int
+	var keys, remoteKeys []string
+	for i, job := range jobs {
+		key := cacheKey(cacheKindValidation, opts, job.country, job.phone)
+		if cache != nil {
+			if cached, ok := cache.Get(key); ok {
+				found[i] = cloneResult(cached.(*ValidationResult))
+				continue
+			}
+		}
+		missing = append(missing, i)
+		keys = append(keys, key)
+		remoteKeys = append(remoteKeys, backendKey(cacheKindValidation, key))
+	}
+	if len(missing) == 0 {
+		return found, true
+	}
+
+	values, err := backend.MGet(remoteKeys)
+	if err != nil || len(values) != len(remoteKeys) {
+		backendErrors.Add(1)
+		return found, false
+	}
+
+	for n, raw := range values {
+		result, ok := decodeCacheValue[*ValidationResult](raw)
+		if raw == nil || !ok || result == nil {
+			backendMisses.Add(1)
+			continue
+		}
+		backendHits.Add(1)
+		if cache != nil {
+			cache.Set(keys[n], cloneResult(result))
+		}
+		found[missing[n]] = result
+	}
+	return found, true
+}
+
+// storeValidation выполняет валидацию и сохраняет результат в кеш процесса и общий бэкенд
+func storeValidation(phone, countryCode string, opts *ValidationOptions) *ValidationResult {
+	result := validatePhone(phone, countryCode, opts)
+	key := cacheKey(cacheKindValidation, opts, countryCode, phone)
+	if cache := activeCache(); cache != nil {
+		cache.Set(key, cloneResult(result))
+	}
+	if backend := activeBackend(); backend != nil {
+		storeInBackend(backend, backendKey(cacheKindValidation, key), result)
+	}
+	return result
+}
+
+// сигнатуры типов значений кеша
+var (
+	shapeCache   = make(map[reflect.Type]string)
+	shapeCacheMu sync.Mutex
+)
+
+// shapeOf возвращает короткую сигнатуру структуры типа T: имена, JSON-теги и типы полей
+func shapeOf[T any]() string {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	shapeCacheMu.Lock()
+	defer shapeCacheMu.Unlock()
+	if shape, ok := shapeCache[t]; ok {
+		return shape
+	}
+
+	var b strings.Builder
+	writeShape(&b, t, make(map[reflect.Type]bool))
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(b.String()))
+	shape := fmt.Sprintf("%016x", h.Sum64())
+	shapeCache[t] = shape
+	return shape
+}
+
+// writeShape записывает описание типа, рекурсивно раскрывая структуры
+func writeShape(b *strings.Builder, t reflect.Type, seen map[reflect.Type]bool) {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		b.WriteString(t.Kind().String())
+		b.WriteByte('(')
+		writeShape(b, t.Elem(), seen)
+		b.WriteByte(')')
+	case reflect.Map:
+		b.WriteString("map(")
+		writeShape(b, t.Key(), seen)
+		b.WriteByte(',')
+		writeShape(b, t.Elem(), seen)
+		b.WriteByte(')')
+	case reflect.Struct:
+		if seen[t] {
+			b.WriteString(t.String())
+			return
+		}
+		seen[t] = true
+		b.WriteByte('{')
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			b.WriteString(field.Name)
+			b.WriteByte(' ')
+			b.WriteString(field.Tag.Get("json"))
+			b.WriteByte(' ')
+			writeShape(b, field.Type, seen)
+			b.WriteByte(';')
+		}
+		b.WriteByte('}')
+	default:
+		b.WriteString(t.Kind().String())
+	}
+}
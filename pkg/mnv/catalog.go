@@ -0,0 +1,370 @@
+package mnv
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var embeddedLocales embed.FS
+
+// PluralForm категория множественного числа (по CLDR)
+type PluralForm string
+
+const (
+	PluralOne   PluralForm = "one"
+	PluralFew   PluralForm = "few"
+	PluralMany  PluralForm = "many"
+	PluralOther PluralForm = "other"
+)
+
+// PluralRule выбирает форму множественного числа для n
+type PluralRule func(n int) PluralForm
+
+// PluralRules правила множественного числа по языкам. Для языков без правила
+// используется английское (one/other)
+var PluralRules = map[string]PluralRule{
+	"en": pluralOneOther,
+	"ru": pluralRussian,
+	"ua": pluralRussian,
+	"by": pluralRussian,
+	"kg": pluralOneOther,
+	"kz": pluralOneOther,
+	"uz": pluralOneOther,
+}
+
+// pluralOneOther правило для языков с формами one/other
+func pluralOneOther(n int) PluralForm {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRussian правило для восточнославянских языков (one/few/many)
+func pluralRussian(n int) PluralForm {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// Message сообщение каталога: одна строка или набор форм множественного числа.
+// В JSON задается строкой или объектом {"one": "...", "other": "..."}
+type Message map[PluralForm]string
+
+// UnmarshalJSON разбирает сообщение из строки или объекта с формами
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*m = Message{PluralOther: text}
+		return nil
+	}
+
+	var forms map[PluralForm]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("message must be a string or an object of plural forms: %w", err)
+	}
+	if _, exists := forms[PluralOther]; !exists {
+		return fmt.Errorf("plural message must define the %q form", PluralOther)
+	}
+
+	*m = forms
+	return nil
+}
+
+// form возвращает текст для формы множественного числа или форму "other"
+func (m Message) form(form PluralForm) string {
+	if text, exists := m[form]; exists {
+		return text
+	}
+	return m[PluralOther]
+}
+
+// LocaleFile формат JSON-файла каталога
+type LocaleFile struct {
+	// Language код языка
+	Language string `json:"language"`
+
+	// Fallback языки, к которым обращаться при отсутствии сообщения (по порядку)
+	Fallback []string `json:"fallback,omitempty"`
+
+	// Messages сообщения по ключам
+	Messages map[string]Message `json:"messages"`
+}
+
+// Catalog каталог локализованных сообщений с цепочками отката между языками
+type Catalog struct {
+	mu        sync.RWMutex
+	messages  map[string]map[string]Message
+	fallbacks map[string][]string
+}
+
+// NewCatalog создает пустой каталог
+func NewCatalog() *Catalog {
+	return &Catalog{
+		messages:  make(map[string]map[string]Message),
+		fallbacks: make(map[string][]string),
+	}
+}
+
+// DefaultCatalog каталог сообщений библиотеки, загруженный из встроенных файлов locales/*.json
+var DefaultCatalog = mustLoadEmbeddedCatalog()
+
+// mustLoadEmbeddedCatalog загружает встроенные каталоги
+func mustLoadEmbeddedCatalog() *Catalog {
+	catalog := NewCatalog()
+	if err := catalog.LoadFS(embeddedLocales, "locales/*.json"); err != nil {
+		panic(fmt.Sprintf("mnv: failed to load embedded locales: %v", err))
+	}
+	return catalog
+}
+
+// LoadJSON загружает каталог одного языка из JSON. Сообщения дополняют
+// и переопределяют уже загруженные для этого языка
+func (c *Catalog) LoadJSON(data []byte) error {
+	var file LocaleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("invalid locale file: %w", err)
+	}
+
+	lang := NormalizeLanguage(file.Language)
+	if lang == "" {
+		return fmt.Errorf("locale file must specify a language")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages[lang] == nil {
+		c.messages[lang] = make(map[string]Message)
+	}
+	for key, message := range file.Messages {
+		c.messages[lang][key] = message
+	}
+	if file.Fallback != nil {
+		c.fallbacks[lang] = normalizeLanguages(file.Fallback)
+	}
+
+	return nil
+}
+
+// LoadReader загружает каталог из r
+func (c *Catalog) LoadReader(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return c.LoadJSON(data)
+}
+
+// LoadFile загружает каталог из файла
+func (c *Catalog) LoadFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if err := c.LoadJSON(data); err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+	return nil
+}
+
+// LoadFS загружает все файлы fsys, подходящие под шаблон pattern (например, "locales/*.json")
+func (c *Catalog) LoadFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no locale files match %q", pattern)
+	}
+
+	for _, name := range files {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if err := c.LoadJSON(data); err != nil {
+			return fmt.Errorf("%s: %w", path.Base(name), err)
+		}
+	}
+	return nil
+}
+
+// SetFallback задает цепочку отката для языка
+func (c *Catalog) SetFallback(lang string, chain ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fallbacks[NormalizeLanguage(lang)] = normalizeLanguages(chain)
+}
+
+// FallbackChain возвращает языки, по которым ищется сообщение для lang:
+// сам язык, его цепочка отката и DefaultLanguage
+func (c *Catalog) FallbackChain(lang string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.fallbackChain(NormalizeLanguage(lang))
+}
+
+// fallbackChain строит цепочку отката без блокировки, пропуская повторы и циклы
+func (c *Catalog) fallbackChain(lang string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+
+	var visit func(string)
+	visit = func(lang string) {
+		if lang == "" || seen[lang] {
+			return
+		}
+		seen[lang] = true
+		chain = append(chain, lang)
+		for _, next := range c.fallbacks[lang] {
+			visit(next)
+		}
+	}
+
+	visit(lang)
+	visit(DefaultLanguage)
+	return chain
+}
+
+// Languages возвращает отсортированный список загруженных языков
+func (c *Catalog) Languages() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	languages := make([]string, 0, len(c.messages))
+	for lang := range c.messages {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// HasLanguage проверяет, загружен ли язык
+func (c *Catalog) HasLanguage(lang string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, exists := c.messages[NormalizeLanguage(lang)]
+	return exists
+}
+
+// Translate возвращает сообщение key на языке lang с подставленными параметрами.
+// Плейсхолдеры записываются как {name}; форма множественного числа выбирается
+// по параметру "count". Если сообщения нет ни в одном языке цепочки, возвращает false
+func (c *Catalog) Translate(lang, key string, params map[string]interface{}) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, candidate := range c.fallbackChain(NormalizeLanguage(lang)) {
+		message, exists := c.messages[candidate][key]
+		if !exists {
+			continue
+		}
+
+		text := message[PluralOther]
+		if count, ok := pluralCount(params); ok {
+			text = message.form(pluralRule(candidate)(count))
+		}
+		return renderTemplate(text, params), true
+	}
+
+	return "", false
+}
+
+// pluralRule возвращает правило множественного числа для языка
+func pluralRule(lang string) PluralRule {
+	if rule, exists := PluralRules[lang]; exists {
+		return rule
+	}
+	return pluralOneOther
+}
+
+// pluralCount извлекает параметр "count"
+func pluralCount(params map[string]interface{}) (int, bool) {
+	switch count := params["count"].(type) {
+	case int:
+		return count, true
+	case int64:
+		return int(count), true
+	case float64:
+		return int(count), true
+	default:
+		return 0, false
+	}
+}
+
+// renderTemplate подставляет параметры вместо {name}. Неизвестные плейсхолдеры остаются как есть
+func renderTemplate(text string, params map[string]interface{}) string {
+	if len(params) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	var builder strings.Builder
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		builder.WriteString(text[:start])
+		if value, exists := params[text[start+1:end]]; exists {
+			builder.WriteString(formatParam(value))
+		} else {
+			builder.WriteString(text[start : end+1])
+		}
+		text = text[end+1:]
+	}
+	builder.WriteString(text)
+
+	return builder.String()
+}
+
+// formatParam форматирует значение параметра сообщения
+func formatParam(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// normalizeLanguages нормализует список кодов языков
+func normalizeLanguages(languages []string) []string {
+	result := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		if normalized := NormalizeLanguage(lang); normalized != "" {
+			result = append(result, normalized)
+		}
+	}
+	return result
+}
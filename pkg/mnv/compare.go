@@ -0,0 +1,160 @@
+package mnv
+
+import (
+	"strings"
+)
+
+// MatchType уровень совпадения двух номеров
+type MatchType string
+
+const (
+	// MatchTypeExact номера совпадают полностью, включая код страны
+	MatchTypeExact MatchType = "EXACT_MATCH"
+
+	// MatchTypeNSN совпадают национальные номера, но код страны известен только у одного (или ни у одного) из них
+	MatchTypeNSN MatchType = "NSN_MATCH"
+
+	// MatchTypeShortNSN один национальный номер является окончанием другого
+	MatchTypeShortNSN MatchType = "SHORT_NSN_MATCH"
+
+	// MatchTypeNone номера не совпадают
+	MatchTypeNone MatchType = "NO_MATCH"
+)
+
+// minShortNSNLength минимальная длина номера для совпадения по окончанию
+const minShortNSNLength = 5
+
+// extensionMarkers маркеры добавочного номера в порядке проверки
+var extensionMarkers = []string{";ext=", "ext.", "ext", "доб.", "доб", "x", "#"}
+
+// comparableNumber номер, разобранный для сравнения
+type comparableNumber struct {
+	// prefix префикс страны ("+996"); пустой, если код страны неизвестен
+	prefix string
+
+	// nsn национальный значимый номер
+	nsn string
+
+	// extension добавочный номер
+	extension string
+}
+
+// CompareNumbers сравнивает два номера и возвращает уровень совпадения.
+// Номера в национальном формате дополняются кодом страны defaultRegion (может быть пустым).
+// Разные добавочные номера дают MatchTypeNone, а добавочный номер только у одного
+// из номеров - не выше MatchTypeShortNSN, как в libphonenumber
+func CompareNumbers(a, b, defaultRegion string) MatchType {
+	region := resolveCountryCode(defaultRegion)
+
+	first, ok := parseForComparison(a, region)
+	if !ok {
+		return MatchTypeNone
+	}
+	second, ok := parseForComparison(b, region)
+	if !ok {
+		return MatchTypeNone
+	}
+
+	return compareParsedNumbers(first, second)
+}
+
+// compareParsedNumbers сравнивает разобранные номера
+func compareParsedNumbers(a, b comparableNumber) MatchType {
+	if a.extension != "" && b.extension != "" && a.extension != b.extension {
+		return MatchTypeNone
+	}
+	sameExtension := a.extension == b.extension
+
+	if a.prefix != "" && b.prefix != "" {
+		if a.prefix != b.prefix {
+			return MatchTypeNone
+		}
+		if a.nsn == b.nsn && sameExtension {
+			return MatchTypeExact
+		}
+		if isShortNSNMatch(a.nsn, b.nsn) {
+			return MatchTypeShortNSN
+		}
+		return MatchTypeNone
+	}
+
+	if a.nsn == b.nsn && sameExtension {
+		return MatchTypeNSN
+	}
+	if isShortNSNMatch(a.nsn, b.nsn) {
+		return MatchTypeShortNSN
+	}
+	return MatchTypeNone
+}
+
+// isShortNSNMatch проверяет, является ли один номер окончанием другого
+func isShortNSNMatch(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return len(a) >= minShortNSNLength && strings.HasSuffix(b, a)
+}
+
+// parseForComparison разбирает номер на префикс страны, национальный номер и добавочный
+func parseForComparison(phone, region string) (comparableNumber, bool) {
+	number, extension := splitExtension(phone)
+
+	digits := extractDigitsOnly(number)
+	if digits == "" {
+		return comparableNumber{}, false
+	}
+
+	trimmed := strings.TrimSpace(number)
+	international := strings.HasPrefix(trimmed, "+") || strings.HasPrefix(digits, "00")
+	_, regionKnown := CountryPhoneCodes[region]
+
+	// Национальный номер без региона: код страны неизвестен, отбрасываем национальный префикс
+	if !international && !regionKnown {
+		return comparableNumber{
+			nsn:       strings.TrimLeft(digits, "0"),
+			extension: extension,
+		}, true
+	}
+
+	e164, ok := normalizeToE164(number, region)
+	if !ok {
+		return comparableNumber{}, false
+	}
+
+	prefix, nsn := parsePhoneComponents(e164)
+	if prefix == "" {
+		// Неизвестный код страны: сравниваем номер целиком
+		return comparableNumber{
+			prefix:    "+",
+			nsn:       strings.TrimPrefix(e164, "+"),
+			extension: extension,
+		}, true
+	}
+
+	return comparableNumber{
+		prefix:    prefix,
+		nsn:       nsn,
+		extension: extension,
+	}, true
+}
+
+// splitExtension отделяет добавочный номер ("ext. 123", "x123", "#123", "доб. 123")
+func splitExtension(phone string) (number, extension string) {
+	lower := strings.ToLower(phone)
+
+	for _, marker := range extensionMarkers {
+		idx := strings.LastIndex(lower, marker)
+		if idx <= 0 {
+			continue
+		}
+
+		ext := extractDigitsOnly(lower[idx+len(marker):])
+		if ext == "" {
+			continue
+		}
+
+		return strings.TrimSpace(phone[:idx]), ext
+	}
+
+	return phone, ""
+}
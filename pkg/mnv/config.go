@@ -239,11 +239,13 @@ var (
 	cacheConfigMutex sync.RWMutex
 )
 
-// SetCacheConfig устанавливает конфигурацию кеша
+// SetCacheConfig устанавливает конфигурацию кеша и пересоздает глобальный кеш
+// результатов: при Enabled создается новый пустой кеш, иначе кеширование отключается
 func SetCacheConfig(cfg CacheConfig) {
 	cacheConfigMutex.Lock()
 	defer cacheConfigMutex.Unlock()
 	cacheConfig = cfg
+	configureDefaultCache(cfg)
 }
 
 // GetCacheConfig возвращает текущую конфигурацию кеша
@@ -259,9 +261,10 @@ type PerformanceConfig struct {
 	EnableProfiling bool `json:"enable_profiling"`
 
 	// MaxConcurrentValidations максимальное количество одновременных валидаций
+	// в функциях с context.Context (0 - без ограничения)
 	MaxConcurrentValidations int `json:"max_concurrent_validations"`
 
-	// ValidationTimeout таймаут валидации в миллисекундах
+	// ValidationTimeout таймаут одной валидации в функциях с context.Context (0 - без ограничения)
 	ValidationTimeout time.Duration `json:"validation_timeout"`
 
 	// EnableMetrics включает сбор метрик
@@ -284,11 +287,13 @@ var (
 	performanceConfigMutex sync.RWMutex
 )
 
-// SetPerformanceConfig устанавливает конфигурацию производительности
+// SetPerformanceConfig устанавливает конфигурацию производительности и пересоздает лимитер
+// одновременных валидаций, используемый функциями с context.Context
 func SetPerformanceConfig(cfg PerformanceConfig) {
 	performanceConfigMutex.Lock()
 	defer performanceConfigMutex.Unlock()
 	performanceConfig = cfg
+	configureLimiter(cfg.MaxConcurrentValidations)
 }
 
 // GetPerformanceConfig возвращает текущую конфигурацию производительности
@@ -303,4 +308,5 @@ func init() {
 	globalConfig = DefaultConfig()
 	cacheConfig = DefaultCacheConfig()
 	performanceConfig = DefaultPerformanceConfig()
+	configureLimiter(performanceConfig.MaxConcurrentValidations)
 }
@@ -0,0 +1,153 @@
+package mnv
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// admissionLimiter ограничивает количество одновременных валидаций процесса
+type admissionLimiter struct {
+	slots chan struct{}
+}
+
+// текущий лимитер; nil - без ограничения
+var validationLimiter atomic.Pointer[admissionLimiter]
+
+// configureLimiter пересоздает лимитер под MaxConcurrentValidations (0 и меньше - без
+// ограничения). Уже допущенные валидации освобождают места в прежнем лимитере
+func configureLimiter(maxConcurrent int) {
+	var next *admissionLimiter
+	if maxConcurrent > 0 {
+		next = &admissionLimiter{slots: make(chan struct{}, maxConcurrent)}
+	}
+	validationLimiter.Store(next)
+}
+
+// AcquireValidationSlot занимает место в общем лимитере одновременных валидаций
+// (PerformanceConfig.MaxConcurrentValidations), ожидая его до отмены ctx. Возвращает функцию
+// освобождения места. Используется провайдерами живой проверки, чтобы делить лимит с валидацией
+func AcquireValidationSlot(ctx context.Context) (release func(), err error) {
+	limiter := validationLimiter.Load()
+	if limiter == nil {
+		return func() {}, ctx.Err()
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+		return func() { <-limiter.slots }, nil
+	default:
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+		return func() { <-limiter.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ValidatePhoneContext выполняет ValidatePhone с учетом ctx и PerformanceConfig: валидация
+// ожидает места в лимитере MaxConcurrentValidations и ограничена ValidationTimeout.
+// Если место не получено до истечения срока, возвращается ошибка типа ErrorTypeOverloaded,
+// если валидация не завершилась вовремя - ErrorTypeTimeout. При отмене ctx возвращается ctx.Err()
+func ValidatePhoneContext(ctx context.Context, phone, countryCode string, options ...*ValidationOptions) (*ValidationResult, error) {
+	return withAdmission(ctx, phone, countryCode, func() *ValidationResult {
+		return ValidatePhone(phone, countryCode, options...)
+	})
+}
+
+// GetPhoneInfoContext выполняет GetPhoneInfo с учетом ctx и PerformanceConfig
+// (см. ValidatePhoneContext)
+func GetPhoneInfoContext(ctx context.Context, phone string) (*PhoneInfo, error) {
+	return withAdmission(ctx, phone, "", func() *PhoneInfo {
+		return GetPhoneInfo(phone)
+	})
+}
+
+// BatchValidatePhonesContext выполняет BatchValidatePhones с учетом ctx. Каждый номер
+// проверяется как ValidatePhoneContext; номера, не проверенные из-за таймаута или перегрузки,
+// возвращаются невалидными с ErrorType timeout или overloaded и учитываются в BatchStats.Errors.
+// Если ctx отменен или истек до завершения пакета, возвращается только ошибка
+func BatchValidatePhonesContext(ctx context.Context, request *BatchValidationRequest) (*BatchValidationResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, contextError(err, "", "")
+	}
+	return batchValidate(ctx, request, func(ctx context.Context, job batchJob, compute func() *ValidationResult) *ValidationResult {
+		result, err := withAdmission(ctx, job.phone, job.country, compute)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Номер не проверен из-за отмены всего пакета
+				return nil
+			}
+			return errorResult(job.phone, err)
+		}
+		return result
+	})
+}
+
+// withAdmission выполняет fn с учетом ctx, лимитера и таймаута валидации
+func withAdmission[T any](ctx context.Context, phone, countryCode string, fn func() T) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, contextError(err, phone, countryCode)
+	}
+
+	cfg := GetPerformanceConfig()
+	if cfg.ValidationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.ValidationTimeout)
+		defer cancel()
+	}
+
+	release, err := AcquireValidationSlot(ctx)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return zero, err
+		}
+		return zero, NewOverloadedError(phone, countryCode, cfg.MaxConcurrentValidations)
+	}
+
+	// Место освобождается по завершении fn, даже если результат уже не нужен:
+	// иначе зависшие проверки обходили бы лимит
+	done := make(chan T, 1)
+	go func() {
+		defer release()
+		done <- fn()
+	}()
+
+	select {
+	case value := <-done:
+		return value, nil
+	case <-ctx.Done():
+		select {
+		case value := <-done:
+			return value, nil
+		default:
+		}
+		return zero, contextError(ctx.Err(), phone, countryCode)
+	}
+}
+
+// contextError преобразует ошибку контекста: истечение срока - в ошибку таймаута,
+// отмена возвращается как есть
+func contextError(err error, phone, countryCode string) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return NewTimeoutError(phone, countryCode, err)
+	}
+	return err
+}
+
+// errorResult формирует невалидный результат для номера, который не удалось проверить
+func errorResult(phone string, err error) *ValidationResult {
+	result := &ValidationResult{
+		OriginalNumber: phone,
+		ErrorType:      ErrorTypeUnknown,
+		ErrorMessage:   err.Error(),
+	}
+	if ve, ok := GetValidationError(err); ok {
+		result.ErrorType = ve.Type
+		result.CountryCode = ve.CountryCode
+	}
+	return result
+}
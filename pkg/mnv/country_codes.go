@@ -4,128 +4,222 @@ package mnv
 var CountryPhoneCodes = map[string]PhoneCodeInfo{
 	// Центральная Азия
 	"kg": {
-		Prefix:      "+996",
-		Pattern:     `^\+996[0-9]{9}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Kyrgyzstan",
-		Description: "Kyrgyzstan mobile numbers",
+		Prefix:         "+996",
+		NationalPrefix: "0",
+		Pattern:        `^\+996[0-9]{9}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Kyrgyzstan",
+		Description:    "Kyrgyzstan mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"20", "22", "50", "51", "54", "55", "56", "57", "59", "70", "75", "77", "78", "88", "99"},
+			PhoneTypeLandline: {"3"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 	"kz": {
-		Prefix:      "+7",
-		Pattern:     `^\+7[67][0-9]{8}$`, // Казахстан использует +7 6xx и +7 7xx
-		MinLength:   10,
-		MaxLength:   10,
-		CountryName: "Kazakhstan",
-		Description: "Kazakhstan mobile numbers (6xx, 7xx)",
+		Prefix:         "+7",
+		NationalPrefix: "8",
+		Pattern:        `^\+7[67][0-9]{9}$`, // Казахстан использует +7 6xx и +7 7xx
+		MinLength:      10,
+		MaxLength:      10,
+		CountryName:    "Kazakhstan",
+		Description:    "Kazakhstan mobile numbers (6xx, 7xx)",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"70", "74", "75", "76", "77"},
+			PhoneTypeLandline: {"71", "72"},
+		},
 	},
 	"uz": {
-		Prefix:      "+998",
-		Pattern:     `^\+998[0-9]{9}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Uzbekistan",
-		Description: "Uzbekistan mobile numbers",
+		Prefix:         "+998",
+		NationalPrefix: "8",
+		Pattern:        `^\+998[0-9]{9}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Uzbekistan",
+		Description:    "Uzbekistan mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"20", "33", "50", "55", "77", "88", "90", "91", "93", "94", "95", "97", "98", "99"},
+			PhoneTypeLandline: {"61", "62", "65", "66", "67", "69", "71", "72", "73", "74", "75", "76", "79"},
+		},
 	},
 	"tj": {
-		Prefix:      "+992",
-		Pattern:     `^\+992[0-9]{9}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Tajikistan",
-		Description: "Tajikistan mobile numbers",
+		Prefix:         "+992",
+		NationalPrefix: "8",
+		Pattern:        `^\+992[0-9]{9}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Tajikistan",
+		Description:    "Tajikistan mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"0", "1", "5", "7", "8", "9"},
+			PhoneTypeLandline: {"3", "4"},
+		},
 	},
 	"tm": {
-		Prefix:      "+993",
-		Pattern:     `^\+993[0-9]{8}$`,
-		MinLength:   8,
-		MaxLength:   8,
-		CountryName: "Turkmenistan",
-		Description: "Turkmenistan mobile numbers",
+		Prefix:         "+993",
+		NationalPrefix: "8",
+		Pattern:        `^\+993[0-9]{8}$`,
+		MinLength:      8,
+		MaxLength:      8,
+		CountryName:    "Turkmenistan",
+		Description:    "Turkmenistan mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"6", "71"},
+			PhoneTypeLandline: {"1", "2", "3", "4", "5"},
+		},
 	},
 
 	// Россия и СНГ
 	"ru": {
-		Prefix:      "+7",
-		Pattern:     `^\+7[9][0-9]{9}$`, // Россия использует +7 9xx
-		MinLength:   10,
-		MaxLength:   10,
-		CountryName: "Russia",
-		Description: "Russia mobile numbers (9xx)",
+		Prefix:         "+7",
+		NationalPrefix: "8",
+		Pattern:        `^\+7[3489][0-9]{9}$`, // Россия: 9xx - мобильные, 3xx/4xx/8xx - стационарные и бесплатные
+		MinLength:      10,
+		MaxLength:      10,
+		CountryName:    "Russia",
+		Description:    "Russia mobile numbers (9xx)",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"9"},
+			PhoneTypeLandline: {"3", "4", "8"},
+			PhoneTypeTollFree: {"800"},
+			PhoneTypePremium:  {"809"},
+		},
 	},
 	"ua": {
-		Prefix:      "+380",
-		Pattern:     `^\+380[0-9]{9}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Ukraine",
-		Description: "Ukraine mobile numbers",
+		Prefix:         "+380",
+		NationalPrefix: "0",
+		Pattern:        `^\+380[0-9]{9}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Ukraine",
+		Description:    "Ukraine mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"39", "50", "63", "66", "67", "68", "73", "91", "92", "93", "94", "95", "96", "97", "98", "99"},
+			PhoneTypeLandline: {"3", "4", "5", "6"},
+			PhoneTypeTollFree: {"800"},
+			PhoneTypePremium:  {"900"},
+		},
 	},
 	"by": {
-		Prefix:      "+375",
-		Pattern:     `^\+375[0-9]{9}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Belarus",
-		Description: "Belarus mobile numbers",
+		Prefix:         "+375",
+		NationalPrefix: "8",
+		Pattern:        `^\+375[0-9]{9}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Belarus",
+		Description:    "Belarus mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"25", "29", "33", "44"},
+			PhoneTypeLandline: {"1", "2"},
+			PhoneTypeTollFree: {"800"},
+			PhoneTypePremium:  {"902"},
+		},
 	},
 	"am": {
-		Prefix:      "+374",
-		Pattern:     `^\+374[0-9]{8}$`,
-		MinLength:   8,
-		MaxLength:   8,
-		CountryName: "Armenia",
-		Description: "Armenia mobile numbers",
+		Prefix:         "+374",
+		NationalPrefix: "0",
+		Pattern:        `^\+374[0-9]{8}$`,
+		MinLength:      8,
+		MaxLength:      8,
+		CountryName:    "Armenia",
+		Description:    "Armenia mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"33", "41", "43", "44", "49", "55", "77", "91", "93", "94", "95", "96", "98", "99"},
+			PhoneTypeLandline: {"1", "2", "3"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 	"az": {
-		Prefix:      "+994",
-		Pattern:     `^\+994[0-9]{9}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Azerbaijan",
-		Description: "Azerbaijan mobile numbers",
+		Prefix:         "+994",
+		NationalPrefix: "0",
+		Pattern:        `^\+994[0-9]{9}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Azerbaijan",
+		Description:    "Azerbaijan mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"10", "50", "51", "55", "60", "70", "77", "99"},
+			PhoneTypeLandline: {"1", "2"},
+			PhoneTypeTollFree: {"88"},
+		},
 	},
 	"ge": {
-		Prefix:      "+995",
-		Pattern:     `^\+995[0-9]{9}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Georgia",
-		Description: "Georgia mobile numbers",
+		Prefix:         "+995",
+		NationalPrefix: "0",
+		Pattern:        `^\+995[0-9]{9}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Georgia",
+		Description:    "Georgia mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"5"},
+			PhoneTypeLandline: {"3", "4"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 	"md": {
-		Prefix:      "+373",
-		Pattern:     `^\+373[0-9]{8}$`,
-		MinLength:   8,
-		MaxLength:   8,
-		CountryName: "Moldova",
-		Description: "Moldova mobile numbers",
+		Prefix:         "+373",
+		NationalPrefix: "0",
+		Pattern:        `^\+373[0-9]{8}$`,
+		MinLength:      8,
+		MaxLength:      8,
+		CountryName:    "Moldova",
+		Description:    "Moldova mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"6", "7"},
+			PhoneTypeLandline: {"2"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 
 	// Западная Европа
 	"de": {
-		Prefix:      "+49",
-		Pattern:     `^\+49[1][5-7][0-9]{8,9}$`,
-		MinLength:   10,
-		MaxLength:   12,
-		CountryName: "Germany",
-		Description: "Germany mobile numbers",
+		Prefix:         "+49",
+		NationalPrefix: "0",
+		Pattern:        `^\+49[1][5-7][0-9]{8,9}$`,
+		MinLength:      10,
+		MaxLength:      12,
+		CountryName:    "Germany",
+		Description:    "Germany mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"15", "16", "17"},
+			PhoneTypeLandline: {"2", "3", "4", "5", "6", "7", "8", "9"},
+			PhoneTypeTollFree: {"800"},
+			PhoneTypePremium:  {"900"},
+		},
 	},
 	"fr": {
-		Prefix:      "+33",
-		Pattern:     `^\+33[6-7][0-9]{8}$`,
-		MinLength:   9,
-		MaxLength:   10,
-		CountryName: "France",
-		Description: "France mobile numbers",
+		Prefix:         "+33",
+		NationalPrefix: "0",
+		Pattern:        `^\+33[6-7][0-9]{8}$`,
+		MinLength:      9,
+		MaxLength:      10,
+		CountryName:    "France",
+		Description:    "France mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"6", "7"},
+			PhoneTypeLandline: {"1", "2", "3", "4", "5"},
+			PhoneTypeTollFree: {"80"},
+			PhoneTypePremium:  {"89"},
+			PhoneTypeVoip:     {"9"},
+		},
 	},
 	"uk": {
-		Prefix:      "+44",
-		Pattern:     `^\+44[7][0-9]{9}$`,
-		MinLength:   10,
-		MaxLength:   11,
-		CountryName: "United Kingdom",
-		Description: "UK mobile numbers",
+		Prefix:         "+44",
+		NationalPrefix: "0",
+		Pattern:        `^\+44[7][0-9]{9}$`,
+		MinLength:      10,
+		MaxLength:      11,
+		CountryName:    "United Kingdom",
+		Description:    "UK mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"7"},
+			PhoneTypeLandline: {"1", "2"},
+			PhoneTypeTollFree: {"800", "808"},
+			PhoneTypePremium:  {"9"},
+			PhoneTypeVoip:     {"56"},
+		},
 	},
 	"it": {
 		Prefix:      "+39",
@@ -134,6 +228,12 @@ var CountryPhoneCodes = map[string]PhoneCodeInfo{
 		MaxLength:   11,
 		CountryName: "Italy",
 		Description: "Italy mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"3"},
+			PhoneTypeLandline: {"0"},
+			PhoneTypeTollFree: {"800"},
+			PhoneTypePremium:  {"89"},
+		},
 	},
 	"es": {
 		Prefix:      "+34",
@@ -142,154 +242,262 @@ var CountryPhoneCodes = map[string]PhoneCodeInfo{
 		MaxLength:   9,
 		CountryName: "Spain",
 		Description: "Spain mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"6", "7"},
+			PhoneTypeLandline: {"8", "9"},
+			PhoneTypeTollFree: {"900"},
+			PhoneTypePremium:  {"80"},
+		},
 	},
 	"nl": {
-		Prefix:      "+31",
-		Pattern:     `^\+31[6][0-9]{8}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Netherlands",
-		Description: "Netherlands mobile numbers",
+		Prefix:         "+31",
+		NationalPrefix: "0",
+		Pattern:        `^\+31[6][0-9]{8}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Netherlands",
+		Description:    "Netherlands mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"6"},
+			PhoneTypeLandline: {"1", "2", "3", "4", "5", "7"},
+			PhoneTypeTollFree: {"800"},
+			PhoneTypePremium:  {"900"},
+			PhoneTypeVoip:     {"85"},
+		},
 	},
 
 	// Северная Америка
 	"us": {
-		Prefix:      "+1",
-		Pattern:     `^\+1[2-9][0-9]{9}$`,
-		MinLength:   10,
-		MaxLength:   10,
-		CountryName: "United States",
-		Description: "US mobile numbers",
+		Prefix:         "+1",
+		NationalPrefix: "1",
+		Pattern:        `^\+1[2-9][0-9]{9}$`,
+		MinLength:      10,
+		MaxLength:      10,
+		CountryName:    "United States",
+		Description:    "US mobile numbers",
+		NumberRanges:   nanpNumberRanges,
 	},
 	"ca": {
-		Prefix:      "+1",
-		Pattern:     `^\+1[2-9][0-9]{9}$`,
-		MinLength:   10,
-		MaxLength:   10,
-		CountryName: "Canada",
-		Description: "Canada mobile numbers",
+		Prefix:         "+1",
+		NationalPrefix: "1",
+		Pattern:        `^\+1[2-9][0-9]{9}$`,
+		MinLength:      10,
+		MaxLength:      10,
+		CountryName:    "Canada",
+		Description:    "Canada mobile numbers",
+		NumberRanges:   nanpNumberRanges,
 	},
 
 	// Азия
 	"tr": {
-		Prefix:      "+90",
-		Pattern:     `^\+90[5][0-9]{9}$`,
-		MinLength:   10,
-		MaxLength:   10,
-		CountryName: "Turkey",
-		Description: "Turkey mobile numbers",
+		Prefix:         "+90",
+		NationalPrefix: "0",
+		Pattern:        `^\+90[5][0-9]{9}$`,
+		MinLength:      10,
+		MaxLength:      10,
+		CountryName:    "Turkey",
+		Description:    "Turkey mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"5"},
+			PhoneTypeLandline: {"2", "3", "4"},
+			PhoneTypeTollFree: {"800"},
+			PhoneTypePremium:  {"900"},
+		},
 	},
 	"cn": {
-		Prefix:      "+86",
-		Pattern:     `^\+86[1][3-9][0-9]{9}$`,
-		MinLength:   11,
-		MaxLength:   11,
-		CountryName: "China",
-		Description: "China mobile numbers",
+		Prefix:         "+86",
+		NationalPrefix: "0",
+		Pattern:        `^\+86[1][3-9][0-9]{9}$`,
+		MinLength:      11,
+		MaxLength:      11,
+		CountryName:    "China",
+		Description:    "China mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"13", "14", "15", "16", "17", "18", "19"},
+			PhoneTypeLandline: {"2", "3", "4", "5", "6", "7", "8", "9"},
+			PhoneTypeTollFree: {"400", "800"},
+		},
 	},
 	"in": {
-		Prefix:      "+91",
-		Pattern:     `^\+91[6-9][0-9]{9}$`,
-		MinLength:   10,
-		MaxLength:   10,
-		CountryName: "India",
-		Description: "India mobile numbers",
+		Prefix:         "+91",
+		NationalPrefix: "0",
+		Pattern:        `^\+91[6-9][0-9]{9}$`,
+		MinLength:      10,
+		MaxLength:      10,
+		CountryName:    "India",
+		Description:    "India mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"6", "7", "8", "9"},
+			PhoneTypeLandline: {"1", "2", "3", "4", "5"},
+			PhoneTypeTollFree: {"1800"},
+		},
 	},
 	"jp": {
-		Prefix:      "+81",
-		Pattern:     `^\+81[7-9][0-9]{8}$`,
-		MinLength:   10,
-		MaxLength:   11,
-		CountryName: "Japan",
-		Description: "Japan mobile numbers",
+		Prefix:         "+81",
+		NationalPrefix: "0",
+		Pattern:        `^\+81[7-9][0-9]{8}$`,
+		MinLength:      10,
+		MaxLength:      11,
+		CountryName:    "Japan",
+		Description:    "Japan mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"70", "80", "90"},
+			PhoneTypeLandline: {"1", "2", "3", "4", "5", "6", "7", "8", "9"},
+			PhoneTypeTollFree: {"120", "800"},
+			PhoneTypeVoip:     {"50"},
+		},
 	},
 	"kr": {
-		Prefix:      "+82",
-		Pattern:     `^\+82[1][0-9]{8,9}$`,
-		MinLength:   9,
-		MaxLength:   10,
-		CountryName: "South Korea",
-		Description: "South Korea mobile numbers",
+		Prefix:         "+82",
+		NationalPrefix: "0",
+		Pattern:        `^\+82[1][0-9]{8,9}$`,
+		MinLength:      9,
+		MaxLength:      10,
+		CountryName:    "South Korea",
+		Description:    "South Korea mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"10", "11", "16", "17", "18", "19"},
+			PhoneTypeLandline: {"2", "3", "4", "5", "6"},
+			PhoneTypeTollFree: {"80"},
+			PhoneTypeVoip:     {"70"},
+		},
 	},
 
 	// Ближний Восток
 	"ae": {
-		Prefix:      "+971",
-		Pattern:     `^\+971[5][0-9]{8}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "United Arab Emirates",
-		Description: "UAE mobile numbers",
+		Prefix:         "+971",
+		NationalPrefix: "0",
+		Pattern:        `^\+971[5][0-9]{8}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "United Arab Emirates",
+		Description:    "UAE mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"5"},
+			PhoneTypeLandline: {"2", "3", "4", "6", "7", "9"},
+			PhoneTypeTollFree: {"800"},
+			PhoneTypePremium:  {"900"},
+		},
 	},
 	"sa": {
-		Prefix:      "+966",
-		Pattern:     `^\+966[5][0-9]{8}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Saudi Arabia",
-		Description: "Saudi Arabia mobile numbers",
+		Prefix:         "+966",
+		NationalPrefix: "0",
+		Pattern:        `^\+966[5][0-9]{8}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Saudi Arabia",
+		Description:    "Saudi Arabia mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"5"},
+			PhoneTypeLandline: {"1"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 	"il": {
-		Prefix:      "+972",
-		Pattern:     `^\+972[5][0-9]{8}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Israel",
-		Description: "Israel mobile numbers",
+		Prefix:         "+972",
+		NationalPrefix: "0",
+		Pattern:        `^\+972[5][0-9]{8}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Israel",
+		Description:    "Israel mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"5"},
+			PhoneTypeLandline: {"2", "3", "4", "8", "9"},
+			PhoneTypeTollFree: {"1800"},
+			PhoneTypeVoip:     {"7"},
+		},
 	},
 
 	// Африка
 	"za": {
-		Prefix:      "+27",
-		Pattern:     `^\+27[6-8][0-9]{8}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "South Africa",
-		Description: "South Africa mobile numbers",
+		Prefix:         "+27",
+		NationalPrefix: "0",
+		Pattern:        `^\+27[6-8][0-9]{8}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "South Africa",
+		Description:    "South Africa mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"6", "7", "8"},
+			PhoneTypeLandline: {"1", "2", "3", "4", "5"},
+			PhoneTypeTollFree: {"80"},
+			PhoneTypePremium:  {"86"},
+		},
 	},
 	"eg": {
-		Prefix:      "+20",
-		Pattern:     `^\+20[1][0-9]{9}$`,
-		MinLength:   10,
-		MaxLength:   10,
-		CountryName: "Egypt",
-		Description: "Egypt mobile numbers",
+		Prefix:         "+20",
+		NationalPrefix: "0",
+		Pattern:        `^\+20[1][0-9]{9}$`,
+		MinLength:      10,
+		MaxLength:      10,
+		CountryName:    "Egypt",
+		Description:    "Egypt mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"10", "11", "12", "15"},
+			PhoneTypeLandline: {"2", "3", "4", "5", "6", "8", "9"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 
 	// Океания
 	"au": {
-		Prefix:      "+61",
-		Pattern:     `^\+61[4][0-9]{8}$`,
-		MinLength:   9,
-		MaxLength:   9,
-		CountryName: "Australia",
-		Description: "Australia mobile numbers",
+		Prefix:         "+61",
+		NationalPrefix: "0",
+		Pattern:        `^\+61[4][0-9]{8}$`,
+		MinLength:      9,
+		MaxLength:      9,
+		CountryName:    "Australia",
+		Description:    "Australia mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"4"},
+			PhoneTypeLandline: {"2", "3", "7", "8"},
+			PhoneTypeTollFree: {"1800"},
+			PhoneTypePremium:  {"190"},
+		},
 	},
 	"nz": {
-		Prefix:      "+64",
-		Pattern:     `^\+64[2][0-9]{7,9}$`,
-		MinLength:   8,
-		MaxLength:   10,
-		CountryName: "New Zealand",
-		Description: "New Zealand mobile numbers",
+		Prefix:         "+64",
+		NationalPrefix: "0",
+		Pattern:        `^\+64[2][0-9]{7,9}$`,
+		MinLength:      8,
+		MaxLength:      10,
+		CountryName:    "New Zealand",
+		Description:    "New Zealand mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"2"},
+			PhoneTypeLandline: {"3", "4", "6", "7", "9"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 
 	// Латинская Америка
 	"br": {
-		Prefix:      "+55",
-		Pattern:     `^\+55[1-9][1-9][9][0-9]{8}$`,
-		MinLength:   11,
-		MaxLength:   11,
-		CountryName: "Brazil",
-		Description: "Brazil mobile numbers",
+		Prefix:         "+55",
+		NationalPrefix: "0",
+		Pattern:        `^\+55[1-9][1-9][9][0-9]{8}$`,
+		MinLength:      11,
+		MaxLength:      11,
+		CountryName:    "Brazil",
+		Description:    "Brazil mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"XX9"},
+			PhoneTypeLandline: {"XX2", "XX3", "XX4", "XX5"},
+		},
 	},
 	"ar": {
-		Prefix:      "+54",
-		Pattern:     `^\+54[9][1-9][0-9]{8}$`,
-		MinLength:   10,
-		MaxLength:   10,
-		CountryName: "Argentina",
-		Description: "Argentina mobile numbers",
+		Prefix:         "+54",
+		NationalPrefix: "0",
+		Pattern:        `^\+54[9][1-9][0-9]{8}$`,
+		MinLength:      10,
+		MaxLength:      10,
+		CountryName:    "Argentina",
+		Description:    "Argentina mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"9"},
+			PhoneTypeLandline: {"1", "2", "3"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 	"mx": {
 		Prefix:      "+52",
@@ -298,9 +506,22 @@ var CountryPhoneCodes = map[string]PhoneCodeInfo{
 		MaxLength:   11,
 		CountryName: "Mexico",
 		Description: "Mexico mobile numbers",
+		NumberRanges: map[PhoneType][]string{
+			PhoneTypeMobile:   {"1"},
+			PhoneTypeLandline: {"2", "3", "4", "5", "6", "7", "8", "9"},
+			PhoneTypeTollFree: {"800"},
+		},
 	},
 }
 
+// nanpNumberRanges диапазоны номеров Североамериканского плана нумерации (США и Канада).
+// Мобильные и стационарные номера не различаются по префиксу, поэтому считаются мобильными
+var nanpNumberRanges = map[PhoneType][]string{
+	PhoneTypeMobile:   {"2", "3", "4", "5", "6", "7", "8", "9"},
+	PhoneTypeTollFree: {"800", "833", "844", "855", "866", "877", "888"},
+	PhoneTypePremium:  {"900"},
+}
+
 // GetCountryInfo возвращает информацию о стране по коду
 func GetCountryInfo(countryCode string) (PhoneCodeInfo, bool) {
 	info, exists := CountryPhoneCodes[countryCode]
@@ -0,0 +1,198 @@
+package mnv
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage язык по умолчанию
+const DefaultLanguage = "en"
+
+// SupportedLanguages языки, для которых есть названия стран.
+// Коды совпадают с ключами ErrorMessages: "kg" - кыргызский, "kz" - казахский
+var SupportedLanguages = []string{"en", "ru", "kg", "kz", "uz"}
+
+// languageAliases сопоставляет коды ISO 639-1 с кодами языков библиотеки
+var languageAliases = map[string]string{
+	"ky": "kg",
+	"kk": "kz",
+}
+
+// CountryNames содержит локализованные названия стран (код страны → язык → название)
+var CountryNames = map[string]map[string]string{
+	// Центральная Азия
+	"kg": {"en": "Kyrgyzstan", "ru": "Кыргызстан", "kg": "Кыргызстан", "kz": "Қырғызстан", "uz": "Qirgʻiziston"},
+	"kz": {"en": "Kazakhstan", "ru": "Казахстан", "kg": "Казакстан", "kz": "Қазақстан", "uz": "Qozogʻiston"},
+	"uz": {"en": "Uzbekistan", "ru": "Узбекистан", "kg": "Өзбекстан", "kz": "Өзбекстан", "uz": "Oʻzbekiston"},
+	"tj": {"en": "Tajikistan", "ru": "Таджикистан", "kg": "Тажикстан", "kz": "Тәжікстан", "uz": "Tojikiston"},
+	"tm": {"en": "Turkmenistan", "ru": "Туркменистан", "kg": "Түркмөнстан", "kz": "Түрікменстан", "uz": "Turkmaniston"},
+
+	// Россия и СНГ
+	"ru": {"en": "Russia", "ru": "Россия", "kg": "Орусия", "kz": "Ресей", "uz": "Rossiya"},
+	"ua": {"en": "Ukraine", "ru": "Украина", "kg": "Украина", "kz": "Украина", "uz": "Ukraina"},
+	"by": {"en": "Belarus", "ru": "Беларусь", "kg": "Беларусь", "kz": "Беларусь", "uz": "Belarus"},
+	"am": {"en": "Armenia", "ru": "Армения", "kg": "Армения", "kz": "Армения", "uz": "Armaniston"},
+	"az": {"en": "Azerbaijan", "ru": "Азербайджан", "kg": "Азербайжан", "kz": "Әзірбайжан", "uz": "Ozarbayjon"},
+	"ge": {"en": "Georgia", "ru": "Грузия", "kg": "Грузия", "kz": "Грузия", "uz": "Gruziya"},
+	"md": {"en": "Moldova", "ru": "Молдова", "kg": "Молдова", "kz": "Молдова", "uz": "Moldova"},
+
+	// Западная Европа
+	"de": {"en": "Germany", "ru": "Германия", "kg": "Германия", "kz": "Германия", "uz": "Germaniya"},
+	"fr": {"en": "France", "ru": "Франция", "kg": "Франция", "kz": "Франция", "uz": "Fransiya"},
+	"uk": {"en": "United Kingdom", "ru": "Великобритания", "kg": "Улуу Британия", "kz": "Ұлыбритания", "uz": "Buyuk Britaniya"},
+	"it": {"en": "Italy", "ru": "Италия", "kg": "Италия", "kz": "Италия", "uz": "Italiya"},
+	"es": {"en": "Spain", "ru": "Испания", "kg": "Испания", "kz": "Испания", "uz": "Ispaniya"},
+	"nl": {"en": "Netherlands", "ru": "Нидерланды", "kg": "Нидерланд", "kz": "Нидерланд", "uz": "Niderlandiya"},
+
+	// Северная Америка
+	"us": {"en": "United States", "ru": "США", "kg": "АКШ", "kz": "АҚШ", "uz": "AQSH"},
+	"ca": {"en": "Canada", "ru": "Канада", "kg": "Канада", "kz": "Канада", "uz": "Kanada"},
+
+	// Азия
+	"tr": {"en": "Turkey", "ru": "Турция", "kg": "Түркия", "kz": "Түркия", "uz": "Turkiya"},
+	"cn": {"en": "China", "ru": "Китай", "kg": "Кытай", "kz": "Қытай", "uz": "Xitoy"},
+	"in": {"en": "India", "ru": "Индия", "kg": "Индия", "kz": "Үндістан", "uz": "Hindiston"},
+	"jp": {"en": "Japan", "ru": "Япония", "kg": "Жапония", "kz": "Жапония", "uz": "Yaponiya"},
+	"kr": {"en": "South Korea", "ru": "Южная Корея", "kg": "Түштүк Корея", "kz": "Оңтүстік Корея", "uz": "Janubiy Koreya"},
+
+	// Ближний Восток
+	"ae": {"en": "United Arab Emirates", "ru": "ОАЭ", "kg": "Бириккен Араб Эмираттары", "kz": "Біріккен Араб Әмірліктері", "uz": "Birlashgan Arab Amirliklari"},
+	"sa": {"en": "Saudi Arabia", "ru": "Саудовская Аравия", "kg": "Сауд Арабиясы", "kz": "Сауд Арабиясы", "uz": "Saudiya Arabistoni"},
+	"il": {"en": "Israel", "ru": "Израиль", "kg": "Израиль", "kz": "Израиль", "uz": "Isroil"},
+
+	// Африка
+	"za": {"en": "South Africa", "ru": "ЮАР", "kg": "Түштүк Африка", "kz": "Оңтүстік Африка", "uz": "Janubiy Afrika"},
+	"eg": {"en": "Egypt", "ru": "Египет", "kg": "Египет", "kz": "Мысыр", "uz": "Misr"},
+
+	// Океания
+	"au": {"en": "Australia", "ru": "Австралия", "kg": "Австралия", "kz": "Австралия", "uz": "Avstraliya"},
+	"nz": {"en": "New Zealand", "ru": "Новая Зеландия", "kg": "Жаңы Зеландия", "kz": "Жаңа Зеландия", "uz": "Yangi Zelandiya"},
+
+	// Латинская Америка
+	"br": {"en": "Brazil", "ru": "Бразилия", "kg": "Бразилия", "kz": "Бразилия", "uz": "Braziliya"},
+	"ar": {"en": "Argentina", "ru": "Аргентина", "kg": "Аргентина", "kz": "Аргентина", "uz": "Argentina"},
+	"mx": {"en": "Mexico", "ru": "Мексика", "kg": "Мексика", "kz": "Мексика", "uz": "Meksika"},
+}
+
+// CountryDescriptionTemplates шаблоны описаний формата номеров по языкам.
+// Для английского языка используется PhoneCodeInfo.Description
+var CountryDescriptionTemplates = map[string]string{
+	"ru": "Мобильные номера: %s",
+	"kg": "%s: мобилдик номерлер",
+	"kz": "%s: ұялы нөмірлер",
+	"uz": "%s: mobil raqamlar",
+}
+
+// CountryName возвращает название страны на языке lang.
+// Если перевода нет, возвращается английское название
+func CountryName(code, lang string) string {
+	code = resolveCountryCode(code)
+	lang = NormalizeLanguage(lang)
+
+	if names, exists := CountryNames[code]; exists {
+		if name, langExists := names[lang]; langExists {
+			return name
+		}
+		if name, enExists := names[DefaultLanguage]; enExists {
+			return name
+		}
+	}
+
+	if info, exists := GetCountryInfo(code); exists {
+		return info.CountryName
+	}
+	return strings.ToUpper(code)
+}
+
+// CountryDescription возвращает описание формата номеров страны на языке lang
+func CountryDescription(code, lang string) string {
+	code = resolveCountryCode(code)
+	lang = NormalizeLanguage(lang)
+
+	info, exists := GetCountryInfo(code)
+	if !exists {
+		return ""
+	}
+
+	template, templateExists := CountryDescriptionTemplates[lang]
+	if lang == DefaultLanguage || !templateExists {
+		return info.Description
+	}
+
+	description := fmt.Sprintf(template, CountryName(code, lang))
+	// Уточнение диапазонов из английского описания, например "(6xx, 7xx)"
+	if start := strings.LastIndex(info.Description, "("); start >= 0 && strings.HasSuffix(info.Description, ")") {
+		description += " " + info.Description[start:]
+	}
+	return description
+}
+
+// LocalizedCountryInfo возвращает информацию о стране с названием и описанием на языке lang
+func LocalizedCountryInfo(code, lang string) (PhoneCodeInfo, bool) {
+	code = resolveCountryCode(code)
+	info, exists := GetCountryInfo(code)
+	if !exists {
+		return PhoneCodeInfo{}, false
+	}
+
+	info.CountryName = CountryName(code, lang)
+	info.Description = CountryDescription(code, lang)
+	return info, true
+}
+
+// NormalizeLanguage приводит код языка или тег BCP 47 ("ru-RU", "ky") к коду библиотеки
+func NormalizeLanguage(tag string) string {
+	lang := strings.ToLower(strings.TrimSpace(tag))
+	if idx := strings.IndexAny(lang, "-_"); idx >= 0 {
+		lang = lang[:idx]
+	}
+	if alias, exists := languageAliases[lang]; exists {
+		return alias
+	}
+	return lang
+}
+
+// IsSupportedLanguage проверяет, поддерживается ли язык: встроенный или загруженный в DefaultCatalog
+func IsSupportedLanguage(lang string) bool {
+	lang = NormalizeLanguage(lang)
+	return contains(SupportedLanguages, lang) || DefaultCatalog.HasLanguage(lang)
+}
+
+// ParseAcceptLanguage выбирает поддерживаемый язык по заголовку Accept-Language
+// с учетом весов q. Если подходящего языка нет, возвращается DefaultLanguage
+func ParseAcceptLanguage(header string) string {
+	type weightedLanguage struct {
+		lang   string
+		weight float64
+	}
+
+	var languages []weightedLanguage
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+
+		if lang := NormalizeLanguage(tag); weight > 0 && IsSupportedLanguage(lang) {
+			languages = append(languages, weightedLanguage{lang: lang, weight: weight})
+		}
+	}
+
+	if len(languages) == 0 {
+		return DefaultLanguage
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].weight > languages[j].weight
+	})
+	return languages[0].lang
+}
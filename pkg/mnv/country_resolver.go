@@ -0,0 +1,215 @@
+package mnv
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// CountryIdentity идентификаторы страны по ISO 3166-1 и известные названия
+type CountryIdentity struct {
+	// Alpha2 код ISO 3166-1 alpha-2 (для Великобритании - "GB", внутренний код - "uk")
+	Alpha2 string `json:"alpha2"`
+
+	// Alpha3 код ISO 3166-1 alpha-3
+	Alpha3 string `json:"alpha3"`
+
+	// Numeric цифровой код ISO 3166-1
+	Numeric string `json:"numeric"`
+
+	// Aliases дополнительные названия и синонимы (основные названия берутся из CountryNames)
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// CountryIdentities содержит идентификаторы стран, ключ - внутренний код из CountryPhoneCodes
+var CountryIdentities = map[string]CountryIdentity{
+	// Центральная Азия
+	"kg": {Alpha2: "KG", Alpha3: "KGZ", Numeric: "417", Aliases: []string{"Kyrgyz Republic", "Kirghizia", "Kirgizia", "Киргизия", "Кыргызская Республика", "Киргизстан"}},
+	"kz": {Alpha2: "KZ", Alpha3: "KAZ", Numeric: "398", Aliases: []string{"Kazakstan"}},
+	"uz": {Alpha2: "UZ", Alpha3: "UZB", Numeric: "860"},
+	"tj": {Alpha2: "TJ", Alpha3: "TJK", Numeric: "762"},
+	"tm": {Alpha2: "TM", Alpha3: "TKM", Numeric: "795", Aliases: []string{"Туркмения"}},
+
+	// Россия и СНГ
+	"ru": {Alpha2: "RU", Alpha3: "RUS", Numeric: "643", Aliases: []string{"Russian Federation", "Российская Федерация", "РФ", "Россия Федерациясы"}},
+	"ua": {Alpha2: "UA", Alpha3: "UKR", Numeric: "804"},
+	"by": {Alpha2: "BY", Alpha3: "BLR", Numeric: "112", Aliases: []string{"Byelorussia", "Белоруссия"}},
+	"am": {Alpha2: "AM", Alpha3: "ARM", Numeric: "051"},
+	"az": {Alpha2: "AZ", Alpha3: "AZE", Numeric: "031"},
+	"ge": {Alpha2: "GE", Alpha3: "GEO", Numeric: "268", Aliases: []string{"Sakartvelo"}},
+	"md": {Alpha2: "MD", Alpha3: "MDA", Numeric: "498", Aliases: []string{"Republic of Moldova", "Молдавия"}},
+
+	// Западная Европа
+	"de": {Alpha2: "DE", Alpha3: "DEU", Numeric: "276", Aliases: []string{"Deutschland"}},
+	"fr": {Alpha2: "FR", Alpha3: "FRA", Numeric: "250"},
+	"uk": {Alpha2: "GB", Alpha3: "GBR", Numeric: "826", Aliases: []string{"Great Britain", "Britain", "England", "Соединенное Королевство", "Англия"}},
+	"it": {Alpha2: "IT", Alpha3: "ITA", Numeric: "380"},
+	"es": {Alpha2: "ES", Alpha3: "ESP", Numeric: "724"},
+	"nl": {Alpha2: "NL", Alpha3: "NLD", Numeric: "528", Aliases: []string{"Holland", "The Netherlands", "Голландия"}},
+
+	// Северная Америка
+	"us": {Alpha2: "US", Alpha3: "USA", Numeric: "840", Aliases: []string{"United States of America", "America", "Соединенные Штаты", "Соединенные Штаты Америки", "Америка Кошмо Штаттары"}},
+	"ca": {Alpha2: "CA", Alpha3: "CAN", Numeric: "124"},
+
+	// Азия
+	"tr": {Alpha2: "TR", Alpha3: "TUR", Numeric: "792", Aliases: []string{"Turkiye", "Türkiye"}},
+	"cn": {Alpha2: "CN", Alpha3: "CHN", Numeric: "156", Aliases: []string{"People's Republic of China", "PRC", "КНР"}},
+	"in": {Alpha2: "IN", Alpha3: "IND", Numeric: "356"},
+	"jp": {Alpha2: "JP", Alpha3: "JPN", Numeric: "392"},
+	"kr": {Alpha2: "KR", Alpha3: "KOR", Numeric: "410", Aliases: []string{"Korea", "Republic of Korea", "Корея", "Республика Корея"}},
+
+	// Ближний Восток
+	"ae": {Alpha2: "AE", Alpha3: "ARE", Numeric: "784", Aliases: []string{"UAE", "Emirates", "Объединенные Арабские Эмираты"}},
+	"sa": {Alpha2: "SA", Alpha3: "SAU", Numeric: "682", Aliases: []string{"KSA"}},
+	"il": {Alpha2: "IL", Alpha3: "ISR", Numeric: "376"},
+
+	// Африка
+	"za": {Alpha2: "ZA", Alpha3: "ZAF", Numeric: "710", Aliases: []string{"RSA", "Южная Африка", "Южно-Африканская Республика"}},
+	"eg": {Alpha2: "EG", Alpha3: "EGY", Numeric: "818"},
+
+	// Океания
+	"au": {Alpha2: "AU", Alpha3: "AUS", Numeric: "036"},
+	"nz": {Alpha2: "NZ", Alpha3: "NZL", Numeric: "554"},
+
+	// Латинская Америка
+	"br": {Alpha2: "BR", Alpha3: "BRA", Numeric: "076", Aliases: []string{"Brasil"}},
+	"ar": {Alpha2: "AR", Alpha3: "ARG", Numeric: "032"},
+	"mx": {Alpha2: "MX", Alpha3: "MEX", Numeric: "484", Aliases: []string{"México"}},
+}
+
+// ResolveCountry определяет внутренний код страны по коду ISO 3166-1 (alpha-2, alpha-3,
+// цифровому), названию на любом из языков CountryNames или синониму.
+// Названия с опечатками распознаются нечетким сравнением, если совпадение однозначно
+func ResolveCountry(input string) (string, bool) {
+	key := normalizeCountryKey(input)
+	if key == "" {
+		return "", false
+	}
+
+	if _, exists := CountryPhoneCodes[key]; exists {
+		return key, true
+	}
+
+	for _, code := range sortedCountryCodes() {
+		for _, candidate := range countrySearchKeys(code) {
+			if candidate == key {
+				return code, true
+			}
+		}
+	}
+
+	matches := fuzzyCountryMatches(key)
+	if len(matches) == 1 || len(matches) > 1 && matches[0].distance < matches[1].distance {
+		return matches[0].code, true
+	}
+
+	return "", false
+}
+
+// resolveCountryCode приводит код страны к внутреннему коду. При включенном
+// CaseSensitiveCountryCode код используется как есть
+func resolveCountryCode(countryCode string) string {
+	if GetConfig().CaseSensitiveCountryCode {
+		return countryCode
+	}
+
+	if code, ok := ResolveCountry(countryCode); ok {
+		return code
+	}
+	return strings.ToLower(countryCode)
+}
+
+// countryMatch результат нечеткого сравнения названия страны
+type countryMatch struct {
+	code     string
+	distance int
+}
+
+// fuzzyCountryMatches ищет страны, названия которых отличаются от key на одну-две правки.
+// Результат отсортирован по расстоянию, для каждой страны - лучшее совпадение
+func fuzzyCountryMatches(key string) []countryMatch {
+	length := len([]rune(key))
+	if length < 5 || isNumericOnly(key) {
+		return nil
+	}
+
+	maxDistance := 1
+	if length >= 8 {
+		maxDistance = 2
+	}
+
+	var matches []countryMatch
+	for _, code := range sortedCountryCodes() {
+		best := maxDistance + 1
+		for _, name := range countrySearchNames(code) {
+			if distance := calculateDistance(key, normalizeCountryKey(name)); distance < best {
+				best = distance
+			}
+		}
+		if best <= maxDistance {
+			matches = append(matches, countryMatch{code: code, distance: best})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+	return matches
+}
+
+// countrySearchNames возвращает названия страны на всех языках и ее синонимы
+func countrySearchNames(code string) []string {
+	var names []string
+	for _, name := range CountryNames[code] {
+		names = append(names, name)
+	}
+	return append(names, CountryIdentities[code].Aliases...)
+}
+
+// countrySearchKeys возвращает нормализованные ключи поиска страны
+func countrySearchKeys(code string) []string {
+	var keys []string
+	if identity, exists := CountryIdentities[code]; exists {
+		keys = append(keys,
+			normalizeCountryKey(identity.Alpha2),
+			normalizeCountryKey(identity.Alpha3),
+			identity.Numeric,
+		)
+	}
+	for _, name := range countrySearchNames(code) {
+		keys = append(keys, normalizeCountryKey(name))
+	}
+	return keys
+}
+
+// normalizeCountryKey приводит название или код страны к виду для сравнения:
+// нижний регистр, "ё" → "е", без точек и апострофов, с одиночными пробелами.
+// Цифровые коды дополняются нулями до трех цифр
+func normalizeCountryKey(input string) string {
+	var builder strings.Builder
+	space := false
+
+	for _, char := range strings.ToLower(strings.TrimSpace(input)) {
+		switch {
+		case char == 'ё':
+			char = 'е'
+		case char == '.' || char == '\'' || char == '’':
+			continue
+		case unicode.IsSpace(char) || char == '-' || char == '_':
+			space = true
+			continue
+		}
+
+		if space && builder.Len() > 0 {
+			builder.WriteRune(' ')
+		}
+		space = false
+		builder.WriteRune(char)
+	}
+
+	key := builder.String()
+	if isNumericOnly(key) && len(key) < 3 {
+		key = strings.Repeat("0", 3-len(key)) + key
+	}
+	return key
+}
@@ -0,0 +1,422 @@
+package mnv
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Колонки, добавляемые EnrichCSV
+const (
+	ColumnIsValid    = "is_valid"
+	ColumnE164       = "e164"
+	ColumnNational   = "national"
+	ColumnCountry    = "country"
+	ColumnType       = "type"
+	ColumnCarrier    = "carrier"
+	ColumnErrorCode  = "error_code"
+	ColumnSuggestion = "suggestion"
+)
+
+// EnrichColumns колонки, добавляемые EnrichCSV по умолчанию
+var EnrichColumns = []string{
+	ColumnIsValid, ColumnE164, ColumnNational, ColumnCountry,
+	ColumnType, ColumnCarrier, ColumnErrorCode, ColumnSuggestion,
+}
+
+// PhoneColumnNames названия колонки с номером, распознаваемые без CSVOptions.PhoneColumn
+var PhoneColumnNames = []string{"phone", "phone_number", "telephone", "tel", "mobile", "msisdn", "телефон"}
+
+// utf8BOM метка порядка байтов UTF-8, которую добавляют экспорты Excel
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// CSVOptions настройки обработки CSV/TSV
+type CSVOptions struct {
+	// Comma разделитель полей (по умолчанию ','; '\t' для TSV)
+	Comma rune
+
+	// NoHeader первая строка содержит данные, а не заголовок
+	NoHeader bool
+
+	// PhoneColumn колонка с номером: название из заголовка или номер колонки с 1.
+	// По умолчанию - первая колонка с названием из PhoneColumnNames, без заголовка - первая колонка
+	PhoneColumn string
+
+	// CountryColumn колонка с подсказкой страны (необязательно)
+	CountryColumn string
+
+	// DefaultRegion страна для номеров в национальном формате без подсказки
+	DefaultRegion string
+
+	// Columns добавляемые колонки (по умолчанию EnrichColumns)
+	Columns []string
+
+	// Parallel количество воркеров (по умолчанию 10)
+	Parallel int
+
+	// Options опции валидации. По умолчанию, если запрошена колонка suggestion,
+	// возвращается одно предложение по исправлению
+	Options *ValidationOptions
+
+	// OnResult вызывается для результата каждой строки в порядке таблицы (необязательно)
+	OnResult func(result *ValidationResult)
+}
+
+// EnrichCSV читает таблицу CSV/TSV из r и записывает в w ее копию с добавленными колонками
+// результата проверки (CSVOptions.Columns). Порядок строк сохраняется, строки проверяются
+// потоком (см. ValidateStream), поэтому размер таблицы не ограничен памятью. Поля в кавычках
+// и метка BOM обрабатываются; если BOM был во входных данных, он записывается и в результат.
+// Возвращает итоговую статистику проверки
+func EnrichCSV(ctx context.Context, r io.Reader, w io.Writer, options *CSVOptions) (*BatchStats, error) {
+	var opts CSVOptions
+	if options != nil {
+		opts = *options
+	}
+	if len(opts.Columns) == 0 {
+		opts.Columns = EnrichColumns
+	}
+	for _, column := range opts.Columns {
+		if _, ok := columnEnrichers[column]; !ok {
+			return nil, fmt.Errorf("unknown enrichment column %q", column)
+		}
+	}
+	if opts.Options == nil && contains(opts.Columns, ColumnSuggestion) {
+		opts.Options = &ValidationOptions{ReturnSuggestions: true, MaxSuggestions: 1}
+	}
+
+	table, err := openCSV(r, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		writer.Comma = opts.Comma
+	}
+	if table.hasBOM {
+		if _, err := w.Write(utf8BOM); err != nil {
+			return nil, err
+		}
+	}
+	if table.header != nil {
+		if err := writer.Write(append(table.header, opts.Columns...)); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Строки хранятся только до записи результата, то есть не больше окна потока
+	var mu sync.Mutex
+	rows := make(map[int][]string)
+
+	in := make(chan BatchItem)
+	readErr := make(chan error, 1)
+	go func() {
+		for index := 0; ; index++ {
+			record, err := table.next()
+			if errors.Is(err, io.EOF) {
+				close(in)
+				return
+			} else if err != nil {
+				readErr <- err
+				cancel()
+				return
+			}
+
+			mu.Lock()
+			rows[index] = record
+			mu.Unlock()
+
+			select {
+			case in <- table.item(record):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var stats *BatchStats
+	results := ValidateStream(ctx, in, &StreamOptions{
+		Parallel:      opts.Parallel,
+		Ordered:       true,
+		DefaultRegion: opts.DefaultRegion,
+		Options:       opts.Options,
+	})
+	for message := range results {
+		if message.Stats != nil {
+			stats = message.Stats
+			continue
+		}
+
+		mu.Lock()
+		record := rows[message.Index]
+		delete(rows, message.Index)
+		mu.Unlock()
+
+		if opts.OnResult != nil {
+			opts.OnResult(message.Result)
+		}
+		for _, column := range opts.Columns {
+			record = append(record, columnEnrichers[column](message.Result))
+		}
+		if err := writer.Write(record); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	select {
+	case err := <-readErr:
+		return nil, err
+	default:
+	}
+	if stats == nil {
+		return nil, contextError(ctx.Err(), "", "")
+	}
+
+	writer.Flush()
+	return stats, writer.Error()
+}
+
+// CSVItems возвращает номера таблицы CSV/TSV из r: значения колонок CSVOptions.PhoneColumn
+// и CountryColumn по строкам (Comma, NoHeader и BOM учитываются так же, как в EnrichCSV).
+// Ошибка разбора таблицы выдается последней
+func CSVItems(r io.Reader, options *CSVOptions) iter.Seq2[BatchItem, error] {
+	var opts CSVOptions
+	if options != nil {
+		opts = *options
+	}
+
+	return func(yield func(BatchItem, error) bool) {
+		table, err := openCSV(r, &opts)
+		if err != nil {
+			yield(BatchItem{}, err)
+			return
+		}
+
+		for {
+			record, err := table.next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(BatchItem{}, err)
+				return
+			}
+			if !yield(table.item(record), nil) {
+				return
+			}
+		}
+	}
+}
+
+// CSVColumnError ошибка выбора колонки таблицы: колонка не найдена в заголовке
+// или ее номер выходит за ширину таблицы
+type CSVColumnError struct {
+	// Column назначение колонки: phone или country
+	Column string
+
+	// Err причина ошибки
+	Err error
+}
+
+// Error реализует интерфейс error
+func (ce *CSVColumnError) Error() string {
+	return ce.Column + " column: " + ce.Err.Error()
+}
+
+// Unwrap возвращает причину ошибки
+func (ce *CSVColumnError) Unwrap() error {
+	return ce.Err
+}
+
+// csvTable таблица CSV/TSV с определенными колонками номера и страны
+type csvTable struct {
+	reader        *csv.Reader
+	hasBOM        bool
+	header        []string
+	first         []string
+	width         int
+	phoneColumn   int
+	countryColumn int
+}
+
+// openCSV читает заголовок (или первую строку данных) и определяет колонки
+func openCSV(r io.Reader, opts *CSVOptions) (*csvTable, error) {
+	input := bufio.NewReader(r)
+	table := &csvTable{countryColumn: -1}
+	if prefix, _ := input.Peek(len(utf8BOM)); bytes.Equal(prefix, utf8BOM) {
+		_, _ = input.Discard(len(utf8BOM))
+		table.hasBOM = true
+	}
+
+	table.reader = csv.NewReader(input)
+	table.reader.FieldsPerRecord = -1
+	if opts.Comma != 0 {
+		table.reader.Comma = opts.Comma
+	}
+
+	first, err := table.reader.Read()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if opts.NoHeader {
+		table.first = first
+		table.width = len(first)
+	} else {
+		table.header = first
+		table.width = len(first)
+	}
+
+	if table.phoneColumn, err = resolveColumn(opts.PhoneColumn, table.header, table.width, PhoneColumnNames); err != nil {
+		return nil, &CSVColumnError{Column: "phone", Err: err}
+	}
+	if opts.CountryColumn != "" {
+		if table.countryColumn, err = resolveColumn(opts.CountryColumn, table.header, table.width, nil); err != nil {
+			return nil, &CSVColumnError{Column: "country", Err: err}
+		}
+	}
+	return table, nil
+}
+
+// next возвращает следующую строку данных, дополненную до ширины таблицы, или io.EOF
+func (t *csvTable) next() ([]string, error) {
+	record := t.first
+	t.first = nil
+	if record == nil {
+		var err error
+		if record, err = t.reader.Read(); err != nil {
+			return nil, err
+		}
+	}
+
+	for len(record) < t.width {
+		record = append(record, "")
+	}
+	return record, nil
+}
+
+// item возвращает номер и подсказку страны строки
+func (t *csvTable) item(record []string) BatchItem {
+	return BatchItem{Phone: field(record, t.phoneColumn), Country: field(record, t.countryColumn)}
+}
+
+// resolveColumn находит колонку по названию из заголовка (без учета регистра) или номеру
+// с 1, не больше ширины таблицы width (0 - пустая таблица). Пустой spec означает первую колонку
+// с названием из defaults, без заголовка - первую колонку
+func resolveColumn(spec string, header []string, width int, defaults []string) (int, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		if header == nil {
+			return 0, nil
+		}
+		for _, name := range defaults {
+			if index := headerIndex(header, name); index >= 0 {
+				return index, nil
+			}
+		}
+		return -1, errors.New("not found in header; specify it by name or number")
+	}
+
+	if index := headerIndex(header, spec); index >= 0 {
+		return index, nil
+	}
+	if number, err := strconv.Atoi(spec); err == nil {
+		if number < 1 {
+			return -1, fmt.Errorf("invalid column number %d: numbers start at 1", number)
+		}
+		if width > 0 && number > width {
+			return -1, fmt.Errorf("column number %d is out of range: the table has %d columns", number, width)
+		}
+		return number - 1, nil
+	}
+	return -1, fmt.Errorf("column %q not found in header", spec)
+}
+
+// headerIndex возвращает индекс колонки заголовка с названием name или -1
+func headerIndex(header []string, name string) int {
+	for i, column := range header {
+		if strings.EqualFold(strings.TrimSpace(column), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// field возвращает значение колонки строки или пустую строку, если колонки нет
+func field(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// ColumnValue возвращает значение колонки результата проверки (см. EnrichColumns);
+// false, если колонка неизвестна
+func ColumnValue(result *ValidationResult, column string) (string, bool) {
+	enrich, ok := columnEnrichers[column]
+	if !ok {
+		return "", false
+	}
+	return enrich(result), true
+}
+
+// columnEnrichers значения добавляемых колонок по результату проверки
+var columnEnrichers = map[string]func(result *ValidationResult) string{
+	ColumnIsValid: func(result *ValidationResult) string {
+		return strconv.FormatBool(result.IsValid)
+	},
+	ColumnE164: func(result *ValidationResult) string {
+		if !result.IsValid {
+			return ""
+		}
+		return result.FormattedNumber
+	},
+	ColumnNational: func(result *ValidationResult) string {
+		if !result.IsValid {
+			return ""
+		}
+		national, err := FormatNumber(result.FormattedNumber, result.CountryCode, FormatNational)
+		if err != nil {
+			return ""
+		}
+		return national
+	},
+	ColumnCountry: func(result *ValidationResult) string {
+		return result.CountryCode
+	},
+	ColumnType: func(result *ValidationResult) string {
+		return string(result.Type)
+	},
+	ColumnCarrier: func(result *ValidationResult) string {
+		if !result.IsValid {
+			return ""
+		}
+		if info := GetPhoneInfo(result.FormattedNumber); info.Carrier != nil {
+			return info.Carrier.Name
+		}
+		return ""
+	},
+	ColumnErrorCode: func(result *ValidationResult) string {
+		if result.IsValid {
+			return ""
+		}
+		return strconv.Itoa(result.ToError().ErrorCode())
+	},
+	ColumnSuggestion: func(result *ValidationResult) string {
+		if len(result.Suggestions) == 0 {
+			return ""
+		}
+		return result.Suggestions[0]
+	},
+}
@@ -0,0 +1,213 @@
+package mnv
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DedupeOptions настройки дедупликации
+type DedupeOptions struct {
+	// DefaultRegion страна для номеров в национальном формате
+	DefaultRegion string
+
+	// Extensions различать номера с разными добавочными; номер без добавочного попадает
+	// в отдельную группу, так как CompareNumbers не считает его полным совпадением.
+	// По умолчанию добавочные номера отбрасываются до сравнения, и DedupeCluster.Match
+	// описывает совпадение номеров без добавочных
+	Extensions bool
+
+	// Level наименьший уровень совпадения, объединяющий номера (см. CompareNumbers):
+	// MatchTypeExact (по умолчанию) или MatchTypeNSN. При MatchTypeNSN национальные номера
+	// с неизвестной страной объединяются между собой и с единственной группой полных номеров
+	// с тем же национальным номером. Совпадения MatchTypeShortNSN не объединяются
+	Level MatchType
+}
+
+// DedupeCluster группа записей одного номера
+type DedupeCluster struct {
+	// Representative канонический номер группы: E.164 (с ";ext=" при DedupeOptions.Extensions)
+	// или национальный номер, если страна неизвестна
+	Representative string `json:"representative"`
+
+	// Match наименьший уровень совпадения записей группы
+	Match MatchType `json:"match"`
+
+	// Indices индексы исходных записей в порядке добавления
+	Indices []int `json:"indices"`
+}
+
+// dedupeGroup записи с одинаковым номером
+type dedupeGroup struct {
+	// prefix префикс страны; пустой, если страна неизвестна
+	prefix string
+
+	// nsn национальный значимый номер
+	nsn string
+
+	// extension добавочный номер (только при DedupeOptions.Extensions)
+	extension string
+
+	// indices индексы записей группы
+	indices []int
+}
+
+// nsnKey ключ группы без учета страны
+func (g *dedupeGroup) nsnKey() string {
+	if g.extension == "" {
+		return g.nsn
+	}
+	return g.nsn + ";ext=" + g.extension
+}
+
+// representative канонический номер группы
+func (g *dedupeGroup) representative() string {
+	return g.prefix + g.nsnKey()
+}
+
+// Deduplicator группирует номера по каноническому E.164. Записи добавляются по одной,
+// индекс записи - порядковый номер вызова Add. Не безопасен для одновременного использования
+type Deduplicator struct {
+	opts      DedupeOptions
+	region    string
+	groups    []*dedupeGroup
+	byKey     map[string]*dedupeGroup
+	count     int
+	unmatched []int
+}
+
+// NewDeduplicator создает дедупликатор
+func NewDeduplicator(opts DedupeOptions) (*Deduplicator, error) {
+	switch opts.Level {
+	case "":
+		opts.Level = MatchTypeExact
+	case MatchTypeExact, MatchTypeNSN:
+	default:
+		return nil, fmt.Errorf("unsupported dedupe match level %q", opts.Level)
+	}
+
+	return &Deduplicator{
+		opts:   opts,
+		region: resolveCountryCode(opts.DefaultRegion),
+		byKey:  make(map[string]*dedupeGroup),
+	}, nil
+}
+
+// Deduplicate группирует номера phones (см. Deduplicator). Возвращает группы и индексы
+// записей, которые не удалось разобрать как номер
+func Deduplicate(phones []string, opts DedupeOptions) ([]DedupeCluster, []int, error) {
+	d, err := NewDeduplicator(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, phone := range phones {
+		d.Add(phone)
+	}
+	return d.Clusters(), d.Unmatched(), nil
+}
+
+// Add добавляет запись и возвращает ее индекс. ok = false, если запись не удалось
+// разобрать как номер; такая запись не входит ни в одну группу
+func (d *Deduplicator) Add(phone string) (index int, ok bool) {
+	return d.AddItem(BatchItem{Phone: phone})
+}
+
+// AddItem добавляет запись со страной item.Country (вместо DefaultRegion), см. Add
+func (d *Deduplicator) AddItem(item BatchItem) (index int, ok bool) {
+	index = d.count
+	d.count++
+
+	region := d.region
+	if item.Country != "" {
+		region = resolveCountryCode(item.Country)
+	}
+	number, ok := parseForComparison(item.Phone, region)
+	if !ok {
+		d.unmatched = append(d.unmatched, index)
+		return index, false
+	}
+
+	group := &dedupeGroup{prefix: number.prefix, nsn: number.nsn}
+	if d.opts.Extensions {
+		group.extension = number.extension
+	}
+
+	// Без уровня NSN национальные номера с неизвестной страной не объединяются
+	key := group.representative()
+	if group.prefix != "" || d.opts.Level == MatchTypeNSN {
+		if existing := d.byKey[key]; existing != nil {
+			group = existing
+		} else {
+			d.byKey[key] = group
+			d.groups = append(d.groups, group)
+		}
+	} else {
+		d.groups = append(d.groups, group)
+	}
+	group.indices = append(group.indices, index)
+	return index, true
+}
+
+// Len возвращает количество добавленных записей
+func (d *Deduplicator) Len() int {
+	return d.count
+}
+
+// Unmatched возвращает индексы записей, которые не удалось разобрать как номер
+func (d *Deduplicator) Unmatched() []int {
+	return append([]int(nil), d.unmatched...)
+}
+
+// Clusters возвращает группы в порядке первой записи каждой группы
+func (d *Deduplicator) Clusters() []DedupeCluster {
+	// Группы полных номеров по национальному номеру для уровня NSN
+	fullByNSN := make(map[string][]*dedupeGroup)
+	for _, group := range d.groups {
+		if group.prefix != "" {
+			fullByNSN[group.nsnKey()] = append(fullByNSN[group.nsnKey()], group)
+		}
+	}
+	// attached возвращает группу полного номера, к которой присоединяется группа
+	// с неизвестной страной; присоединение выполняется, только если такая группа одна
+	attached := func(group *dedupeGroup) *dedupeGroup {
+		if group.prefix != "" || d.opts.Level != MatchTypeNSN {
+			return nil
+		}
+		if candidates := fullByNSN[group.nsnKey()]; len(candidates) == 1 {
+			return candidates[0]
+		}
+		return nil
+	}
+
+	clusters := make([]DedupeCluster, 0, len(d.groups))
+	position := make(map[*dedupeGroup]int, len(d.groups))
+	for _, group := range d.groups {
+		if attached(group) != nil {
+			continue
+		}
+
+		match := MatchTypeExact
+		if group.prefix == "" {
+			match = MatchTypeNSN
+		}
+		position[group] = len(clusters)
+		clusters = append(clusters, DedupeCluster{
+			Representative: group.representative(),
+			Match:          match,
+			Indices:        append([]int(nil), group.indices...),
+		})
+	}
+
+	for _, group := range d.groups {
+		if target := attached(group); target != nil {
+			cluster := &clusters[position[target]]
+			cluster.Indices = append(cluster.Indices, group.indices...)
+			sort.Ints(cluster.Indices)
+			cluster.Match = MatchTypeNSN
+		}
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].Indices[0] < clusters[j].Indices[0]
+	})
+	return clusters
+}
@@ -2,6 +2,20 @@ package mnv
 
 import (
 	"fmt"
+	"strings"
+)
+
+// Ключи сообщений каталога с параметрами
+const (
+	messageKeyLengthExact       = "invalid_length.exact"
+	messageKeyLengthRange       = "invalid_length.range"
+	messageKeyPrefixDetail      = "invalid_prefix.detail"
+	messageKeyCountryDetail     = "unsupported_country.detail"
+	messageKeyCharactersDetail  = "invalid_characters.detail"
+	messageKeyTypeDetail        = "type_not_allowed.detail"
+	messageKeyCountryNotAllowed = "country_not_allowed.detail"
+	messageKeySuggestionNumber  = "suggestion.number"
+	messageKeySuggestionCountry = "suggestion.country"
 )
 
 // Предопределенные ошибки валидации
@@ -41,6 +55,30 @@ var (
 		Type:    ErrorTypeMissingPlus,
 		Message: "phone number must start with + sign",
 	}
+
+	// ErrTypeNotAllowed ошибка неразрешенного типа номера
+	ErrTypeNotAllowed = &ValidationError{
+		Type:    ErrorTypeTypeNotAllowed,
+		Message: "phone number type is not allowed",
+	}
+
+	// ErrCountryNotAllowed ошибка запрещенной страны
+	ErrCountryNotAllowed = &ValidationError{
+		Type:    ErrorTypeCountryNotAllowed,
+		Message: "country is not allowed",
+	}
+
+	// ErrTimeout ошибка истекшего времени валидации
+	ErrTimeout = &ValidationError{
+		Type:    ErrorTypeTimeout,
+		Message: "validation timed out",
+	}
+
+	// ErrOverloaded ошибка превышения лимита одновременных валидаций
+	ErrOverloaded = &ValidationError{
+		Type:    ErrorTypeOverloaded,
+		Message: "validator is overloaded",
+	}
 )
 
 // NewValidationError создает новую ошибку валидации
@@ -74,6 +112,12 @@ func NewInvalidLengthError(phone, countryCode string, expected, actual int) *Val
 		Phone:       phone,
 		CountryCode: countryCode,
 		Suggestions: suggestCorrections(phone, countryCode),
+		MessageKey:  messageKeyLengthExact,
+		Params: map[string]interface{}{
+			"expected": expected,
+			"actual":   actual,
+			"count":    expected,
+		},
 	}
 }
 
@@ -86,6 +130,13 @@ func NewInvalidLengthRangeError(phone, countryCode string, minLen, maxLen, actua
 		Phone:       phone,
 		CountryCode: countryCode,
 		Suggestions: suggestCorrections(phone, countryCode),
+		MessageKey:  messageKeyLengthRange,
+		Params: map[string]interface{}{
+			"min":    minLen,
+			"max":    maxLen,
+			"actual": actual,
+			"count":  maxLen,
+		},
 	}
 }
 
@@ -98,18 +149,23 @@ func NewInvalidPrefixError(phone, countryCode, expectedPrefix, actualPrefix stri
 		Phone:       phone,
 		CountryCode: countryCode,
 		Suggestions: suggestCorrections(phone, countryCode),
+		MessageKey:  messageKeyPrefixDetail,
+		Params: map[string]interface{}{
+			"country":         countryCode,
+			"expected_prefix": expectedPrefix,
+			"actual_prefix":   actualPrefix,
+		},
 	}
 }
 
-// NewUnsupportedCountryError создает ошибку неподдерживаемой страны
+// NewUnsupportedCountryError создает ошибку неподдерживаемой страны.
+// В предложениях перечисляются страны, найденные по коду ISO, названию или опечатке
 func NewUnsupportedCountryError(countryCode string) *ValidationError {
 	similar := findSimilarCountries(countryCode)
 	var suggestions []string
 
-	if len(similar) > 0 {
-		for _, code := range similar {
-			suggestions = append(suggestions, fmt.Sprintf("Did you mean '%s'?", code))
-		}
+	for _, code := range similar {
+		suggestions = append(suggestions, translateSuggestion(DefaultLanguage, messageKeySuggestionCountry, code))
 	}
 
 	message := fmt.Sprintf("unsupported country code: %s", countryCode)
@@ -118,6 +174,11 @@ func NewUnsupportedCountryError(countryCode string) *ValidationError {
 		Message:     message,
 		CountryCode: countryCode,
 		Suggestions: suggestions,
+		MessageKey:  messageKeyCountryDetail,
+		Params: map[string]interface{}{
+			"country":    countryCode,
+			"candidates": similar,
+		},
 	}
 }
 
@@ -129,6 +190,10 @@ func NewInvalidCharactersError(phone string, invalidChars []rune) *ValidationErr
 		Message:     message,
 		Phone:       phone,
 		Suggestions: []string{removeInvalidChars(phone, invalidChars)},
+		MessageKey:  messageKeyCharactersDetail,
+		Params: map[string]interface{}{
+			"characters": quoteRunes(invalidChars),
+		},
 	}
 }
 
@@ -143,6 +208,60 @@ func NewMissingPlusError(phone string) *ValidationError {
 	}
 }
 
+// NewTypeNotAllowedError создает ошибку неразрешенного типа номера
+func NewTypeNotAllowedError(phone, countryCode string, phoneType PhoneType) *ValidationError {
+	message := fmt.Sprintf("phone number type is not allowed: %s", phoneType)
+	return &ValidationError{
+		Type:        ErrorTypeTypeNotAllowed,
+		Message:     message,
+		Phone:       phone,
+		CountryCode: countryCode,
+		MessageKey:  messageKeyTypeDetail,
+		Params: map[string]interface{}{
+			"type": phoneType,
+		},
+	}
+}
+
+// NewCountryNotAllowedError создает ошибку страны, запрещенной политикой
+func NewCountryNotAllowedError(phone, countryCode string) *ValidationError {
+	message := fmt.Sprintf("country is not allowed: %s", countryCode)
+	return &ValidationError{
+		Type:        ErrorTypeCountryNotAllowed,
+		Message:     message,
+		Phone:       phone,
+		CountryCode: countryCode,
+		MessageKey:  messageKeyCountryNotAllowed,
+		Params: map[string]interface{}{
+			"country": countryCode,
+		},
+	}
+}
+
+// NewTimeoutError создает ошибку истекшего времени валидации; cause доступна через errors.Is
+func NewTimeoutError(phone, countryCode string, cause error) *ValidationError {
+	return &ValidationError{
+		Type:        ErrorTypeTimeout,
+		Message:     "validation timed out",
+		Phone:       phone,
+		CountryCode: countryCode,
+		cause:       cause,
+	}
+}
+
+// NewOverloadedError создает ошибку превышения лимита одновременных валидаций
+func NewOverloadedError(phone, countryCode string, limit int) *ValidationError {
+	return &ValidationError{
+		Type:        ErrorTypeOverloaded,
+		Message:     fmt.Sprintf("validator is overloaded: %d concurrent validations in progress", limit),
+		Phone:       phone,
+		CountryCode: countryCode,
+		Params: map[string]interface{}{
+			"limit": limit,
+		},
+	}
+}
+
 // removeInvalidChars удаляет недопустимые символы из номера
 func removeInvalidChars(phone string, invalidChars []rune) string {
 	invalidSet := make(map[rune]bool)
@@ -160,6 +279,15 @@ func removeInvalidChars(phone string, invalidChars []rune) string {
 	return string(result)
 }
 
+// quoteRunes перечисляет символы через запятую в кавычках
+func quoteRunes(chars []rune) string {
+	quoted := make([]string, len(chars))
+	for i, char := range chars {
+		quoted[i] = fmt.Sprintf("'%c'", char)
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // IsValidationError проверяет, является ли ошибка ValidationError
 func IsValidationError(err error) bool {
 	_, ok := err.(*ValidationError)
@@ -182,7 +310,8 @@ func WrapError(err error, errorType ErrorType, phone, countryCode string) *Valid
 	}
 }
 
-// ErrorMessages содержит локализованные сообщения об ошибках
+// ErrorMessages содержит локализованные сообщения об ошибках.
+// Используется, если сообщения нет в каталоге (см. DefaultCatalog)
 var ErrorMessages = map[ErrorType]map[string]string{
 	ErrorTypeInvalidFormat: {
 		"en": "Invalid phone number format",
@@ -214,10 +343,48 @@ var ErrorMessages = map[ErrorType]map[string]string{
 		"ru": "Номер должен начинаться со знака +",
 		"kg": "Номер + белгиси менен башталышы керек",
 	},
+	ErrorTypeTypeNotAllowed: {
+		"en": "Phone number type is not allowed",
+		"ru": "Тип номера телефона не разрешен",
+		"kg": "Телефон номеринин бул түрүнө уруксат жок",
+	},
+	ErrorTypeCountryNotAllowed: {
+		"en": "Phone numbers of this country are not allowed",
+		"ru": "Номера этой страны не разрешены",
+		"kg": "Бул өлкөнүн номерлерине уруксат жок",
+	},
+	ErrorTypeTimeout: {
+		"en": "Validation timed out",
+		"ru": "Превышено время валидации",
+		"kg": "Текшерүү убактысы бүттү",
+	},
+	ErrorTypeOverloaded: {
+		"en": "Validator is overloaded, try again later",
+		"ru": "Валидатор перегружен, повторите попытку позже",
+		"kg": "Текшергич ашыкча жүктөлгөн, кийинчерээк кайталаңыз",
+	},
 }
 
-// GetLocalizedMessage возвращает локализованное сообщение об ошибке
+// GetLocalizedMessage возвращает локализованное сообщение об ошибке.
+// Сообщение берется из DefaultCatalog с подстановкой параметров ошибки;
+// если в каталоге его нет - из ErrorMessages
 func (ve *ValidationError) GetLocalizedMessage(lang string) string {
+	return ve.LocalizedMessage(DefaultCatalog, lang)
+}
+
+// LocalizedMessage возвращает сообщение об ошибке из каталога catalog на языке lang
+func (ve *ValidationError) LocalizedMessage(catalog *Catalog, lang string) string {
+	params := ve.localizedParams(lang)
+
+	if ve.MessageKey != "" {
+		if message, ok := catalog.Translate(lang, ve.MessageKey, params); ok {
+			return message
+		}
+	}
+	if message, ok := catalog.Translate(lang, string(ve.Type), params); ok {
+		return message
+	}
+
 	if messages, exists := ErrorMessages[ve.Type]; exists {
 		if message, langExists := messages[lang]; langExists {
 			return message
@@ -230,6 +397,70 @@ func (ve *ValidationError) GetLocalizedMessage(lang string) string {
 	return ve.Message
 }
 
+// LocalizedSuggestions возвращает предложения по исправлению на языке lang.
+// Для неподдерживаемой страны предлагаются коды стран, для остальных ошибок - номера
+func (ve *ValidationError) LocalizedSuggestions(lang string) []string {
+	if candidates, ok := ve.Params["candidates"].([]string); ok {
+		suggestions := make([]string, 0, len(candidates))
+		for _, code := range candidates {
+			suggestions = append(suggestions, translateSuggestion(lang, messageKeySuggestionCountry, code))
+		}
+		return suggestions
+	}
+
+	if ve.Type == ErrorTypeUnsupportedCountry {
+		return ve.Suggestions
+	}
+
+	suggestions := make([]string, 0, len(ve.Suggestions))
+	for _, number := range ve.Suggestions {
+		suggestions = append(suggestions, translateSuggestion(lang, messageKeySuggestionNumber, number))
+	}
+	return suggestions
+}
+
+// Localize возвращает полный текст ошибки на языке lang: сообщение и предложения
+func (ve *ValidationError) Localize(lang string) string {
+	parts := append([]string{ve.GetLocalizedMessage(lang)}, ve.LocalizedSuggestions(lang)...)
+	return strings.Join(parts, ". ")
+}
+
+// localizedParams возвращает параметры сообщения, в которых код страны
+// и тип номера заменены названиями на языке lang
+func (ve *ValidationError) localizedParams(lang string) map[string]interface{} {
+	params := make(map[string]interface{}, len(ve.Params))
+	for key, value := range ve.Params {
+		params[key] = value
+	}
+
+	if code, ok := ve.Params["country"].(string); ok {
+		if _, exists := CountryPhoneCodes[code]; exists {
+			params["country"] = CountryName(code, lang)
+		}
+	}
+	if phoneType, ok := ve.Params["type"].(PhoneType); ok {
+		params["type"] = PhoneTypeName(phoneType, lang)
+	}
+
+	return params
+}
+
+// PhoneTypeName возвращает название типа номера на языке lang
+func PhoneTypeName(phoneType PhoneType, lang string) string {
+	if name, ok := DefaultCatalog.Translate(lang, "phone_type."+string(phoneType), nil); ok {
+		return name
+	}
+	return string(phoneType)
+}
+
+// translateSuggestion форматирует предложение по ключу каталога
+func translateSuggestion(lang, key, value string) string {
+	if message, ok := DefaultCatalog.Translate(lang, key, map[string]interface{}{"value": value}); ok {
+		return message
+	}
+	return value
+}
+
 // WithSuggestions добавляет предложения к ошибке
 func (ve *ValidationError) WithSuggestions(suggestions []string) *ValidationError {
 	ve.Suggestions = suggestions
@@ -282,6 +513,14 @@ func (ve *ValidationError) ErrorCode() int {
 		return 1005
 	case ErrorTypeMissingPlus:
 		return 1006
+	case ErrorTypeTypeNotAllowed:
+		return 1007
+	case ErrorTypeCountryNotAllowed:
+		return 1008
+	case ErrorTypeTimeout:
+		return 1009
+	case ErrorTypeOverloaded:
+		return 1010
 	default:
 		return 1000
 	}
@@ -290,9 +529,11 @@ func (ve *ValidationError) ErrorCode() int {
 // IsRetryable определяет, можно ли повторить операцию после исправления ошибки
 func (ve *ValidationError) IsRetryable() bool {
 	switch ve.Type {
-	case ErrorTypeInvalidFormat, ErrorTypeInvalidCharacters, ErrorTypeMissingPlus:
+	case ErrorTypeInvalidFormat, ErrorTypeInvalidCharacters, ErrorTypeMissingPlus,
+		ErrorTypeTimeout, ErrorTypeOverloaded:
 		return true
-	case ErrorTypeUnsupportedCountry, ErrorTypeInvalidLength, ErrorTypeInvalidPrefix:
+	case ErrorTypeUnsupportedCountry, ErrorTypeInvalidLength, ErrorTypeInvalidPrefix, ErrorTypeTypeNotAllowed,
+		ErrorTypeCountryNotAllowed:
 		return false
 	default:
 		return false
@@ -311,3 +552,13 @@ func (ve *ValidationError) ToJSON() map[string]interface{} {
 		"retryable":    ve.IsRetryable(),
 	}
 }
+
+// ToLocalizedJSON преобразует ошибку в JSON-совместимую структуру
+// с сообщением и предложениями на языке lang
+func (ve *ValidationError) ToLocalizedJSON(lang string) map[string]interface{} {
+	result := ve.ToJSON()
+	result["message"] = ve.GetLocalizedMessage(lang)
+	result["suggestion_messages"] = ve.LocalizedSuggestions(lang)
+	result["language"] = NormalizeLanguage(lang)
+	return result
+}
@@ -0,0 +1,399 @@
+package mnv
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// NumberFormat формат записи номера
+type NumberFormat string
+
+const (
+	// FormatE164 формат E.164: "+996700123456"
+	FormatE164 NumberFormat = "e164"
+
+	// FormatInternational международный формат: "+996 700 123 456"
+	FormatInternational NumberFormat = "international"
+
+	// FormatNational национальный формат: "0700 123 456"
+	FormatNational NumberFormat = "national"
+
+	// FormatRFC3966 URI по RFC 3966: "tel:+996-700-123-456"
+	FormatRFC3966 NumberFormat = "rfc3966"
+)
+
+// normalizeTag имя тега структуры для NormalizeStruct
+const normalizeTag = "mnv"
+
+// FormatNumber приводит номер к формату format. Номера в национальном формате
+// дополняются кодом страны region (может быть пустым). Для FormatE164 номер должен
+// соответствовать метаданным страны (префикс, длина, шаблон)
+func FormatNumber(phone, region string, format NumberFormat) (string, error) {
+	region = resolveCountryCode(region)
+
+	e164, err := NormalizeE164(phone, region)
+	if err != nil {
+		return "", err
+	}
+
+	// E.164 - канонический вид номера (например, для NormalizeStruct), поэтому номер
+	// должен соответствовать метаданным страны, а не только длине 7-15 цифр
+	if format == FormatE164 {
+		if _, _, ok := metadataCountry(e164, region); !ok {
+			return "", metadataError(phone, e164, region)
+		}
+		return e164, nil
+	}
+
+	country, info, ok := countryForFormatting(e164, region)
+	if !ok {
+		return "", NewValidationError(ErrorTypeUnsupportedCountry, "cannot determine country of phone number", phone, region, nil)
+	}
+	nsn := strings.TrimPrefix(e164, info.Prefix)
+
+	switch format {
+	case FormatInternational:
+		return info.Prefix + " " + groupDigits(nsn), nil
+	case FormatNational:
+		switch info.NationalPrefix {
+		case "":
+			return groupDigits(nsn), nil
+		case "0":
+			return info.NationalPrefix + groupDigits(nsn), nil
+		default:
+			return info.NationalPrefix + " " + groupDigits(nsn), nil
+		}
+	case FormatRFC3966:
+		return "tel:" + info.Prefix + "-" + strings.ReplaceAll(groupDigits(nsn), " ", "-"), nil
+	default:
+		return "", NewValidationError(ErrorTypeInvalidFormat, fmt.Sprintf("unknown number format: %s", format), phone, country, nil)
+	}
+}
+
+// countryForFormatting определяет страну номера E.164: предпочитается region,
+// затем страна по метаданным, затем любая страна с подходящим префиксом
+func countryForFormatting(e164, region string) (string, PhoneCodeInfo, bool) {
+	if code, info, found := metadataCountry(e164, region); found {
+		return code, info, true
+	}
+	if info, exists := CountryPhoneCodes[region]; exists && strings.HasPrefix(e164, info.Prefix) {
+		return region, info, true
+	}
+
+	code, found := countryPolicy{}.detect(e164)
+	return code, CountryPhoneCodes[code], found
+}
+
+// metadataCountry определяет страну номера E.164 по метаданным: предпочитается region
+func metadataCountry(e164, region string) (string, PhoneCodeInfo, bool) {
+	if info, exists := CountryPhoneCodes[region]; exists && matchesCountryMetadata(e164, info) {
+		return region, info, true
+	}
+	return detectCountryByMetadata(e164)
+}
+
+// metadataError возвращает ошибку номера E.164, не соответствующего метаданным ни одной
+// страны: неверную длину для страны с его префиксом или неверный формат
+func metadataError(phone, e164, region string) *ValidationError {
+	if country, found := (countryPolicy{}).detect(e164); found {
+		if err := countryError(phone, e164, country); err != nil {
+			return err
+		}
+		return NewValidationError(ErrorTypeInvalidFormat, fmt.Sprintf("phone number does not match the format of country %s", country), phone, country, nil)
+	}
+	return NewValidationError(ErrorTypeInvalidFormat, "phone number does not match any supported country", phone, region, nil)
+}
+
+// normalizeOptions параметры тега mnv:"normalize,..."
+type normalizeOptions struct {
+	format      NumberFormat
+	region      string
+	regionField string
+}
+
+// parseNormalizeTag разбирает тег вида "normalize,national,region=kg" или
+// "normalize,e164,region_field=Country". Возвращает false, если поле не нормализуется
+func parseNormalizeTag(tag string) (normalizeOptions, bool, error) {
+	parts := strings.Split(tag, ",")
+	if strings.TrimSpace(parts[0]) != "normalize" {
+		return normalizeOptions{}, false, nil
+	}
+
+	opts := normalizeOptions{format: FormatE164}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		key, value, hasValue := strings.Cut(part, "=")
+
+		switch {
+		case hasValue && key == "region":
+			opts.region = value
+		case hasValue && key == "region_field":
+			opts.regionField = value
+		case !hasValue && isNumberFormat(NumberFormat(part)):
+			opts.format = NumberFormat(part)
+		case part == "":
+			continue
+		default:
+			return opts, true, fmt.Errorf("unknown normalize option %q", part)
+		}
+	}
+
+	return opts, true, nil
+}
+
+// isNumberFormat проверяет, является ли значение известным форматом номера
+func isNumberFormat(format NumberFormat) bool {
+	switch format {
+	case FormatE164, FormatInternational, FormatNational, FormatRFC3966:
+		return true
+	}
+	return false
+}
+
+// NormalizeFieldError ошибка нормализации одного поля
+type NormalizeFieldError struct {
+	// Field путь к полю, например "Contacts[0].Phone"
+	Field string `json:"field"`
+
+	// Value исходное значение
+	Value string `json:"value"`
+
+	// Err причина ошибки
+	Err error `json:"-"`
+}
+
+// Error реализует интерфейс error
+func (fe *NormalizeFieldError) Error() string {
+	return fmt.Sprintf("%s: %v", fe.Field, fe.Err)
+}
+
+// Unwrap возвращает причину ошибки
+func (fe *NormalizeFieldError) Unwrap() error {
+	return fe.Err
+}
+
+// NormalizeError ошибки нормализации всех полей структуры
+type NormalizeError struct {
+	// Fields ошибки по полям в порядке обхода
+	Fields []*NormalizeFieldError `json:"fields"`
+}
+
+// Error реализует интерфейс error
+func (ne *NormalizeError) Error() string {
+	messages := make([]string, len(ne.Fields))
+	for i, field := range ne.Fields {
+		messages[i] = field.Error()
+	}
+	return "phone normalization failed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap возвращает ошибки полей
+func (ne *NormalizeError) Unwrap() []error {
+	errs := make([]error, len(ne.Fields))
+	for i, field := range ne.Fields {
+		errs[i] = field
+	}
+	return errs
+}
+
+// NormalizeStruct переписывает строковые поля структуры, помеченные тегом
+// mnv:"normalize,<формат>[,region=<страна>|,region_field=<поле>]", в канонический вид.
+// Обходит вложенные структуры, указатели, срезы и карты. Поддерживаются поля string,
+// *string, sql.NullString, а также срезы и карты строк. Пустые значения не изменяются.
+// Ошибки всех полей собираются в *NormalizeError; корректные поля нормализуются в любом случае
+func NormalizeStruct(ptr any) error {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("mnv: NormalizeStruct requires a non-nil pointer, got %T", ptr)
+	}
+
+	n := &structNormalizer{visited: make(map[uintptr]bool)}
+	n.walk(value, "")
+
+	if len(n.errors) > 0 {
+		return &NormalizeError{Fields: n.errors}
+	}
+	return nil
+}
+
+// structNormalizer состояние обхода NormalizeStruct
+type structNormalizer struct {
+	errors  []*NormalizeFieldError
+	visited map[uintptr]bool
+}
+
+// walk обходит значение и нормализует помеченные поля вложенных структур
+func (n *structNormalizer) walk(value reflect.Value, path string) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() || n.visited[value.Pointer()] {
+			return
+		}
+		n.visited[value.Pointer()] = true
+		n.walk(value.Elem(), path)
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		// Значение в интерфейсе неадресуемо: обходим только указатели
+		if elem := value.Elem(); elem.Kind() == reflect.Ptr {
+			n.walk(elem, path)
+		}
+
+	case reflect.Struct:
+		n.walkStruct(value, path)
+
+	case reflect.Slice, reflect.Array:
+		if !isTraversable(value.Type().Elem()) {
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			n.walk(value.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+
+	case reflect.Map:
+		if !isTraversable(value.Type().Elem()) {
+			return
+		}
+		for _, key := range sortedMapKeys(value) {
+			elem := value.MapIndex(key)
+			elemPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+
+			if elem.Kind() == reflect.Struct && value.CanSet() {
+				// Значения карты неадресуемы: нормализуем копию и записываем обратно
+				copied := reflect.New(elem.Type()).Elem()
+				copied.Set(elem)
+				n.walkStruct(copied, elemPath)
+				value.SetMapIndex(key, copied)
+				continue
+			}
+			n.walk(elem, elemPath)
+		}
+	}
+}
+
+// isTraversable проверяет, могут ли значения типа содержать структуры
+func isTraversable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// walkStruct обходит поля структуры
+func (n *structNormalizer) walkStruct(value reflect.Value, path string) {
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		opts, tagged, err := parseNormalizeTag(field.Tag.Get(normalizeTag))
+		if err != nil {
+			n.fail(fieldPath, "", err)
+			continue
+		}
+		if !tagged {
+			n.walk(fieldValue, fieldPath)
+			continue
+		}
+
+		if opts.regionField != "" {
+			region, _ := countryFromField(value, opts.regionField)
+			opts.region = region
+		}
+		n.normalizeValue(fieldValue, fieldPath, opts)
+	}
+}
+
+// normalizeValue нормализует помеченное поле: строку, указатель на строку,
+// sql.NullString, срез или карту строк
+func (n *structNormalizer) normalizeValue(value reflect.Value, path string, opts normalizeOptions) {
+	if !value.CanSet() {
+		return
+	}
+
+	if value.Type() == reflect.TypeOf(sql.NullString{}) {
+		ns := value.Interface().(sql.NullString)
+		if ns.Valid {
+			if normalized, ok := n.normalizeString(ns.String, path, opts); ok {
+				value.Set(reflect.ValueOf(sql.NullString{String: normalized, Valid: true}))
+			}
+		}
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		if normalized, ok := n.normalizeString(value.String(), path, opts); ok {
+			value.SetString(normalized)
+		}
+
+	case reflect.Ptr:
+		if !value.IsNil() {
+			n.normalizeValue(value.Elem(), path, opts)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			n.normalizeValue(value.Index(i), fmt.Sprintf("%s[%d]", path, i), opts)
+		}
+
+	case reflect.Map:
+		if value.Type().Elem().Kind() != reflect.String {
+			n.fail(path, "", fmt.Errorf("unsupported field type %s", value.Type()))
+			return
+		}
+		for _, key := range sortedMapKeys(value) {
+			elemPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+			if normalized, ok := n.normalizeString(value.MapIndex(key).String(), elemPath, opts); ok {
+				value.SetMapIndex(key, reflect.ValueOf(normalized).Convert(value.Type().Elem()))
+			}
+		}
+
+	default:
+		n.fail(path, "", fmt.Errorf("unsupported field type %s", value.Type()))
+	}
+}
+
+// normalizeString нормализует одно значение; пустые значения пропускаются
+func (n *structNormalizer) normalizeString(phone, path string, opts normalizeOptions) (string, bool) {
+	if strings.TrimSpace(phone) == "" {
+		return "", false
+	}
+
+	normalized, err := FormatNumber(phone, opts.region, opts.format)
+	if err != nil {
+		n.fail(path, phone, err)
+		return "", false
+	}
+	return normalized, true
+}
+
+// sortedMapKeys возвращает ключи карты в порядке их строкового представления,
+// чтобы ошибки перечислялись детерминированно
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// fail добавляет ошибку поля
+func (n *structNormalizer) fail(path, value string, err error) {
+	n.errors = append(n.errors, &NormalizeFieldError{Field: path, Value: value, Err: err})
+}
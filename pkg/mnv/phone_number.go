@@ -0,0 +1,192 @@
+package mnv
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PhoneNumber проверенный номер телефона, хранящийся в формате E.164.
+// Нулевое значение означает отсутствие номера. Значение реализует кодеки JSON,
+// текста, SQL и YAML, поэтому может использоваться в DTO вместо строки
+type PhoneNumber struct {
+	e164    string
+	country string
+}
+
+// ParsePhoneNumber разбирает и проверяет номер. Номера в национальном формате
+// дополняются кодом страны region; если region пуст, страна определяется по номеру.
+// При ошибке возвращается *ValidationError
+func ParsePhoneNumber(phone, region string) (PhoneNumber, error) {
+	e164, ok := normalizeToE164(phone, resolveCountryCode(region))
+	if !ok {
+		return PhoneNumber{}, NewInvalidFormatError(phone, region)
+	}
+
+	result := ValidatePhone(e164, region)
+	if err := result.ToError(); err != nil {
+		err.Phone = phone
+		return PhoneNumber{}, err
+	}
+
+	return PhoneNumber{e164: result.FormattedNumber, country: result.CountryCode}, nil
+}
+
+// MustParsePhoneNumber как ParsePhoneNumber, но паникует при ошибке
+func MustParsePhoneNumber(phone, region string) PhoneNumber {
+	number, err := ParsePhoneNumber(phone, region)
+	if err != nil {
+		panic(err)
+	}
+	return number
+}
+
+// E164 возвращает номер в формате E.164 (пустую строку для нулевого значения)
+func (p PhoneNumber) E164() string {
+	return p.e164
+}
+
+// Country возвращает код страны номера
+func (p PhoneNumber) Country() string {
+	return p.country
+}
+
+// IsZero проверяет, задан ли номер
+func (p PhoneNumber) IsZero() bool {
+	return p.e164 == ""
+}
+
+// Formatted возвращает номер в формате format
+func (p PhoneNumber) Formatted(format NumberFormat) string {
+	if p.IsZero() {
+		return ""
+	}
+	formatted, err := FormatNumber(p.e164, p.country, format)
+	if err != nil {
+		return p.e164
+	}
+	return formatted
+}
+
+// String возвращает номер в формате E.164
+func (p PhoneNumber) String() string {
+	return p.e164
+}
+
+// Format реализует fmt.Formatter:
+// %s, %v - E.164; %q - E.164 в кавычках; %i - международный формат;
+// %n - национальный формат; %t - URI по RFC 3966
+func (p PhoneNumber) Format(f fmt.State, verb rune) {
+	var text string
+	switch verb {
+	case 's', 'v':
+		text = p.e164
+	case 'q':
+		text = strconv.Quote(p.e164)
+	case 'i':
+		text = p.Formatted(FormatInternational)
+	case 'n':
+		text = p.Formatted(FormatNational)
+	case 't':
+		text = p.Formatted(FormatRFC3966)
+	default:
+		text = fmt.Sprintf("%%!%c(mnv.PhoneNumber=%s)", verb, p.e164)
+	}
+
+	if width, ok := f.Width(); ok && len([]rune(text)) < width {
+		padding := strings.Repeat(" ", width-len([]rune(text)))
+		if f.Flag('-') {
+			text += padding
+		} else {
+			text = padding + text
+		}
+	}
+	fmt.Fprint(f, text)
+}
+
+// MarshalText реализует encoding.TextMarshaler
+func (p PhoneNumber) MarshalText() ([]byte, error) {
+	return []byte(p.e164), nil
+}
+
+// UnmarshalText реализует encoding.TextUnmarshaler. Пустой текст дает нулевое значение
+func (p *PhoneNumber) UnmarshalText(text []byte) error {
+	return p.parse(string(text))
+}
+
+// MarshalJSON реализует json.Marshaler. Нулевое значение кодируется как null
+func (p PhoneNumber) MarshalJSON() ([]byte, error) {
+	if p.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(p.e164)
+}
+
+// UnmarshalJSON реализует json.Unmarshaler. Принимает строку или null
+func (p *PhoneNumber) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*p = PhoneNumber{}
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return NewValidationError(ErrorTypeInvalidFormat, "phone number must be a JSON string", string(data), "", nil)
+	}
+	return p.parse(text)
+}
+
+// Scan реализует sql.Scanner. NULL дает нулевое значение
+func (p *PhoneNumber) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*p = PhoneNumber{}
+		return nil
+	case string:
+		return p.parse(value)
+	case []byte:
+		return p.parse(string(value))
+	default:
+		return NewValidationError(ErrorTypeInvalidFormat, fmt.Sprintf("cannot scan %T into PhoneNumber", src), "", "", nil)
+	}
+}
+
+// Value реализует driver.Valuer. Нулевое значение сохраняется как NULL
+func (p PhoneNumber) Value() (driver.Value, error) {
+	if p.IsZero() {
+		return nil, nil
+	}
+	return p.e164, nil
+}
+
+// MarshalYAML реализует yaml.Marshaler (gopkg.in/yaml.v2 и v3)
+func (p PhoneNumber) MarshalYAML() (interface{}, error) {
+	return p.e164, nil
+}
+
+// UnmarshalYAML реализует yaml.Unmarshaler в стиле gopkg.in/yaml.v2 (поддерживается и v3)
+func (p *PhoneNumber) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	return p.parse(text)
+}
+
+// parse разбирает номер в значение; пустая строка дает нулевое значение
+func (p *PhoneNumber) parse(text string) error {
+	if strings.TrimSpace(text) == "" {
+		*p = PhoneNumber{}
+		return nil
+	}
+
+	number, err := ParsePhoneNumber(text, "")
+	if err != nil {
+		return err
+	}
+	*p = number
+	return nil
+}
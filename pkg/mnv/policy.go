@@ -0,0 +1,182 @@
+package mnv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RegionGroups группы стран, которые можно указывать в AllowedCountries и ForbiddenCountries
+var RegionGroups = map[string][]string{
+	// cis государства - участники СНГ
+	"cis": {"am", "az", "by", "kg", "kz", "md", "ru", "tj", "uz"},
+
+	// eaeu государства - члены Евразийского экономического союза
+	"eaeu": {"am", "by", "kg", "kz", "ru"},
+
+	// central_asia страны Центральной Азии
+	"central_asia": {"kg", "kz", "tj", "tm", "uz"},
+
+	// eu поддерживаемые страны Европейского союза
+	"eu": {"de", "es", "fr", "it", "nl"},
+
+	// north_america страны Северной Америки
+	"north_america": {"ca", "mx", "us"},
+
+	// middle_east страны Ближнего Востока
+	"middle_east": {"ae", "il", "sa"},
+}
+
+// ExpandRegion возвращает коды стран группы или страны. Название группы нечувствительно
+// к регистру; страна может быть задана кодом, кодом ISO или названием
+func ExpandRegion(region string) []string {
+	key := strings.ToLower(strings.TrimSpace(region))
+	if key == "" {
+		return nil
+	}
+
+	if group, exists := RegionGroups[key]; exists {
+		codes := make([]string, len(group))
+		copy(codes, group)
+		return codes
+	}
+
+	return []string{resolveCountryCode(region)}
+}
+
+// ValidatePolicy проверяет страны политики opts: каждый элемент AllowedCountries и
+// ForbiddenCountries должен быть группой RegionGroups или поддерживаемой страной,
+// ExpectedCountry - поддерживаемой страной. Неизвестный элемент иначе не совпадает
+// ни с одной страной, и, например, список запрещенных стран молча не действует
+func ValidatePolicy(opts *ValidationOptions) error {
+	if opts == nil {
+		return nil
+	}
+
+	lists := []struct {
+		name    string
+		entries []string
+	}{
+		{"allowed countries", opts.AllowedCountries},
+		{"forbidden countries", opts.ForbiddenCountries},
+	}
+	for _, list := range lists {
+		for _, entry := range list.entries {
+			if _, isGroup := RegionGroups[strings.ToLower(strings.TrimSpace(entry))]; isGroup {
+				continue
+			}
+			if err := checkPolicyCountry(entry); err != nil {
+				return fmt.Errorf("%s: %w", list.name, err)
+			}
+		}
+	}
+
+	if opts.ExpectedCountry != "" {
+		if err := checkPolicyCountry(opts.ExpectedCountry); err != nil {
+			return fmt.Errorf("expected country: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkPolicyCountry проверяет, что страна политики поддерживается
+func checkPolicyCountry(country string) error {
+	if _, exists := GetCountryInfo(resolveCountryCode(country)); exists {
+		return nil
+	}
+	if similar := findSimilarCountries(country); len(similar) > 0 {
+		return fmt.Errorf("unknown country or region group %q (did you mean %s?)", country, strings.Join(similar, ", "))
+	}
+	return fmt.Errorf("unknown country or region group %q", country)
+}
+
+// expandCountryList раскрывает группы и приводит коды стран к внутренним
+func expandCountryList(list []string) map[string]bool {
+	if len(list) == 0 {
+		return nil
+	}
+
+	codes := make(map[string]bool)
+	for _, entry := range list {
+		for _, code := range ExpandRegion(entry) {
+			codes[code] = true
+		}
+	}
+	return codes
+}
+
+// countryPolicy ограничения на страны из ValidationOptions
+type countryPolicy struct {
+	allowed   map[string]bool
+	forbidden map[string]bool
+	expected  string
+}
+
+// newCountryPolicy создает политику из опций валидации (opts может быть nil)
+func newCountryPolicy(opts *ValidationOptions) countryPolicy {
+	if opts == nil {
+		return countryPolicy{}
+	}
+
+	policy := countryPolicy{
+		allowed:   expandCountryList(opts.AllowedCountries),
+		forbidden: expandCountryList(opts.ForbiddenCountries),
+	}
+	if opts.ExpectedCountry != "" {
+		policy.expected = resolveCountryCode(opts.ExpectedCountry)
+	}
+	return policy
+}
+
+// allows проверяет, разрешена ли страна. Запрет имеет приоритет над разрешением
+func (p countryPolicy) allows(code string) bool {
+	if p.forbidden[code] {
+		return false
+	}
+	return p.allowed == nil || p.allowed[code]
+}
+
+// detect определяет страну номера. Порядок предпочтения: ожидаемая страна,
+// разрешенные страны, остальные страны (чтобы сообщить о запрете). Если номер не
+// подходит ни одной стране полностью, страна определяется по префиксу
+func (p countryPolicy) detect(phone string) (string, bool) {
+	codes := detectionOrder()
+	if p.expected != "" {
+		sort.SliceStable(codes, func(i, j int) bool {
+			return codes[i] == p.expected && codes[j] != p.expected
+		})
+	}
+	sort.SliceStable(codes, func(i, j int) bool {
+		return p.allows(codes[i]) && !p.allows(codes[j])
+	})
+
+	for _, code := range codes {
+		if validatePhoneForCountry(phone, code) {
+			return code, true
+		}
+	}
+
+	// Номер не прошел проверку ни для одной страны: ищем страну с самым длинным префиксом
+	best := ""
+	for _, code := range codes {
+		prefix := CountryPhoneCodes[code].Prefix
+		if strings.HasPrefix(phone, prefix) && len(prefix) > len(CountryPhoneCodes[best].Prefix) {
+			best = code
+		}
+	}
+	return best, best != ""
+}
+
+// DetectCountry определяет страну номера с учетом опций: ожидаемая страна
+// предпочитается при общих кодах (+1, +7), разрешенные страны - перед остальными.
+// Возвращает false, если номер не подходит ни одной разрешенной стране
+func DetectCountry(phone string, opts *ValidationOptions) (string, bool) {
+	policy := newCountryPolicy(opts)
+	phone = cleanPhoneNumber(phone)
+
+	code, found := policy.detect(phone)
+	if !found || !policy.allows(code) || !validatePhoneForCountry(phone, code) {
+		return "", false
+	}
+	return code, true
+}
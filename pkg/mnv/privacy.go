@@ -0,0 +1,339 @@
+package mnv
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// minPseudonymSecretLength минимальная длина секрета в байтах
+const minPseudonymSecretLength = 16
+
+// fpeRounds количество раундов сети Фейстеля при токенизации
+const fpeRounds = 10
+
+// fpeMaxCycles ограничение на количество итераций cycle-walking
+const fpeMaxCycles = 1000
+
+// ErrUnknownPseudonymKey токен создан ключом, которого нет в связке
+var ErrUnknownPseudonymKey = errors.New("unknown pseudonym key")
+
+// PseudonymKey ключ для псевдонимизации и токенизации номеров
+type PseudonymKey struct {
+	// ID идентификатор ключа, попадает в префикс токена
+	ID string `json:"id"`
+
+	// Secret секрет HMAC (не менее 16 байт)
+	Secret []byte `json:"-"`
+}
+
+// Validate проверяет корректность ключа
+func (k PseudonymKey) Validate() error {
+	if k.ID == "" {
+		return errors.New("pseudonym key ID cannot be empty")
+	}
+	for _, char := range k.ID {
+		if !isKeyIDChar(char) {
+			return fmt.Errorf("pseudonym key ID %q contains invalid character %q", k.ID, char)
+		}
+	}
+	if len(k.Secret) < minPseudonymSecretLength {
+		return fmt.Errorf("pseudonym key %s: secret must be at least %d bytes", k.ID, minPseudonymSecretLength)
+	}
+	return nil
+}
+
+// isKeyIDChar проверяет, допустим ли символ в идентификаторе ключа
+func isKeyIDChar(char rune) bool {
+	return char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' ||
+		char >= '0' && char <= '9' || char == '-' || char == '_'
+}
+
+// Pseudonymize возвращает детерминированный псевдоним номера: HMAC-SHA256 от номера
+// в формате E.164 с префиксом идентификатора ключа ("<id>:<hmac>").
+// Разные записи одного номера ("+996 700 123 456", "00996700123456") дают один псевдоним
+func Pseudonymize(number string, key PseudonymKey) (string, error) {
+	return PseudonymizeInRegion(number, "", key)
+}
+
+// PseudonymizeInRegion работает как Pseudonymize, но номера в национальном формате
+// дополняет кодом страны region
+func PseudonymizeInRegion(number, region string, key PseudonymKey) (string, error) {
+	if err := key.Validate(); err != nil {
+		return "", err
+	}
+
+	e164, err := NormalizeE164(number, region)
+	if err != nil {
+		return "", err
+	}
+
+	return key.ID + ":" + pseudonymDigest(e164, key), nil
+}
+
+// pseudonymDigest вычисляет HMAC номера в формате E.164
+func pseudonymDigest(e164 string, key PseudonymKey) string {
+	mac := hmac.New(sha256.New, key.Secret)
+	mac.Write([]byte(e164))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// PseudonymKeyID возвращает идентификатор ключа, которым создан псевдоним
+func PseudonymKeyID(token string) (string, bool) {
+	id, digest, found := strings.Cut(token, ":")
+	if !found || id == "" || digest == "" {
+		return "", false
+	}
+	return id, true
+}
+
+// Keyring связка ключей псевдонимизации с поддержкой ротации.
+// Новые псевдонимы создаются основным ключом, старые проверяются ключом из префикса токена
+type Keyring struct {
+	mu      sync.RWMutex
+	keys    map[string]PseudonymKey
+	primary string
+}
+
+// NewKeyring создает связку с основным ключом и (необязательно) предыдущими ключами
+func NewKeyring(primary PseudonymKey, previous ...PseudonymKey) (*Keyring, error) {
+	kr := &Keyring{keys: make(map[string]PseudonymKey)}
+
+	for _, key := range previous {
+		if err := kr.Add(key); err != nil {
+			return nil, err
+		}
+	}
+	if err := kr.Rotate(primary); err != nil {
+		return nil, err
+	}
+
+	return kr, nil
+}
+
+// Add добавляет ключ в связку, не делая его основным
+func (kr *Keyring) Add(key PseudonymKey) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.keys[key.ID] = key
+	return nil
+}
+
+// Rotate добавляет ключ и делает его основным
+func (kr *Keyring) Rotate(key PseudonymKey) error {
+	if err := kr.Add(key); err != nil {
+		return err
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.primary = key.ID
+	return nil
+}
+
+// Remove удаляет ключ из связки. Основной ключ удалить нельзя
+func (kr *Keyring) Remove(id string) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	if id == kr.primary {
+		return fmt.Errorf("cannot remove primary pseudonym key %s", id)
+	}
+	delete(kr.keys, id)
+	return nil
+}
+
+// PrimaryKeyID возвращает идентификатор основного ключа
+func (kr *Keyring) PrimaryKeyID() string {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	return kr.primary
+}
+
+// Key возвращает ключ по идентификатору
+func (kr *Keyring) Key(id string) (PseudonymKey, bool) {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	key, exists := kr.keys[id]
+	return key, exists
+}
+
+// Pseudonymize создает псевдоним основным ключом
+func (kr *Keyring) Pseudonymize(number string) (string, error) {
+	return kr.PseudonymizeInRegion(number, "")
+}
+
+// PseudonymizeInRegion создает псевдоним основным ключом с учетом региона по умолчанию
+func (kr *Keyring) PseudonymizeInRegion(number, region string) (string, error) {
+	key, _ := kr.Key(kr.PrimaryKeyID())
+	return PseudonymizeInRegion(number, region, key)
+}
+
+// Match проверяет, соответствует ли псевдоним номеру. Используется ключ из префикса токена,
+// поэтому псевдонимы, созданные до ротации, продолжают сопоставляться
+func (kr *Keyring) Match(number, token string) (bool, error) {
+	return kr.MatchInRegion(number, "", token)
+}
+
+// MatchInRegion работает как Match с учетом региона по умолчанию
+func (kr *Keyring) MatchInRegion(number, region, token string) (bool, error) {
+	id, ok := PseudonymKeyID(token)
+	if !ok {
+		return false, fmt.Errorf("malformed pseudonym %q", token)
+	}
+
+	key, exists := kr.Key(id)
+	if !exists {
+		return false, fmt.Errorf("%w: %s", ErrUnknownPseudonymKey, id)
+	}
+
+	expected, err := PseudonymizeInRegion(number, region, key)
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1, nil
+}
+
+// NeedsRotation сообщает, создан ли псевдоним не основным ключом
+func (kr *Keyring) NeedsRotation(token string) bool {
+	id, ok := PseudonymKeyID(token)
+	return !ok || id != kr.PrimaryKeyID()
+}
+
+// Tokenize выполняет сохраняющую формат токенизацию: код страны, первая цифра
+// национального номера и длина сохраняются, остальные цифры шифруются сетью Фейстеля
+// (по схеме FF1) с ключом HMAC-SHA256. Результат остается валидным номером той же страны,
+// поэтому GetPhoneInfo продолжает работать. Операция обратима через Detokenize
+func Tokenize(number string, key PseudonymKey) (string, error) {
+	return transformToken(number, key, true)
+}
+
+// Detokenize восстанавливает исходный номер из токена, созданного Tokenize
+func Detokenize(token string, key PseudonymKey) (string, error) {
+	return transformToken(token, key, false)
+}
+
+// transformToken выполняет шифрование или расшифровку с cycle-walking,
+// пока результат не будет соответствовать метаданным страны
+func transformToken(number string, key PseudonymKey, encrypt bool) (string, error) {
+	if err := key.Validate(); err != nil {
+		return "", err
+	}
+
+	e164, err := NormalizeE164(number, "")
+	if err != nil {
+		return "", err
+	}
+
+	country, info, found := detectCountryByMetadata(e164)
+	if !found {
+		return "", NewValidationError(ErrorTypeUnsupportedCountry, "cannot determine country for tokenization", number, "", nil)
+	}
+
+	// Первая цифра национального номера определяет тип номера и сохраняется
+	nsn := strings.TrimPrefix(e164, info.Prefix)
+	if len(nsn) < 3 {
+		return "", NewValidationError(ErrorTypeInvalidLength, "phone number is too short for tokenization", number, country, nil)
+	}
+	head, body := info.Prefix+nsn[:1], nsn[1:]
+	tweak := []byte(info.Prefix + ":" + strconv.Itoa(len(nsn)))
+
+	for i := 0; i < fpeMaxCycles; i++ {
+		if encrypt {
+			body = fpeEncrypt(key.Secret, tweak, body)
+		} else {
+			body = fpeDecrypt(key.Secret, tweak, body)
+		}
+		if matchesCountryMetadata(head+body, info) {
+			return head + body, nil
+		}
+	}
+
+	return "", NewValidationError(ErrorTypeUnknown, "tokenization did not converge", number, country, nil)
+}
+
+// fpeEncrypt шифрует строку десятичных цифр с сохранением длины
+func fpeEncrypt(secret, tweak []byte, digits string) string {
+	u := len(digits) / 2
+	a, b := digits[:u], digits[u:]
+
+	for round := 0; round < fpeRounds; round++ {
+		m := fpeHalfLength(round, len(digits))
+		c := (parseDigits(a) + fpeRound(secret, tweak, round, b, m)) % pow10(m)
+		a, b = b, formatDigits(c, m)
+	}
+
+	return a + b
+}
+
+// fpeDecrypt выполняет обратное к fpeEncrypt преобразование
+func fpeDecrypt(secret, tweak []byte, digits string) string {
+	u := len(digits) / 2
+	a, b := digits[:u], digits[u:]
+
+	for round := fpeRounds - 1; round >= 0; round-- {
+		m := fpeHalfLength(round, len(digits))
+		modulus := pow10(m)
+		c := (parseDigits(b) + modulus - fpeRound(secret, tweak, round, a, m)) % modulus
+		a, b = formatDigits(c, m), a
+	}
+
+	return a + b
+}
+
+// fpeHalfLength возвращает длину изменяемой половины в раунде
+func fpeHalfLength(round, n int) int {
+	if round%2 == 0 {
+		return n / 2
+	}
+	return n - n/2
+}
+
+// fpeRound раундовая функция: HMAC от номера раунда и неизменяемой половины
+func fpeRound(secret, tweak []byte, round int, half string, m int) uint64 {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("mnv-fpe:"))
+	mac.Write(tweak)
+	mac.Write([]byte{':', byte(round), ':'})
+	mac.Write([]byte(half))
+	return binary.BigEndian.Uint64(mac.Sum(nil)[:8]) % pow10(m)
+}
+
+// parseDigits преобразует строку цифр в число
+func parseDigits(s string) uint64 {
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		n = n*10 + uint64(s[i]-'0')
+	}
+	return n
+}
+
+// formatDigits преобразует число в строку цифр фиксированной длины
+func formatDigits(n uint64, width int) string {
+	s := strconv.FormatUint(n, 10)
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
+
+// pow10 возвращает 10^n
+func pow10(n int) uint64 {
+	result := uint64(1)
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
@@ -0,0 +1,317 @@
+package mnv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReportFormat формат вывода отчета о качестве данных
+type ReportFormat string
+
+const (
+	// ReportFormatJSON отчет в JSON
+	ReportFormatJSON ReportFormat = "json"
+
+	// ReportFormatMarkdown отчет в Markdown
+	ReportFormatMarkdown ReportFormat = "markdown"
+
+	// ReportFormatHTML самодостаточная страница HTML
+	ReportFormatHTML ReportFormat = "html"
+)
+
+// InputFix исправление, потребовавшееся для приведения валидного номера к E.164
+type InputFix string
+
+const (
+	// FixReformatted удалены разделители международного номера
+	FixReformatted InputFix = "reformatted"
+
+	// FixPlusAdded добавлен знак + (или международный префикс 00 заменен на +)
+	FixPlusAdded InputFix = "plus_added"
+
+	// FixTrunkPrefixStripped удален национальный префикс (0, 8) и добавлен код страны
+	FixTrunkPrefixStripped InputFix = "trunk_prefix_stripped"
+
+	// FixCountryCodeAdded добавлен код страны к национальному номеру без префикса
+	FixCountryCodeAdded InputFix = "country_code_added"
+)
+
+// Параметры отчета
+const (
+	// reportTopPrefixes количество префиксов в списке частых невалидных префиксов
+	reportTopPrefixes = 10
+
+	// invalidPrefixDigits количество цифр префикса невалидного номера
+	invalidPrefixDigits = 4
+)
+
+// CountStats количество и доля значения в отчете
+type CountStats struct {
+	// Name значение (тип ошибки, тип номера, исправление, префикс)
+	Name string `json:"name"`
+
+	// Count количество
+	Count int `json:"count"`
+
+	// Share доля в процентах от базы раздела
+	Share float64 `json:"share"`
+}
+
+// BatchReport отчет о качестве данных пакета номеров
+type BatchReport struct {
+	// GeneratedAt время формирования отчета
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// Total общее количество номеров
+	Total int `json:"total"`
+
+	// Valid количество валидных номеров
+	Valid int `json:"valid"`
+
+	// Invalid количество проверенных невалидных номеров
+	Invalid int `json:"invalid"`
+
+	// Errors количество номеров, которые не удалось проверить
+	Errors int `json:"errors"`
+
+	// SuccessRate доля валидных номеров в процентах
+	SuccessRate float64 `json:"success_rate"`
+
+	// Unique количество различных валидных номеров после приведения к E.164
+	Unique int `json:"unique"`
+
+	// Duplicates количество повторов валидных номеров после приведения к E.164
+	Duplicates int `json:"duplicates"`
+
+	// Fixed количество валидных номеров, потребовавших исправления записи
+	Fixed int `json:"fixed"`
+
+	// ByCountry статистика по странам, по убыванию количества номеров.
+	// Номера без определенной страны учитываются с пустым кодом
+	ByCountry []CountryStats `json:"by_country"`
+
+	// ByErrorType невалидные и непроверенные номера по типу ошибки (доля от их числа)
+	ByErrorType []CountStats `json:"by_error_type"`
+
+	// ByType валидные номера по типу номера (доля от валидных)
+	ByType []CountStats `json:"by_type"`
+
+	// Fixes валидные номера по виду исправления (доля от валидных)
+	Fixes []CountStats `json:"fixes"`
+
+	// TopInvalidPrefixes самые частые начала невалидных номеров: первые цифры,
+	// с + для международной записи (доля от невалидных)
+	TopInvalidPrefixes []CountStats `json:"top_invalid_prefixes"`
+}
+
+// ReportBuilder накапливает результаты проверки для BatchReport. Память зависит только от
+// количества различных валидных номеров, поэтому построитель подходит для потоковой проверки.
+// Не безопасен для одновременного использования
+type ReportBuilder struct {
+	stats      BatchStats
+	countries  map[string]*CountryStats
+	errorTypes map[string]int
+	types      map[string]int
+	fixes      map[string]int
+	prefixes   map[string]int
+	seen       map[string]struct{}
+	duplicates int
+}
+
+// NewReportBuilder создает построитель отчета
+func NewReportBuilder() *ReportBuilder {
+	return &ReportBuilder{
+		stats:      BatchStats{ByCountry: make(map[string]int)},
+		countries:  make(map[string]*CountryStats),
+		errorTypes: make(map[string]int),
+		types:      make(map[string]int),
+		fixes:      make(map[string]int),
+		prefixes:   make(map[string]int),
+		seen:       make(map[string]struct{}),
+	}
+}
+
+// BuildReport строит отчет по результатам пакетной проверки
+func BuildReport(results []ValidationResult) *BatchReport {
+	builder := NewReportBuilder()
+	for i := range results {
+		builder.Add(&results[i])
+	}
+	return builder.Report()
+}
+
+// Add учитывает результат проверки. OriginalNumber должен содержать исходную запись номера
+func (b *ReportBuilder) Add(result *ValidationResult) {
+	b.stats.Total++
+	b.stats.add(result)
+
+	country := b.countries[result.CountryCode]
+	if country == nil {
+		country = &CountryStats{CountryCode: result.CountryCode, CountryName: result.CountryName}
+		if country.CountryName == "" && result.CountryCode != "" {
+			country.CountryName = CountryName(result.CountryCode, DefaultLanguage)
+		}
+		b.countries[result.CountryCode] = country
+	}
+	country.TotalValidated++
+
+	if !result.IsValid {
+		country.InvalidNumbers++
+		errorType := result.ErrorType
+		if errorType == "" {
+			errorType = ErrorTypeInvalidFormat
+		}
+		b.errorTypes[string(errorType)]++
+		if !isProcessingError(errorType) {
+			if prefix := invalidPrefix(result.OriginalNumber); prefix != "" {
+				b.prefixes[prefix]++
+			}
+		}
+		return
+	}
+
+	country.ValidNumbers++
+	phoneType := result.Type
+	if phoneType == "" {
+		phoneType = PhoneTypeUnknown
+	}
+	b.types[string(phoneType)]++
+
+	if fix := classifyFix(result.OriginalNumber, result.FormattedNumber, result.CountryCode); fix != "" {
+		b.fixes[string(fix)]++
+	}
+	if _, exists := b.seen[result.FormattedNumber]; exists {
+		b.duplicates++
+	} else {
+		b.seen[result.FormattedNumber] = struct{}{}
+	}
+}
+
+// Report возвращает отчет по учтенным результатам
+func (b *ReportBuilder) Report() *BatchReport {
+	report := &BatchReport{
+		GeneratedAt:        time.Now(),
+		Total:              b.stats.Total,
+		Valid:              b.stats.Valid,
+		Invalid:            b.stats.Invalid,
+		Errors:             b.stats.Errors,
+		SuccessRate:        percent(b.stats.Valid, b.stats.Total),
+		Unique:             len(b.seen),
+		Duplicates:         b.duplicates,
+		ByCountry:          make([]CountryStats, 0, len(b.countries)),
+		ByErrorType:        countStats(b.errorTypes, b.stats.Invalid+b.stats.Errors, 0),
+		ByType:             countStats(b.types, b.stats.Valid, 0),
+		Fixes:              countStats(b.fixes, b.stats.Valid, 0),
+		TopInvalidPrefixes: countStats(b.prefixes, b.stats.Invalid, reportTopPrefixes),
+	}
+	for _, fix := range report.Fixes {
+		report.Fixed += fix.Count
+	}
+
+	for _, country := range b.countries {
+		stats := *country
+		stats.SuccessRate = percent(stats.ValidNumbers, stats.TotalValidated)
+		report.ByCountry = append(report.ByCountry, stats)
+	}
+	sort.Slice(report.ByCountry, func(i, j int) bool {
+		a, b := report.ByCountry[i], report.ByCountry[j]
+		if a.TotalValidated != b.TotalValidated {
+			return a.TotalValidated > b.TotalValidated
+		}
+		return a.CountryCode < b.CountryCode
+	})
+	return report
+}
+
+// Render выводит отчет в формате format
+func (r *BatchReport) Render(w io.Writer, format ReportFormat) error {
+	switch format {
+	case ReportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case ReportFormatMarkdown:
+		return markdownReport.Execute(w, r)
+	case ReportFormatHTML:
+		return htmlReport.Execute(w, r)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+// classifyFix определяет исправление, приведшее запись original к e164
+func classifyFix(original, e164, country string) InputFix {
+	original = strings.TrimSpace(original)
+	if original == e164 {
+		return ""
+	}
+
+	digits := extractDigitsOnly(original)
+	number := strings.TrimPrefix(e164, "+")
+	switch {
+	case strings.HasPrefix(original, "+"):
+		return FixReformatted
+	case digits == number || digits == "00"+number:
+		return FixPlusAdded
+	}
+
+	nsn := strings.TrimPrefix(number, strings.TrimPrefix(CountryPhoneCodes[country].Prefix, "+"))
+	if digits == nsn {
+		return FixCountryCodeAdded
+	}
+	return FixTrunkPrefixStripped
+}
+
+// invalidPrefix возвращает начало невалидного номера для группировки: первые цифры,
+// с + для международной записи (+ или 00)
+func invalidPrefix(original string) string {
+	original = strings.TrimSpace(original)
+	digits := extractDigitsOnly(original)
+
+	marker := ""
+	switch {
+	case strings.HasPrefix(original, "+"):
+		marker = "+"
+	case strings.HasPrefix(digits, "00"):
+		marker = "+"
+		digits = digits[2:]
+	}
+	if digits == "" {
+		return ""
+	}
+	if len(digits) > invalidPrefixDigits {
+		digits = digits[:invalidPrefixDigits]
+	}
+	return marker + digits
+}
+
+// countStats преобразует счетчики в список по убыванию количества; limit > 0 ограничивает длину
+func countStats(counts map[string]int, base, limit int) []CountStats {
+	stats := make([]CountStats, 0, len(counts))
+	for name, count := range counts {
+		stats = append(stats, CountStats{Name: name, Count: count, Share: percent(count, base)})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+	return stats
+}
+
+// percent возвращает долю part от total в процентах с точностью до сотых
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(total)) / 100
+}
@@ -0,0 +1,125 @@
+package mnv
+
+import (
+	htmltemplate "html/template"
+	"strconv"
+	"text/template"
+)
+
+// reportFuncs функции шаблонов отчета
+var reportFuncs = map[string]any{
+	"pct": func(value float64) string {
+		return strconv.FormatFloat(value, 'f', 2, 64) + "%"
+	},
+	"country": func(stats CountryStats) string {
+		if stats.CountryCode == "" {
+			return "Unknown"
+		}
+		return stats.CountryName + " (" + stats.CountryCode + ")"
+	},
+	"date": func(report *BatchReport) string {
+		return report.GeneratedAt.Format("2006-01-02 15:04:05 MST")
+	},
+	"dict": func(pairs ...any) map[string]any {
+		values := make(map[string]any, len(pairs)/2)
+		for i := 0; i+1 < len(pairs); i += 2 {
+			values[pairs[i].(string)] = pairs[i+1]
+		}
+		return values
+	},
+}
+
+// markdownReport шаблон отчета в Markdown
+var markdownReport = template.Must(template.New("report.md").Funcs(reportFuncs).Parse(`# Phone number data quality report
+
+Generated: {{date .}}
+
+| Metric | Value |
+|---|---:|
+| Total | {{.Total}} |
+| Valid | {{.Valid}} ({{pct .SuccessRate}}) |
+| Invalid | {{.Invalid}} |
+| Not checked (errors) | {{.Errors}} |
+| Unique valid numbers | {{.Unique}} |
+| Duplicates after normalization | {{.Duplicates}} |
+| Inputs that needed fixes | {{.Fixed}} |
+
+## By country
+
+| Country | Total | Valid | Invalid | Success rate |
+|---|---:|---:|---:|---:|
+{{range .ByCountry}}| {{country .}} | {{.TotalValidated}} | {{.ValidNumbers}} | {{.InvalidNumbers}} | {{pct .SuccessRate}} |
+{{end}}
+{{- define "counts"}}
+| {{.title}} | Count | Share |
+|---|---:|---:|
+{{range .items}}| {{.Name}} | {{.Count}} | {{pct .Share}} |
+{{else}}| - | 0 | - |
+{{end}}{{end}}
+## By error type
+{{template "counts" (dict "title" "Error type" "items" .ByErrorType)}}
+## By number type
+{{template "counts" (dict "title" "Type" "items" .ByType)}}
+## Input fixes
+{{template "counts" (dict "title" "Fix" "items" .Fixes)}}
+## Top invalid prefixes
+{{template "counts" (dict "title" "Prefix" "items" .TopInvalidPrefixes)}}`))
+
+// htmlReport шаблон самодостаточной страницы отчета
+var htmlReport = htmltemplate.Must(htmltemplate.New("report.html").Funcs(reportFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Phone number data quality report</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 960px; color: #222; }
+h1 { font-size: 1.6rem; }
+h2 { font-size: 1.2rem; margin-top: 2rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: .4rem .6rem; text-align: left; }
+td.num, th.num { text-align: right; font-variant-numeric: tabular-nums; }
+.summary { display: flex; flex-wrap: wrap; gap: 1rem; }
+.card { border: 1px solid #ddd; border-radius: 6px; padding: .8rem 1rem; min-width: 140px; }
+.card b { display: block; font-size: 1.4rem; }
+.bar { background: #e8eef7; height: .6rem; border-radius: 3px; }
+.bar span { display: block; background: #3b73c4; height: 100%; border-radius: 3px; }
+.muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>Phone number data quality report</h1>
+<p class="muted">Generated: {{date .}}</p>
+
+<div class="summary">
+<div class="card">Total<b>{{.Total}}</b></div>
+<div class="card">Valid<b>{{.Valid}}</b>{{pct .SuccessRate}}</div>
+<div class="card">Invalid<b>{{.Invalid}}</b></div>
+<div class="card">Not checked<b>{{.Errors}}</b></div>
+<div class="card">Unique<b>{{.Unique}}</b></div>
+<div class="card">Duplicates<b>{{.Duplicates}}</b></div>
+<div class="card">Needed fixes<b>{{.Fixed}}</b></div>
+</div>
+
+<h2>By country</h2>
+<table>
+<tr><th>Country</th><th class="num">Total</th><th class="num">Valid</th><th class="num">Invalid</th><th class="num">Success rate</th><th></th></tr>
+{{range .ByCountry}}<tr><td>{{country .}}</td><td class="num">{{.TotalValidated}}</td><td class="num">{{.ValidNumbers}}</td><td class="num">{{.InvalidNumbers}}</td><td class="num">{{pct .SuccessRate}}</td><td><div class="bar"><span style="width: {{.SuccessRate}}%"></span></div></td></tr>
+{{end}}</table>
+{{define "counts"}}
+<table>
+<tr><th>{{.title}}</th><th class="num">Count</th><th class="num">Share</th><th></th></tr>
+{{range .items}}<tr><td>{{.Name}}</td><td class="num">{{.Count}}</td><td class="num">{{pct .Share}}</td><td><div class="bar"><span style="width: {{.Share}}%"></span></div></td></tr>
+{{else}}<tr><td class="muted" colspan="4">None</td></tr>
+{{end}}</table>
+{{end}}
+<h2>By error type</h2>
+{{template "counts" (dict "title" "Error type" "items" .ByErrorType)}}
+<h2>By number type</h2>
+{{template "counts" (dict "title" "Type" "items" .ByType)}}
+<h2>Input fixes</h2>
+{{template "counts" (dict "title" "Fix" "items" .Fixes)}}
+<h2>Top invalid prefixes</h2>
+{{template "counts" (dict "title" "Prefix" "items" .TopInvalidPrefixes)}}
+</body>
+</html>
+`))
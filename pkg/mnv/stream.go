@@ -0,0 +1,280 @@
+package mnv
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// StreamOptions настройки потоковой валидации
+type StreamOptions struct {
+	// Parallel количество воркеров (по умолчанию 10)
+	Parallel int
+
+	// Ordered выдавать результаты в порядке поступления номеров. Без него результаты
+	// выдаются по мере готовности
+	Ordered bool
+
+	// Buffer максимальное количество номеров, принятых из входного потока, но еще не
+	// выданных в выходной (по умолчанию 2*Parallel, не меньше Parallel). Ограничивает
+	// память и окно переупорядочивания: при медленном читателе прием номеров приостанавливается
+	Buffer int
+
+	// DefaultRegion страна для номеров в национальном формате без подсказки
+	DefaultRegion string
+
+	// StatsEvery выдавать снимок статистики после каждых StatsEvery результатов;
+	// 0 - только итоговый снимок
+	StatsEvery int
+
+	// Options опции валидации
+	Options *ValidationOptions
+}
+
+// withDefaults заполняет незаданные настройки значениями по умолчанию
+func (o StreamOptions) withDefaults() StreamOptions {
+	if o.Parallel <= 0 {
+		o.Parallel = defaultBatchParallel
+	}
+	if o.Buffer <= 0 {
+		o.Buffer = 2 * o.Parallel
+	}
+	if o.Buffer < o.Parallel {
+		o.Buffer = o.Parallel
+	}
+	return o
+}
+
+// StreamResult сообщение потоковой валидации: результат проверки номера или снимок статистики
+type StreamResult struct {
+	// Index порядковый номер во входном потоке (с нуля); для снимка статистики -
+	// количество выданных результатов
+	Index int `json:"index"`
+
+	// Result результат проверки; nil для снимка статистики
+	Result *ValidationResult `json:"result,omitempty"`
+
+	// Stats снимок статистики по выданным результатам; Total - количество выданных результатов
+	Stats *BatchStats `json:"stats,omitempty"`
+
+	// Final снимок итоговый: входной поток прочитан и все результаты выданы
+	Final bool `json:"final,omitempty"`
+}
+
+// ValidateStream проверяет номера из in пулом воркеров и выдает результаты в возвращаемый
+// канал. Каждый номер проверяется как в BatchValidatePhonesContext. После каждых
+// StatsEvery результатов (перед следующим результатом) и по завершении входного потока
+// выдается снимок статистики; итоговый снимок всегда последний и отмечен Final. Память
+// ограничена StreamOptions.Buffer независимо от длины потока. Канал закрывается после
+// итогового снимка или при отмене ctx; после отмены итоговый снимок не выдается
+func ValidateStream(ctx context.Context, in <-chan BatchItem, options ...*StreamOptions) <-chan StreamResult {
+	var opts StreamOptions
+	if len(options) > 0 && options[0] != nil {
+		opts = *options[0]
+	}
+	opts = opts.withDefaults()
+
+	type streamJob struct {
+		index int
+		item  BatchItem
+	}
+
+	jobs := make(chan streamJob)
+	outcomes := make(chan batchOutcome, opts.Parallel)
+	out := make(chan StreamResult, opts.Parallel)
+
+	// window ограничивает количество номеров между приемом и выдачей
+	window := make(chan struct{}, opts.Buffer)
+
+	// received и complete читаются только после закрытия readerDone
+	received, complete := 0, false
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		defer close(jobs)
+		for {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			var item BatchItem
+			var ok bool
+			select {
+			case item, ok = <-in:
+			case <-ctx.Done():
+				return
+			}
+			if !ok {
+				complete = true
+				return
+			}
+
+			select {
+			case jobs <- streamJob{index: received, item: item}:
+				received++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				result := validateStreamItem(ctx, job.item, &opts)
+				if result == nil {
+					return
+				}
+
+				select {
+				case outcomes <- batchOutcome{index: job.index, result: *result}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	go func() {
+		defer close(out)
+
+		stats := BatchStats{ByCountry: make(map[string]int)}
+		send := func(message StreamResult) bool {
+			select {
+			case out <- message:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// held промежуточный снимок, ожидающий следующего результата. Если результатов
+		// больше нет, вместо него выдается итоговый снимок с теми же данными
+		var held *BatchStats
+		emit := func(outcome batchOutcome) bool {
+			if held != nil {
+				if !send(StreamResult{Index: held.Total, Stats: held}) {
+					return false
+				}
+				held = nil
+			}
+
+			stats.Total++
+			stats.add(&outcome.result)
+			if !send(StreamResult{Index: outcome.index, Result: &outcome.result}) {
+				return false
+			}
+			<-window
+
+			if opts.StatsEvery > 0 && stats.Total%opts.StatsEvery == 0 {
+				held = stats.snapshot()
+			}
+			return true
+		}
+
+		pending := make(map[int]batchOutcome)
+		next := 0
+		for outcome := range outcomes {
+			if !opts.Ordered {
+				if !emit(outcome) {
+					return
+				}
+				continue
+			}
+
+			pending[outcome.index] = outcome
+			for {
+				ready, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				if !emit(ready) {
+					return
+				}
+			}
+		}
+
+		<-readerDone
+		if !complete || stats.Total < received {
+			return
+		}
+		send(StreamResult{Index: stats.Total, Stats: stats.snapshot(), Final: true})
+	}()
+
+	return out
+}
+
+// ValidateSeq проверяет номера последовательности items как ValidateStream. Если ctx
+// отменен или истек до завершения последовательности, последней выдается ошибка.
+// Прекращение перебора останавливает проверку
+func ValidateSeq(ctx context.Context, items iter.Seq[BatchItem], options ...*StreamOptions) iter.Seq2[StreamResult, error] {
+	return func(yield func(StreamResult, error) bool) {
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		in := make(chan BatchItem)
+		go func() {
+			defer close(in)
+			for item := range items {
+				select {
+				case in <- item:
+				case <-streamCtx.Done():
+					return
+				}
+			}
+		}()
+
+		finished := false
+		for message := range ValidateStream(streamCtx, in, options...) {
+			finished = message.Final
+			if !yield(message, nil) {
+				return
+			}
+		}
+
+		if !finished {
+			if err := ctx.Err(); err != nil {
+				yield(StreamResult{}, contextError(err, "", ""))
+			}
+		}
+	}
+}
+
+// validateStreamItem проверяет номер потока с учетом ctx и PerformanceConfig.
+// Возвращает nil, если номер не проверен из-за отмены ctx
+func validateStreamItem(ctx context.Context, item BatchItem, opts *StreamOptions) *ValidationResult {
+	job := newBatchJob(item, opts.DefaultRegion)
+	result, err := withAdmission(ctx, job.phone, job.country, func() *ValidationResult {
+		return ValidatePhone(job.phone, job.country, opts.Options)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		result = errorResult(job.phone, err)
+	}
+
+	result.OriginalNumber = item.Phone
+	return result
+}
+
+// snapshot возвращает копию статистики
+func (s *BatchStats) snapshot() *BatchStats {
+	snapshot := *s
+	snapshot.ByCountry = make(map[string]int, len(s.ByCountry))
+	for country, count := range s.ByCountry {
+		snapshot.ByCountry[country] = count
+	}
+	return &snapshot
+}
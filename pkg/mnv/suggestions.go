@@ -0,0 +1,248 @@
+package mnv
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Стоимость исправлений: чем она ниже, тем вероятнее исправление
+const (
+	costFormatting         = 0.1
+	costMissingPlus        = 0.2
+	costNationalFormat     = 0.2
+	costTrunkPrefix        = 0.3
+	costDoubledCountryCode = 0.3
+	costOCR                = 0.3 // за каждый исправленный символ
+	costTransposition      = 0.8
+	costMissingDigit       = 1.0
+	costExtraDigit         = 1.0
+	costCountryMismatch    = 1.5 // штраф, если страна не совпадает с ожидаемой
+)
+
+// defaultMaxSuggestions количество предложений по умолчанию
+const defaultMaxSuggestions = 5
+
+// ocrConfusions символы, которые при распознавании текста путают с цифрами
+var ocrConfusions = map[rune]rune{
+	'O': '0', 'o': '0', 'Q': '0',
+	'l': '1', 'I': '1', 'i': '1', '|': '1',
+	'Z': '2', 'z': '2',
+	'S': '5', 's': '5',
+	'G': '6',
+	'B': '8',
+}
+
+// suggestionCandidate кандидат на исправление (цифры без знака +)
+type suggestionCandidate struct {
+	digits   string
+	cost     float64
+	kind     CorrectionKind
+	kindCost float64
+}
+
+// then возвращает кандидата после еще одного исправления.
+// Видом исправления считается самое дорогое из примененных
+func (sc suggestionCandidate) then(digits string, cost float64, kind CorrectionKind) suggestionCandidate {
+	next := sc
+	next.digits = digits
+	next.cost += cost
+	if cost >= sc.kindCost {
+		next.kind = kind
+		next.kindCost = cost
+	}
+	return next
+}
+
+// suggestCorrections предлагает исправления для неверного номера
+func suggestCorrections(phone, countryCode string) []string {
+	return suggestionNumbers(rankSuggestions(phone, countryCode, defaultMaxSuggestions))
+}
+
+// suggestionNumbers возвращает номера из предложений
+func suggestionNumbers(suggestions []Suggestion) []string {
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	numbers := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		numbers[i] = suggestion.Number
+	}
+	return numbers
+}
+
+// rankSuggestions генерирует исправления, моделируя типичные ошибки ввода: пропущенную
+// или лишнюю цифру, перестановку соседних цифр, повторный код страны, национальный
+// префикс после кода страны и путаницу букв с цифрами. Каждый кандидат проверяется
+// по метаданным стран и ранжируется по стоимости исправлений и совпадению с ожидаемой страной
+func rankSuggestions(phone, countryCode string, maxSuggestions int) []Suggestion {
+	if maxSuggestions <= 0 {
+		maxSuggestions = defaultMaxSuggestions
+	}
+
+	number, _ := splitExtension(phone)
+	start, international, ok := readSuggestionDigits(number)
+	if !ok {
+		return nil
+	}
+
+	hint := resolveCountryCode(countryCode)
+	hintInfo, hasHint := CountryPhoneCodes[hint]
+
+	bases := []suggestionCandidate{start}
+	if !international {
+		bases[0] = start.then(start.digits, costMissingPlus, CorrectionMissingPlus)
+		if hasHint {
+			if national := nationalToInternational(start.digits, hintInfo); national != start.digits {
+				bases = append(bases, start.then(national, costNationalFormat, CorrectionNationalFormat))
+			}
+		}
+	}
+	bases = append(bases, structuralFixes(bases)...)
+
+	candidates := append([]suggestionCandidate{}, bases...)
+	for _, base := range bases {
+		candidates = append(candidates, digitEdits(base)...)
+	}
+
+	codes := sortedCountryCodes()
+	original := cleanPhoneNumber(phone)
+	best := make(map[string]Suggestion)
+
+	for _, candidate := range candidates {
+		e164 := "+" + candidate.digits
+		if e164 == original {
+			continue
+		}
+
+		country := ""
+		if hasHint && matchesCountryMetadata(e164, hintInfo) {
+			country = hint
+		} else if code, _, found := detectCountryAmong(e164, codes); found {
+			country = code
+		} else {
+			continue
+		}
+
+		cost := candidate.cost
+		if hasHint && country != hint {
+			cost += costCountryMismatch
+		}
+
+		suggestion := Suggestion{
+			Number:      e164,
+			CountryCode: country,
+			Confidence:  math.Round(100/(1+cost)) / 100,
+			Correction:  candidate.kind,
+		}
+		if existing, exists := best[e164]; !exists || suggestion.Confidence > existing.Confidence {
+			best[e164] = suggestion
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, len(best))
+	for _, suggestion := range best {
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].Number < suggestions[j].Number
+	})
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+// readSuggestionDigits извлекает цифры из номера, исправляя похожие на цифры символы
+func readSuggestionDigits(phone string) (suggestionCandidate, bool, bool) {
+	var digits strings.Builder
+	international := false
+	candidate := suggestionCandidate{kind: CorrectionFormatting}
+
+	for _, char := range strings.TrimSpace(phone) {
+		switch {
+		case char >= '0' && char <= '9':
+			digits.WriteRune(char)
+		case char == '+' && digits.Len() == 0:
+			international = true
+		case ocrConfusions[char] != 0:
+			digits.WriteRune(ocrConfusions[char])
+			candidate.cost += costOCR
+			candidate.kind = CorrectionOCR
+			candidate.kindCost = costOCR
+		case strings.ContainsRune(" \t-()./", char):
+			continue
+		default:
+			candidate.cost += costFormatting
+		}
+	}
+
+	candidate.digits = digits.String()
+	if !international && strings.HasPrefix(candidate.digits, "00") {
+		candidate.digits = candidate.digits[2:]
+		international = true
+	}
+
+	return candidate, international, candidate.digits != ""
+}
+
+// structuralFixes исправляет повторный код страны ("+996996...") и национальный
+// префикс, оставшийся после кода страны ("+9960700...")
+func structuralFixes(bases []suggestionCandidate) []suggestionCandidate {
+	var fixes []suggestionCandidate
+	seen := make(map[string]bool)
+
+	for _, info := range CountryPhoneCodes {
+		code := strings.TrimPrefix(info.Prefix, "+")
+		key := code + "|" + info.NationalPrefix
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		for _, base := range bases {
+			if strings.HasPrefix(base.digits, code+code) {
+				fixes = append(fixes, base.then(base.digits[len(code):], costDoubledCountryCode, CorrectionDoubledCountryCode))
+			}
+			if info.NationalPrefix != "" && strings.HasPrefix(base.digits, code+info.NationalPrefix) {
+				trimmed := code + base.digits[len(code)+len(info.NationalPrefix):]
+				fixes = append(fixes, base.then(trimmed, costTrunkPrefix, CorrectionTrunkPrefix))
+			}
+		}
+	}
+
+	return fixes
+}
+
+// digitEdits генерирует кандидатов на расстоянии одной правки:
+// вставка пропущенной цифры, удаление лишней и перестановка соседних цифр
+func digitEdits(base suggestionCandidate) []suggestionCandidate {
+	digits := base.digits
+	edits := make([]suggestionCandidate, 0, len(digits)*12)
+
+	for i := 0; i <= len(digits); i++ {
+		for d := byte('0'); d <= '9'; d++ {
+			edits = append(edits, base.then(digits[:i]+string(d)+digits[i:], costMissingDigit, CorrectionMissingDigit))
+		}
+	}
+
+	for i := 0; i < len(digits); i++ {
+		edits = append(edits, base.then(digits[:i]+digits[i+1:], costExtraDigit, CorrectionExtraDigit))
+	}
+
+	for i := 0; i+1 < len(digits); i++ {
+		if digits[i] == digits[i+1] {
+			continue
+		}
+		swapped := []byte(digits)
+		swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+		edits = append(edits, base.then(string(swapped), costTransposition, CorrectionTransposition))
+	}
+
+	return edits
+}
@@ -0,0 +1,161 @@
+package mnv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// Теги, сообщения которых зависят от параметра
+const (
+	tagPhone          = "phone"
+	tagPhoneByCountry = "phonebycountry"
+	tagPhoneType      = "phone_type"
+)
+
+// phoneCountriesKey ключ сообщения для тега phone со списком стран (phone=kg kz)
+const phoneCountriesKey = "phone.countries"
+
+// defaultCountryField поле со страной, которое использует тег phonebycountry
+const defaultCountryField = "Country"
+
+// RegisterTranslations регистрирует в trans локализованные сообщения для всех тегов,
+// которые регистрирует RegisterValidators. Сообщения берутся из DefaultCatalog на языке
+// lang (если lang пуст - на языке переводчика); для страновых тегов указывается
+// ожидаемый формат номера
+func RegisterTranslations(v *validator.Validate, trans ut.Translator, lang string) error {
+	if lang == "" {
+		lang = trans.Locale()
+	}
+	lang = NormalizeLanguage(lang)
+
+	for _, tag := range registeredTags() {
+		text, err := tagTranslation(tag, lang)
+		if err != nil {
+			return err
+		}
+
+		register := func(trans ut.Translator) error {
+			return trans.Add(tag, text, true)
+		}
+		if tag == tagPhone {
+			countriesText, err := tagTranslation(phoneCountriesKey, lang)
+			if err != nil {
+				return err
+			}
+			register = func(trans ut.Translator) error {
+				if err := trans.Add(tag, text, true); err != nil {
+					return err
+				}
+				return trans.Add(phoneCountriesKey, countriesText, true)
+			}
+		}
+
+		if err := v.RegisterTranslation(tag, trans, register, fieldErrorTranslator(lang)); err != nil {
+			return fmt.Errorf("failed to register translation %s: %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// fieldErrorTranslator переводит ошибку поля: {0} - имя поля, {1} - параметр тега.
+// Коды стран в параметре тега phone заменяются названиями на языке lang
+func fieldErrorTranslator(lang string) validator.TranslationFunc {
+	return func(trans ut.Translator, fe validator.FieldError) string {
+		key, param := fe.Tag(), fe.Param()
+
+		switch {
+		case key == tagPhone && param != "":
+			key = phoneCountriesKey
+			param = countryNameList(param, lang)
+		case key == tagPhoneByCountry && param == "":
+			param = defaultCountryField
+		case key == tagPhoneType:
+			param = phoneTypeNameList(param, lang)
+		}
+
+		message, err := trans.T(key, fe.Field(), param)
+		if err != nil {
+			return fe.Error()
+		}
+		return message
+	}
+}
+
+// countryNameList заменяет коды стран из списка через пробел названиями на языке lang
+func countryNameList(codes, lang string) string {
+	var names []string
+	for _, code := range strings.Fields(codes) {
+		names = append(names, CountryName(code, lang))
+	}
+	return strings.Join(names, ", ")
+}
+
+// tagTranslation формирует шаблон сообщения для тега в формате universal-translator
+func tagTranslation(tag, lang string) (string, error) {
+	key := "tag." + tag
+	params := map[string]interface{}{
+		"field": "{0}",
+		"param": "{1}",
+	}
+
+	if _, exists := CountryPhoneCodes[tag]; exists {
+		key = "tag.country"
+		params["country"] = CountryName(tag, lang)
+		params["format"] = ExpectedFormat(tag, lang)
+	}
+
+	text, ok := DefaultCatalog.Translate(lang, key, params)
+	if !ok {
+		return "", fmt.Errorf("no translation for tag %s", tag)
+	}
+	return text, nil
+}
+
+// ExpectedFormat возвращает ожидаемый формат номера страны, например "+996 XXX XXX XXX".
+// Если длина номера может меняться, указывается диапазон длин на языке lang
+func ExpectedFormat(countryCode, lang string) string {
+	info, exists := GetCountryInfo(resolveCountryCode(countryCode))
+	if !exists {
+		return ""
+	}
+
+	format := info.Prefix + " " + groupDigits(strings.Repeat("X", info.MinLength))
+	if info.MaxLength <= info.MinLength {
+		return format
+	}
+
+	params := map[string]interface{}{
+		"format": format,
+		"min":    info.MinLength,
+		"max":    info.MaxLength,
+		"count":  info.MaxLength,
+	}
+	if text, ok := DefaultCatalog.Translate(lang, "format.length_range", params); ok {
+		return text
+	}
+	return format
+}
+
+// registeredTags возвращает отсортированный список тегов RegisterValidators
+func registeredTags() []string {
+	tags := make([]string, 0, len(tagValidators))
+	for tag := range tagValidators {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// phoneTypeNameList заменяет типы номеров из списка через пробел названиями на языке lang
+func phoneTypeNameList(types, lang string) string {
+	var names []string
+	for _, phoneType := range strings.Fields(types) {
+		names = append(names, PhoneTypeName(PhoneType(strings.ToLower(phoneType)), lang))
+	}
+	return strings.Join(names, ", ")
+}
@@ -7,6 +7,9 @@ type PhoneCodeInfo struct {
 	// Prefix - телефонный префикс страны (например, "+996")
 	Prefix string `json:"prefix"`
 
+	// NationalPrefix - префикс выхода на междугороднюю связь внутри страны (например, "0" или "8")
+	NationalPrefix string `json:"national_prefix,omitempty"`
+
 	// Pattern - регулярное выражение для строгой валидации
 	Pattern string `json:"pattern"`
 
@@ -21,6 +24,10 @@ type PhoneCodeInfo struct {
 
 	// Description - описание формата номера
 	Description string `json:"description"`
+
+	// NumberRanges - начала национального номера по типам (например, "70" для мобильных).
+	// Символ "X" обозначает любую цифру; при пересечении выбирается самое длинное совпадение
+	NumberRanges map[PhoneType][]string `json:"number_ranges,omitempty"`
 }
 
 // ValidatorConfig конфигурация валидатора
@@ -69,8 +76,103 @@ type ValidationResult struct {
 
 	// Suggestions предложения по исправлению (если есть)
 	Suggestions []string `json:"suggestions,omitempty"`
+
+	// SuggestionDetails предложения по исправлению с оценкой уверенности
+	SuggestionDetails []Suggestion `json:"suggestion_details,omitempty"`
+
+	// Type тип номера (определяется для номеров, прошедших проверку страны)
+	Type PhoneType `json:"type,omitempty"`
+
+	// ErrorType тип ошибки (если есть)
+	ErrorType ErrorType `json:"error_type,omitempty"`
+}
+
+// setSuggestions заполняет предложения по исправлению
+func (vr *ValidationResult) setSuggestions(suggestions []Suggestion) {
+	vr.Suggestions = suggestionNumbers(suggestions)
+	vr.SuggestionDetails = suggestions
+}
+
+// ToError преобразует результат проверки в *ValidationError (nil для корректного номера)
+func (vr *ValidationResult) ToError() *ValidationError {
+	if vr.IsValid {
+		return nil
+	}
+
+	var err *ValidationError
+	switch vr.ErrorType {
+	case ErrorTypeTypeNotAllowed:
+		err = NewTypeNotAllowedError(vr.OriginalNumber, vr.CountryCode, vr.Type)
+	case ErrorTypeCountryNotAllowed:
+		err = NewCountryNotAllowedError(vr.OriginalNumber, vr.CountryCode)
+	case ErrorTypeInvalidLength, ErrorTypeInvalidPrefix:
+		// Ожидаемые и фактические значения восстанавливаются по номеру и стране результата
+		err = countryError(vr.OriginalNumber, cleanPhoneNumber(vr.OriginalNumber), vr.CountryCode)
+		if err == nil || err.Type != vr.ErrorType {
+			err = NewValidationError(vr.ErrorType, vr.ErrorMessage, vr.OriginalNumber, vr.CountryCode, nil)
+		}
+		err.Suggestions = nil
+	default:
+		errorType := vr.ErrorType
+		if errorType == "" {
+			errorType = ErrorTypeInvalidFormat
+		}
+		err = NewValidationError(errorType, vr.ErrorMessage, vr.OriginalNumber, vr.CountryCode, nil)
+	}
+
+	if len(vr.Suggestions) > 0 {
+		err.Suggestions = vr.Suggestions
+	}
+	return err
+}
+
+// Suggestion предложение по исправлению номера
+type Suggestion struct {
+	// Number исправленный номер в формате E.164
+	Number string `json:"number"`
+
+	// CountryCode страна исправленного номера
+	CountryCode string `json:"country_code"`
+
+	// Confidence уверенность в исправлении (от 0 до 1)
+	Confidence float64 `json:"confidence"`
+
+	// Correction вид исправления
+	Correction CorrectionKind `json:"correction"`
 }
 
+// CorrectionKind вид исправления номера
+type CorrectionKind string
+
+const (
+	// CorrectionMissingPlus добавлен знак +
+	CorrectionMissingPlus CorrectionKind = "missing_plus"
+
+	// CorrectionNationalFormat номер в национальном формате дополнен кодом страны
+	CorrectionNationalFormat CorrectionKind = "national_format"
+
+	// CorrectionTrunkPrefix удален национальный префикс после кода страны
+	CorrectionTrunkPrefix CorrectionKind = "trunk_prefix"
+
+	// CorrectionDoubledCountryCode удален повторный код страны
+	CorrectionDoubledCountryCode CorrectionKind = "doubled_country_code"
+
+	// CorrectionMissingDigit добавлена пропущенная цифра
+	CorrectionMissingDigit CorrectionKind = "missing_digit"
+
+	// CorrectionExtraDigit удалена лишняя цифра
+	CorrectionExtraDigit CorrectionKind = "extra_digit"
+
+	// CorrectionTransposition переставлены соседние цифры
+	CorrectionTransposition CorrectionKind = "transposition"
+
+	// CorrectionOCR исправлены символы, похожие на цифры (O → 0, l → 1)
+	CorrectionOCR CorrectionKind = "ocr_confusion"
+
+	// CorrectionFormatting исправлено только форматирование
+	CorrectionFormatting CorrectionKind = "formatting"
+)
+
 // PhoneInfo детальная информация о номере телефона
 type PhoneInfo struct {
 	// Number номер телефона
@@ -162,15 +264,22 @@ type ValidationOptions struct {
 	// Config конфигурация валидатора
 	Config *ValidatorConfig `json:"config,omitempty"`
 
-	// ExpectedCountry ожидаемая страна (для оптимизации)
+	// ExpectedCountry ожидаемая страна: используется, если страна не указана,
+	// и предпочитается при определении страны по общему коду (+1, +7)
 	ExpectedCountry string `json:"expected_country,omitempty"`
 
-	// AllowedCountries список разрешенных стран
+	// AllowedCountries список разрешенных стран или групп стран (см. RegionGroups)
 	AllowedCountries []string `json:"allowed_countries,omitempty"`
 
-	// ForbiddenCountries список запрещенных стран
+	// ForbiddenCountries список запрещенных стран или групп стран; запрет важнее разрешения
 	ForbiddenCountries []string `json:"forbidden_countries,omitempty"`
 
+	// AllowedTypes разрешенные типы номеров (например, только мобильные для SMS)
+	AllowedTypes []PhoneType `json:"allowed_types,omitempty"`
+
+	// ForbiddenTypes запрещенные типы номеров (например, премиум и бесплатные)
+	ForbiddenTypes []PhoneType `json:"forbidden_types,omitempty"`
+
 	// ReturnSuggestions возвращать предложения по исправлению
 	ReturnSuggestions bool `json:"return_suggestions"`
 
@@ -178,16 +287,48 @@ type ValidationOptions struct {
 	MaxSuggestions int `json:"max_suggestions"`
 }
 
+// BatchItem номер пакета с необязательной подсказкой страны
+type BatchItem struct {
+	// Phone номер телефона
+	Phone string `json:"phone"`
+
+	// Country код страны номера; если не задан, используется DefaultRegion запроса,
+	// а для номеров в международном формате - определение страны по номеру
+	Country string `json:"country,omitempty"`
+}
+
+// BatchProgressFunc вызывается после проверки каждого номера пакета с количеством
+// обработанных и общим количеством номеров. Вызовы выполняются последовательно
+type BatchProgressFunc func(done, total int)
+
 // BatchValidationRequest запрос на пакетную валидацию
 type BatchValidationRequest struct {
 	// Phones список номеров для проверки
 	Phones []string `json:"phones"`
 
+	// Items номера с подсказками стран; проверяются после Phones, результаты идут в том же порядке
+	Items []BatchItem `json:"items,omitempty"`
+
+	// DefaultRegion страна для номеров в национальном формате без подсказки
+	DefaultRegion string `json:"default_region,omitempty"`
+
 	// Options опции валидации
 	Options *ValidationOptions `json:"options,omitempty"`
 
-	// Parallel количество параллельных проверок
+	// Parallel количество воркеров пула (по умолчанию 10)
 	Parallel int `json:"parallel"`
+
+	// Progress необязательный обработчик прогресса
+	Progress BatchProgressFunc `json:"-"`
+}
+
+// batchItems возвращает все номера запроса: сначала Phones, затем Items
+func (r *BatchValidationRequest) batchItems() []BatchItem {
+	items := make([]BatchItem, 0, len(r.Phones)+len(r.Items))
+	for _, phone := range r.Phones {
+		items = append(items, BatchItem{Phone: phone})
+	}
+	return append(items, r.Items...)
 }
 
 // BatchValidationResponse ответ на пакетную валидацию
@@ -210,13 +351,13 @@ type BatchStats struct {
 	// Valid количество валидных номеров
 	Valid int `json:"valid"`
 
-	// Invalid количество невалидных номеров
+	// Invalid количество проверенных невалидных номеров
 	Invalid int `json:"invalid"`
 
 	// ByCountry статистика по странам
 	ByCountry map[string]int `json:"by_country"`
 
-	// Errors количество ошибок
+	// Errors количество номеров, которые не удалось проверить (таймаут, перегрузка)
 	Errors int `json:"errors"`
 }
 
@@ -242,6 +383,9 @@ type CustomCountry struct {
 
 	// Description описание
 	Description string `json:"description"`
+
+	// NumberRanges начала национального номера по типам
+	NumberRanges map[PhoneType][]string `json:"number_ranges,omitempty"`
 }
 
 // ErrorType тип ошибки валидации
@@ -266,6 +410,18 @@ const (
 	// ErrorTypeMissingPlus отсутствует знак +
 	ErrorTypeMissingPlus ErrorType = "missing_plus"
 
+	// ErrorTypeTypeNotAllowed тип номера не разрешен
+	ErrorTypeTypeNotAllowed ErrorType = "type_not_allowed"
+
+	// ErrorTypeCountryNotAllowed страна не разрешена политикой
+	ErrorTypeCountryNotAllowed ErrorType = "country_not_allowed"
+
+	// ErrorTypeTimeout истекло время валидации
+	ErrorTypeTimeout ErrorType = "timeout"
+
+	// ErrorTypeOverloaded превышен лимит одновременных валидаций
+	ErrorTypeOverloaded ErrorType = "overloaded"
+
 	// ErrorTypeUnknown неизвестная ошибка
 	ErrorTypeUnknown ErrorType = "unknown"
 )
@@ -286,6 +442,15 @@ type ValidationError struct {
 
 	// Suggestions предложения по исправлению
 	Suggestions []string `json:"suggestions,omitempty"`
+
+	// MessageKey ключ сообщения в каталоге; если пуст, используется Type
+	MessageKey string `json:"message_key,omitempty"`
+
+	// Params параметры сообщения для подстановки в шаблон каталога
+	Params map[string]interface{} `json:"params,omitempty"`
+
+	// cause исходная ошибка (например, context.DeadlineExceeded)
+	cause error
 }
 
 // Error реализует интерфейс error
@@ -293,6 +458,23 @@ func (ve *ValidationError) Error() string {
 	return ve.Message
 }
 
+// Unwrap возвращает исходную ошибку для errors.Is и errors.As
+func (ve *ValidationError) Unwrap() error {
+	return ve.cause
+}
+
+// Is сообщает, что ошибка таймаута или перегрузки соответствует ErrTimeout или ErrOverloaded:
+// errors.Is(err, mnv.ErrTimeout) верно для любой ошибки таймаута. Остальные ошибки-образцы
+// (ErrInvalidFormat и другие) по-прежнему сравниваются по идентичности
+func (ve *ValidationError) Is(target error) bool {
+	switch target {
+	case ErrTimeout, ErrOverloaded:
+		return ve.Type == target.(*ValidationError).Type
+	default:
+		return false
+	}
+}
+
 // CacheEntry запись в кеше валидации
 type CacheEntry struct {
 	// Phone номер телефона
@@ -2,10 +2,15 @@ package mnv
 
 import (
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"unicode"
 )
 
+// patternCache кеш скомпилированных регулярных выражений стран
+var patternCache sync.Map
+
 // cleanPhoneNumber очищает номер телефона от лишних символов согласно конфигурации
 func cleanPhoneNumber(phone string) string {
 	config := GetConfig()
@@ -96,56 +101,6 @@ func extractDigitsOnly(phone string) string {
 	return digits.String()
 }
 
-// suggestCorrections предлагает исправления для неверного номера
-func suggestCorrections(phone, countryCode string) []string {
-	var suggestions []string
-
-	// Если нет знака +, добавляем его
-	if !strings.HasPrefix(phone, "+") {
-		suggestion := "+" + phone
-		suggestions = append(suggestions, suggestion)
-	}
-
-	// Если есть код страны, пробуем добавить правильный префикс
-	if countryCode != "" {
-		if info, exists := GetCountryInfo(countryCode); exists {
-			digitsOnly := extractDigitsOnly(phone)
-
-			// Убираем код страны если он есть в начале
-			prefixDigits := extractDigitsOnly(info.Prefix)
-			if strings.HasPrefix(digitsOnly, prefixDigits) {
-				localPart := digitsOnly[len(prefixDigits):]
-				suggestion := info.Prefix + localPart
-				suggestions = append(suggestions, suggestion)
-			} else {
-				// Добавляем префикс к номеру
-				suggestion := info.Prefix + digitsOnly
-				suggestions = append(suggestions, suggestion)
-			}
-		}
-	}
-
-	// Пробуем определить страну по длине и добавить соответствующий префикс
-	digitsOnly := extractDigitsOnly(phone)
-	for _, info := range CountryPhoneCodes {
-		if len(digitsOnly) >= info.MinLength && len(digitsOnly) <= info.MaxLength {
-			// Убираем уже возможный дублирующий префикс перед добавлением нового
-			trimmed := strings.TrimPrefix(phone, info.Prefix)
-			suggestion := info.Prefix + extractDigitsOnly(trimmed)
-			if !contains(suggestions, suggestion) {
-				suggestions = append(suggestions, suggestion)
-			}
-		}
-	}
-
-	// Ограничиваем количество предложений
-	if len(suggestions) > 5 {
-		suggestions = suggestions[:5]
-	}
-
-	return suggestions
-}
-
 // contains проверяет, содержит ли слайс строку
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -158,6 +113,7 @@ func contains(slice []string, item string) bool {
 
 // formatPhoneNumber форматирует номер телефона согласно стандартам страны
 func formatPhoneNumber(phone, countryCode string) (string, error) {
+	countryCode = resolveCountryCode(countryCode)
 	_, exists := GetCountryInfo(countryCode)
 	if !exists {
 		return "", &ValidationError{
@@ -181,41 +137,79 @@ func formatPhoneNumber(phone, countryCode string) (string, error) {
 	return cleaned, nil
 }
 
-// detectPhoneType определяет тип номера телефона (примитивная реализация)
+// detectPhoneType определяет тип номера по диапазонам NumberRanges страны.
+// Выбирается самый длинный подходящий диапазон, при равной длине - диапазон с меньшим
+// числом "X", затем тип, первый по алфавиту. Номер, не попадающий ни в один диапазон,
+// имеет тип PhoneTypeUnknown. Для стран без NumberRanges (например, добавленных
+// AddCountry) сохраняется прежнее поведение: тип PhoneTypeMobile
 func detectPhoneType(phone, countryCode string) PhoneType {
-	// Базовая логика определения типа номера
-	// В реальном приложении здесь была бы более сложная логика
-
-	switch countryCode {
-	case "us", "ca":
-		// В США и Канаде номера 800, 888, 877, 866, 855, 844, 833, 822 - бесплатные
-		if strings.HasPrefix(phone, "+1800") ||
-			strings.HasPrefix(phone, "+1888") ||
-			strings.HasPrefix(phone, "+1877") ||
-			strings.HasPrefix(phone, "+1866") {
-			return PhoneTypeTollFree
-		}
-		// 900 номера - премиум
-		if strings.HasPrefix(phone, "+1900") {
-			return PhoneTypePremium
-		}
-	case "uk":
-		// В UK номера начинающиеся с +447 обычно мобильные
-		if strings.HasPrefix(phone, "+447") {
-			return PhoneTypeMobile
+	info, exists := CountryPhoneCodes[countryCode]
+	if !exists || !strings.HasPrefix(phone, info.Prefix) {
+		return PhoneTypeUnknown
+	}
+	if len(info.NumberRanges) == 0 {
+		return PhoneTypeMobile
+	}
+
+	types := make([]PhoneType, 0, len(info.NumberRanges))
+	for phoneType := range info.NumberRanges {
+		types = append(types, phoneType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	nsn := removeNonDigits(strings.TrimPrefix(phone, info.Prefix))
+	detected, longest, digits := PhoneTypeUnknown, 0, 0
+
+	for _, phoneType := range types {
+		for _, numberRange := range info.NumberRanges[phoneType] {
+			if !matchesNumberRange(nsn, numberRange) {
+				continue
+			}
+			rangeDigits := len(numberRange) - strings.Count(numberRange, "X")
+			if len(numberRange) > longest || len(numberRange) == longest && rangeDigits > digits {
+				detected, longest, digits = phoneType, len(numberRange), rangeDigits
+			}
 		}
-		// +4480x - бесплатные
-		if strings.HasPrefix(phone, "+44800") {
-			return PhoneTypeTollFree
+	}
+
+	return detected
+}
+
+// matchesNumberRange проверяет, начинается ли национальный номер с диапазона ("X" - любая цифра)
+func matchesNumberRange(nsn, numberRange string) bool {
+	if len(nsn) < len(numberRange) {
+		return false
+	}
+	for i := 0; i < len(numberRange); i++ {
+		if numberRange[i] != 'X' && numberRange[i] != nsn[i] {
+			return false
 		}
 	}
+	return true
+}
 
-	// По умолчанию считаем мобильным, так как библиотека в основном для мобильных номеров
-	return PhoneTypeMobile
+// isPhoneTypeAllowed проверяет тип номера по спискам разрешенных и запрещенных типов.
+// Пустой список разрешенных типов разрешает любой тип
+func isPhoneTypeAllowed(phoneType PhoneType, allowed, forbidden []PhoneType) bool {
+	if containsPhoneType(forbidden, phoneType) {
+		return false
+	}
+	return len(allowed) == 0 || containsPhoneType(allowed, phoneType)
+}
+
+// containsPhoneType проверяет наличие типа номера в списке
+func containsPhoneType(types []PhoneType, phoneType PhoneType) bool {
+	for _, t := range types {
+		if t == phoneType {
+			return true
+		}
+	}
+	return false
 }
 
-// calculateDistance вычисляет расстояние Левенштейна между строками
-func calculateDistance(s1, s2 string) int {
+// calculateDistance вычисляет расстояние Левенштейна между строками (посимвольно)
+func calculateDistance(str1, str2 string) int {
+	s1, s2 := []rune(str1), []rune(str2)
 	len1, len2 := len(s1), len(s2)
 	if len1 == 0 {
 		return len2
@@ -268,18 +262,32 @@ func min(a, b, c int) int {
 	return c
 }
 
-// findSimilarCountries находит похожие коды стран
+// findSimilarCountries находит похожие коды стран: коды с одной опечаткой,
+// а также страны, название или код ISO которых похожи на введенное значение
 func findSimilarCountries(countryCode string) []string {
 	var similar []string
-	countryCode = strings.ToLower(countryCode)
+	key := normalizeCountryKey(countryCode)
 
-	for code := range CountryPhoneCodes {
-		distance := calculateDistance(countryCode, code)
-		if distance <= 1 && distance > 0 { // Максимум 1 символ различия
+	if code, ok := ResolveCountry(countryCode); ok {
+		similar = append(similar, code)
+	}
+
+	for _, code := range sortedCountryCodes() {
+		distance := calculateDistance(key, code)
+		if distance <= 1 && distance > 0 && !contains(similar, code) { // Максимум 1 символ различия
 			similar = append(similar, code)
 		}
 	}
 
+	for _, match := range fuzzyCountryMatches(key) {
+		if !contains(similar, match.code) {
+			similar = append(similar, match.code)
+		}
+	}
+
+	if len(similar) > defaultMaxSuggestions {
+		similar = similar[:defaultMaxSuggestions]
+	}
 	return similar
 }
 
@@ -365,3 +373,174 @@ func generatePhoneExample(countryCode string) string {
 
 	return example
 }
+
+// normalizeToE164 приводит номер к формату E.164 независимо от текущей конфигурации.
+// Разделители (пробелы, тире, скобки, точки, слэши) отбрасываются, "00" в начале
+// трактуется как международный выход. Номер без знака + дополняется кодом страны region,
+// если он задан, с удалением национального префикса
+func normalizeToE164(phone, region string) (string, bool) {
+	var digits strings.Builder
+	international := false
+
+	for _, char := range strings.TrimSpace(phone) {
+		switch {
+		case char >= '0' && char <= '9':
+			digits.WriteRune(char)
+		case char == '+' && digits.Len() == 0 && !international:
+			international = true
+		case strings.ContainsRune(" \t-()./", char):
+			continue
+		default:
+			return "", false
+		}
+	}
+
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		number = number[2:]
+		international = true
+	}
+
+	if !international && region != "" {
+		if info, exists := CountryPhoneCodes[region]; exists {
+			number = nationalToInternational(number, info)
+		}
+	}
+
+	// E.164 допускает не более 15 цифр
+	if len(number) < 7 || len(number) > 15 || number[0] == '0' {
+		return "", false
+	}
+
+	return "+" + number, true
+}
+
+// nationalToInternational дополняет национальный номер кодом страны (без знака +)
+func nationalToInternational(number string, info PhoneCodeInfo) string {
+	code := strings.TrimPrefix(info.Prefix, "+")
+	fits := func(nsn string) bool {
+		return len(nsn) >= info.MinLength && len(nsn) <= info.MaxLength
+	}
+
+	// Номер уже содержит код страны, но без знака +
+	if strings.HasPrefix(number, code) && fits(number[len(code):]) {
+		return number
+	}
+
+	// Номер набран с национальным префиксом
+	if info.NationalPrefix != "" && strings.HasPrefix(number, info.NationalPrefix) &&
+		fits(number[len(info.NationalPrefix):]) {
+		return code + number[len(info.NationalPrefix):]
+	}
+
+	return code + number
+}
+
+// matchesCountryMetadata проверяет номер в формате E.164 по метаданным страны,
+// не завися от глобальной конфигурации
+func matchesCountryMetadata(e164 string, info PhoneCodeInfo) bool {
+	if !strings.HasPrefix(e164, info.Prefix) {
+		return false
+	}
+
+	if info.Pattern != "" {
+		re, err := compilePattern(info.Pattern)
+		return err == nil && re.MatchString(e164)
+	}
+
+	nsnLen := len(e164) - len(info.Prefix)
+	return nsnLen >= info.MinLength && nsnLen <= info.MaxLength
+}
+
+// detectCountryByMetadata определяет страну номера в формате E.164 по метаданным.
+// Страны перебираются в порядке detectionOrder, чтобы результат был детерминированным
+func detectCountryByMetadata(e164 string) (string, PhoneCodeInfo, bool) {
+	return detectCountryAmong(e164, detectionOrder())
+}
+
+// detectCountryAmong определяет страну номера среди переданных кодов (в их порядке)
+func detectCountryAmong(e164 string, codes []string) (string, PhoneCodeInfo, bool) {
+	for _, code := range codes {
+		info := CountryPhoneCodes[code]
+		if matchesCountryMetadata(e164, info) {
+			return code, info, true
+		}
+	}
+	return "", PhoneCodeInfo{}, false
+}
+
+// mainCountryCodes основные страны кодов, общих для нескольких стран (+1 - США, +7 - Россия)
+var mainCountryCodes = map[string]bool{
+	"us": true,
+	"ru": true,
+}
+
+// detectionOrder возвращает коды стран в порядке определения страны по номеру:
+// сначала основные страны общих кодов, затем остальные в алфавитном порядке
+func detectionOrder() []string {
+	codes := sortedCountryCodes()
+	sort.SliceStable(codes, func(i, j int) bool {
+		return mainCountryCodes[codes[i]] && !mainCountryCodes[codes[j]]
+	})
+	return codes
+}
+
+// sortedCountryCodes возвращает коды поддерживаемых стран в алфавитном порядке
+func sortedCountryCodes() []string {
+	codes := make([]string, 0, len(CountryPhoneCodes))
+	for code := range CountryPhoneCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// compilePattern компилирует регулярное выражение с кешированием
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Store(pattern, re)
+	return re, nil
+}
+
+// digitGroups разбивает национальный номер длины length на группы для отображения:
+// 8 → 2 3 3, 9 → 3 3 3, 10 → 3 3 4, 11 → 3 4 4, остальные - по три цифры
+func digitGroups(length int) []int {
+	switch length {
+	case 8:
+		return []int{2, 3, 3}
+	case 9:
+		return []int{3, 3, 3}
+	case 10:
+		return []int{3, 3, 4}
+	case 11:
+		return []int{3, 4, 4}
+	}
+
+	var groups []int
+	for length > 0 {
+		size := 3
+		if length < size || length == 4 {
+			size = length
+		}
+		groups = append(groups, size)
+		length -= size
+	}
+	return groups
+}
+
+// groupDigits разделяет цифры пробелами по группам digitGroups
+func groupDigits(digits string) string {
+	var parts []string
+	for _, size := range digitGroups(len(digits)) {
+		parts = append(parts, digits[:size])
+		digits = digits[size:]
+	}
+	return strings.Join(parts, " ")
+}
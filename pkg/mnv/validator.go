@@ -1,42 +1,54 @@
 package mnv
 
 import (
+	"database/sql"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
-// RegisterValidators регистрирует все кастомные валидаторы
+// e164Regex формат E.164: знак + и от 7 до 15 цифр, первая цифра не 0
+var e164Regex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// tagValidators валидаторы, регистрируемые RegisterValidators, по тегам
+var tagValidators = map[string]validator.Func{
+	"phonebycountry": validatePhoneByCountry,
+	"kg":             validateKGPhone,
+	"ru":             validateRUPhone,
+	"kz":             validateKZPhone,
+	"uz":             validateUZPhone,
+	"tj":             validateTJPhone,
+	"tm":             validateTMPhone,
+	"us":             validateUSPhone,
+	"ca":             validateCAPhone,
+	"uk":             validateUKPhone,
+	"de":             validateDEPhone,
+	"fr":             validateFRPhone,
+	"it":             validateITPhone,
+	"es":             validateESPhone,
+	"nl":             validateNLPhone,
+	"tr":             validateTRPhone,
+	"cn":             validateCNPhone,
+	"in":             validateINPhone,
+	"jp":             validateJPPhone,
+	"kr":             validateKRPhone,
+	"phone":          validateGeneralPhone,
+	"mobile":         validateMobilePhone,
+	"phone_type":     validatePhoneType,
+	"e164":           validateE164Phone,
+}
+
+// RegisterValidators регистрирует все кастомные валидаторы.
+// Теги с параметрами: phone=kg kz uz (любая из стран), phone_type=mobile,
+// e164 (заменяет встроенный тег validator), phonebycountry=CountryCode (поле со страной).
+// Поддерживаются поля string, *string и sql.NullString, а также срезы через dive
 func RegisterValidators(v *validator.Validate) error {
-	validators := map[string]validator.Func{
-		"phonebycountry": validatePhoneByCountry,
-		"kg":             validateKGPhone,
-		"ru":             validateRUPhone,
-		"kz":             validateKZPhone,
-		"uz":             validateUZPhone,
-		"tj":             validateTJPhone,
-		"tm":             validateTMPhone,
-		"us":             validateUSPhone,
-		"ca":             validateCAPhone,
-		"uk":             validateUKPhone,
-		"de":             validateDEPhone,
-		"fr":             validateFRPhone,
-		"it":             validateITPhone,
-		"es":             validateESPhone,
-		"nl":             validateNLPhone,
-		"tr":             validateTRPhone,
-		"cn":             validateCNPhone,
-		"in":             validateINPhone,
-		"jp":             validateJPPhone,
-		"kr":             validateKRPhone,
-		"phone":          validateGeneralPhone,
-		"mobile":         validateMobilePhone,
-	}
-
-	for tag, fn := range validators {
+	v.RegisterCustomTypeFunc(nullStringValue, sql.NullString{})
+
+	for tag, fn := range tagValidators {
 		if err := v.RegisterValidation(tag, fn); err != nil {
 			return fmt.Errorf("failed to register validator %s: %w", tag, err)
 		}
@@ -45,23 +57,74 @@ func RegisterValidators(v *validator.Validate) error {
 	return nil
 }
 
-// validatePhoneByCountry проверяет телефон по коду страны из поля Country
+// validatePhoneByCountry проверяет телефон по коду страны из соседнего поля:
+// указанного в параметре тега (phonebycountry=CountryCode) или поля Country
 func validatePhoneByCountry(fl validator.FieldLevel) bool {
-	parent := fl.Parent()
-	countryField := parent.FieldByName("Country")
-	if !countryField.IsValid() {
+	fieldName := fl.Param()
+	if fieldName == "" {
+		fieldName = defaultCountryField
+	}
+
+	country, ok := countryFromField(fl.Parent(), fieldName)
+	if !ok {
 		return false
 	}
 
-	countryCode := normalizeCountryCode(countryField.String())
-	phone := cleanPhoneNumber(fl.Field().String())
+	countryCode := resolveCountryCode(country)
+	phone := fieldPhone(fl)
 
 	return validatePhoneForCountry(phone, countryCode)
 }
 
+// countryFromField возвращает значение поля со страной из структуры parent
+func countryFromField(parent reflect.Value, fieldName string) (string, bool) {
+	parent = reflect.Indirect(parent)
+	if parent.Kind() != reflect.Struct {
+		return "", false
+	}
+	return stringValue(parent.FieldByName(fieldName))
+}
+
+// fieldPhone возвращает очищенный номер из проверяемого поля (пустую строку, если значения нет)
+func fieldPhone(fl validator.FieldLevel) string {
+	value, _ := stringValue(fl.Field())
+	return cleanPhoneNumber(value)
+}
+
+// stringValue извлекает строку из значения string, *string или sql.NullString
+func stringValue(field reflect.Value) (string, bool) {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return "", false
+		}
+		field = field.Elem()
+	}
+
+	if !field.IsValid() {
+		return "", false
+	}
+	if field.Kind() == reflect.String {
+		return field.String(), true
+	}
+	if field.CanInterface() {
+		if ns, ok := field.Interface().(sql.NullString); ok {
+			return ns.String, ns.Valid
+		}
+	}
+	return "", false
+}
+
+// nullStringValue передает validator значение sql.NullString (nil, если значение не задано)
+func nullStringValue(field reflect.Value) interface{} {
+	if ns, ok := field.Interface().(sql.NullString); ok && ns.Valid {
+		return ns.String
+	}
+	return nil
+}
+
 // validatePhoneForCountry проверяет номер телефона для конкретной страны
 func validatePhoneForCountry(phone, countryCode string) bool {
-	normalizedCode := normalizeCountryCode(countryCode)
+	normalizedCode := resolveCountryCode(countryCode)
 	phoneInfo, ok := CountryPhoneCodes[normalizedCode]
 	if !ok {
 		return false
@@ -92,18 +155,55 @@ func validatePhoneForCountry(phone, countryCode string) bool {
 	return phoneLen >= phoneInfo.MinLength && phoneLen <= phoneInfo.MaxLength
 }
 
-// ValidatePhone выполняет полную валидацию номера телефона с детальными результатами
+// countryError возвращает причину, по которой очищенный номер cleaned не прошел проверку
+// страны: неверный префикс или длину. nil, если причина другая (например, номер
+// не соответствует шаблону страны в строгом режиме)
+func countryError(phone, cleaned, countryCode string) *ValidationError {
+	info, ok := CountryPhoneCodes[countryCode]
+	if !ok {
+		return nil
+	}
+
+	if !strings.HasPrefix(cleaned, info.Prefix) {
+		actualPrefix, _ := parsePhoneComponents(cleaned)
+		if actualPrefix == "" {
+			return nil
+		}
+		return NewInvalidPrefixError(phone, countryCode, info.Prefix, actualPrefix)
+	}
+
+	length := len(removeNonDigits(strings.TrimPrefix(cleaned, info.Prefix)))
+	if length >= info.MinLength && length <= info.MaxLength {
+		return nil
+	}
+	if info.MinLength == info.MaxLength {
+		return NewInvalidLengthError(phone, countryCode, info.MinLength, length)
+	}
+	return NewInvalidLengthRangeError(phone, countryCode, info.MinLength, info.MaxLength, length)
+}
+
+// ValidatePhone выполняет полную валидацию номера телефона с детальными результатами.
+// Если кеширование включено (SetCacheConfig, SetCacheBackend), результат берется из кеша
 func ValidatePhone(phone, countryCode string, options ...*ValidationOptions) *ValidationResult {
+	var opts *ValidationOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	return cachedValue(cacheKindValidation, opts, []string{countryCode, phone}, cloneResult, func() *ValidationResult {
+		return validatePhone(phone, countryCode, opts)
+	})
+}
 
+// validatePhone выполняет валидацию номера без кеширования
+func validatePhone(phone, countryCode string, opts *ValidationOptions) *ValidationResult {
 	result := &ValidationResult{
 		OriginalNumber: phone,
 		CountryCode:    countryCode,
 	}
 
 	// Применяем опции если переданы
-	var opts *ValidationOptions
-	if len(options) > 0 && options[0] != nil {
-		opts = options[0]
+	if opts != nil {
 		if opts.Config != nil {
 			// Временно устанавливаем конфигурацию
 			oldConfig := GetConfig()
@@ -112,13 +212,31 @@ func ValidatePhone(phone, countryCode string, options ...*ValidationOptions) *Va
 		}
 	}
 
-	// Нормализуем код страны
-	normalizedCountry := normalizeCountryCode(countryCode)
+	policy := newCountryPolicy(opts)
+
+	// Страна не указана: определяем ее по номеру с учетом ожидаемой и разрешенных стран
+	if strings.TrimSpace(countryCode) == "" {
+		detected, found := policy.detect(cleanPhoneNumber(phone))
+		if !found {
+			result.IsValid = false
+			result.ErrorType = ErrorTypeInvalidFormat
+			result.ErrorMessage = "Could not detect country of phone number"
+			if opts != nil && opts.ReturnSuggestions {
+				result.setSuggestions(rankSuggestions(phone, policy.expected, opts.MaxSuggestions))
+			}
+			return result
+		}
+		countryCode = detected
+	}
+
+	// Определяем код страны по коду ISO, названию или синониму
+	normalizedCountry := resolveCountryCode(countryCode)
 
 	// Проверяем, поддерживается ли страна
 	info, exists := GetCountryInfo(normalizedCountry)
 	if !exists {
 		result.IsValid = false
+		result.ErrorType = ErrorTypeUnsupportedCountry
 		result.ErrorMessage = fmt.Sprintf("Unsupported country code: %s", countryCode)
 		if opts != nil && opts.ReturnSuggestions {
 			result.Suggestions = findSimilarCountries(countryCode)
@@ -126,101 +244,70 @@ func ValidatePhone(phone, countryCode string, options ...*ValidationOptions) *Va
 		return result
 	}
 
+	result.CountryCode = normalizedCountry
 	result.CountryName = info.CountryName
 
+	// Проверяем ограничения на страны
+	if !policy.allows(normalizedCountry) {
+		result.IsValid = false
+		result.ErrorType = ErrorTypeCountryNotAllowed
+		result.ErrorMessage = fmt.Sprintf("Country %s is not allowed", normalizedCountry)
+		return result
+	}
+
 	// Очищаем номер
 	cleanedPhone := cleanPhoneNumber(phone)
 
 	// Базовая валидация
 	if !validatePhoneFormat(cleanedPhone) {
 		result.IsValid = false
+		result.ErrorType = ErrorTypeInvalidFormat
 		result.ErrorMessage = "Invalid phone number format"
 		if opts != nil && opts.ReturnSuggestions {
-			result.Suggestions = suggestCorrections(phone, countryCode)
+			result.setSuggestions(rankSuggestions(phone, countryCode, opts.MaxSuggestions))
 		}
 		return result
 	}
 
 	// Валидация для конкретной страны
-	if validatePhoneForCountry(cleanedPhone, normalizedCountry) {
-		result.IsValid = true
-		result.FormattedNumber = cleanedPhone
-	} else {
+	if !validatePhoneForCountry(cleanedPhone, normalizedCountry) {
 		result.IsValid = false
+		result.ErrorType = ErrorTypeInvalidFormat
 		result.ErrorMessage = fmt.Sprintf("Invalid phone number for country %s", countryCode)
+		if countryErr := countryError(phone, cleanedPhone, normalizedCountry); countryErr != nil {
+			result.ErrorType = countryErr.Type
+			result.ErrorMessage = countryErr.Message
+		}
 		if opts != nil && opts.ReturnSuggestions {
-			result.Suggestions = suggestCorrections(phone, countryCode)
+			result.setSuggestions(rankSuggestions(phone, countryCode, opts.MaxSuggestions))
 		}
+		return result
 	}
 
-	return result
-}
-
-// BatchValidatePhones выполняет пакетную валидацию номеров телефонов
-func BatchValidatePhones(request *BatchValidationRequest) *BatchValidationResponse {
-	startTime := time.Now()
-
-	response := &BatchValidationResponse{
-		Results: make([]ValidationResult, len(request.Phones)),
-		Stats: BatchStats{
-			Total:     len(request.Phones),
-			ByCountry: make(map[string]int),
-		},
-	}
-
-	// Определяем количество параллельных воркеров
-	parallel := request.Parallel
-	if parallel <= 0 {
-		parallel = 10 // По умолчанию
-	}
-
-	// Канал для результатов
-	resultChan := make(chan struct {
-		index  int
-		result ValidationResult
-	}, len(request.Phones))
-
-	// Семафор для ограничения количества горутин
-	semaphore := make(chan struct{}, parallel)
-
-	// Запускаем валидацию
-	for i, phone := range request.Phones {
-		go func(idx int, ph string) {
-			semaphore <- struct{}{}        // Захватываем место в семафоре
-			defer func() { <-semaphore }() // Освобождаем место
-
-			result := ValidatePhone(ph, "", request.Options)
-
-			resultChan <- struct {
-				index  int
-				result ValidationResult
-			}{idx, *result}
-		}(i, phone)
-	}
-
-	// Собираем результаты
-	for i := 0; i < len(request.Phones); i++ {
-		res := <-resultChan
-		response.Results[res.index] = res.result
-
-		// Обновляем статистику
-		if res.result.IsValid {
-			response.Stats.Valid++
-			if res.result.CountryCode != "" {
-				response.Stats.ByCountry[res.result.CountryCode]++
-			}
-		} else {
-			response.Stats.Invalid++
-			response.Stats.Errors++
-		}
+	// Проверка типа номера
+	result.Type = detectPhoneType(cleanedPhone, normalizedCountry)
+	if opts != nil && !isPhoneTypeAllowed(result.Type, opts.AllowedTypes, opts.ForbiddenTypes) {
+		result.IsValid = false
+		result.ErrorType = ErrorTypeTypeNotAllowed
+		result.ErrorMessage = fmt.Sprintf("Phone number type %s is not allowed", result.Type)
+		return result
 	}
 
-	response.ProcessingTime = time.Since(startTime).String()
-	return response
+	result.IsValid = true
+	result.FormattedNumber = cleanedPhone
+	return result
 }
 
-// GetPhoneInfo возвращает детальную информацию о номере телефона
+// GetPhoneInfo возвращает детальную информацию о номере телефона.
+// Если кеширование включено (SetCacheConfig), результат берется из кеша
 func GetPhoneInfo(phone string) *PhoneInfo {
+	return cachedValue(cacheKindInfo, nil, []string{phone}, clonePhoneInfo, func() *PhoneInfo {
+		return getPhoneInfo(phone)
+	})
+}
+
+// getPhoneInfo возвращает информацию о номере без кеширования
+func getPhoneInfo(phone string) *PhoneInfo {
 	// Определяем страну по номеру
 	country, found := GetCountryByPhone(phone)
 	if !found {
@@ -248,105 +335,111 @@ func GetPhoneInfo(phone string) *PhoneInfo {
 
 // Валидаторы для конкретных стран
 func validateKGPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "kg")
 }
 
 func validateRUPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "ru")
 }
 
 func validateKZPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "kz")
 }
 
 func validateUZPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "uz")
 }
 
 func validateTJPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "tj")
 }
 
 func validateTMPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "tm")
 }
 
 func validateUSPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "us")
 }
 
 func validateCAPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "ca")
 }
 
 func validateUKPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "uk")
 }
 
 func validateDEPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "de")
 }
 
 func validateFRPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "fr")
 }
 
 func validateITPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "it")
 }
 
 func validateESPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "es")
 }
 
 func validateNLPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "nl")
 }
 
 func validateTRPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "tr")
 }
 
 func validateCNPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "cn")
 }
 
 func validateINPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "in")
 }
 
 func validateJPPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "jp")
 }
 
 func validateKRPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
 	return validatePhoneForCountry(phone, "kr")
 }
 
-// validateGeneralPhone общий валидатор телефона (проверяет по всем странам)
+// validateGeneralPhone общий валидатор телефона. Без параметра проверяет по всем странам,
+// с параметром (phone=kg kz uz) - по перечисленным странам
 func validateGeneralPhone(fl validator.FieldLevel) bool {
-	phone := cleanPhoneNumber(fl.Field().String())
+	phone := fieldPhone(fl)
+
+	countries := strings.Fields(fl.Param())
+	if len(countries) == 0 {
+		countries = sortedCountryCodes()
+	}
 
-	for countryCode := range CountryPhoneCodes {
+	for _, countryCode := range countries {
 		if validatePhoneForCountry(phone, countryCode) {
 			return true
 		}
@@ -354,21 +447,59 @@ func validateGeneralPhone(fl validator.FieldLevel) bool {
 	return false
 }
 
-// validateMobilePhone валидатор мобильных телефонов (алиас для общего валидатора)
+// validateMobilePhone валидатор мобильных телефонов: номер должен быть действительным
+// и относиться к мобильному диапазону страны
 func validateMobilePhone(fl validator.FieldLevel) bool {
-	return validateGeneralPhone(fl)
+	return isPhoneOfType(fieldPhone(fl), []PhoneType{PhoneTypeMobile})
 }
 
-// GetCountryByPhone определяет страну по номеру телефона
-func GetCountryByPhone(phone string) (string, bool) {
-	phone = cleanPhoneNumber(phone)
+// validatePhoneType проверяет тип номера: phone_type=mobile или несколько типов через пробел
+func validatePhoneType(fl validator.FieldLevel) bool {
+	phone := fieldPhone(fl)
 
-	for countryCode := range CountryPhoneCodes {
-		if validatePhoneForCountry(phone, countryCode) {
-			return countryCode, true
+	var allowed []PhoneType
+	for _, name := range strings.Fields(fl.Param()) {
+		allowed = append(allowed, PhoneType(strings.ToLower(name)))
+	}
+
+	return isPhoneOfType(phone, allowed)
+}
+
+// isPhoneOfType проверяет, что номер действителен для одной из стран и имеет один из типов allowed
+func isPhoneOfType(phone string, allowed []PhoneType) bool {
+	for _, countryCode := range sortedCountryCodes() {
+		if !validatePhoneForCountry(phone, countryCode) {
+			continue
+		}
+		if isPhoneTypeAllowed(detectPhoneType(phone, countryCode), allowed, nil) {
+			return true
 		}
 	}
-	return "", false
+	return false
+}
+
+// validateE164Phone проверяет, что значение записано строго в формате E.164
+// (знак + и только цифры) и соответствует одной из поддерживаемых стран
+func validateE164Phone(fl validator.FieldLevel) bool {
+	value, ok := stringValue(fl.Field())
+	if !ok || !e164Regex.MatchString(value) {
+		return false
+	}
+
+	_, _, found := detectCountryByMetadata(value)
+	return found
+}
+
+// GetCountryByPhone определяет страну по номеру телефона.
+// Для общих кодов (+1, +7) предпочитается основная страна кода; см. также DetectCountry.
+// Если кеширование включено (SetCacheConfig), результат берется из кеша
+func GetCountryByPhone(phone string) (string, bool) {
+	identity := func(lookup countryLookup) countryLookup { return lookup }
+	lookup := cachedValue(cacheKindCountry, nil, []string{phone}, identity, func() countryLookup {
+		code, found := DetectCountry(phone, nil)
+		return countryLookup{Code: code, Found: found}
+	})
+	return lookup.Code, lookup.Found
 }
 
 // FormatPhone форматирует номер телефона по стандарту страны
@@ -376,6 +507,16 @@ func FormatPhone(phone, countryCode string) (string, error) {
 	return formatPhoneNumber(phone, countryCode)
 }
 
+// NormalizeE164 приводит номер к формату E.164. Для номеров в национальном формате
+// используется defaultRegion (может быть пустым)
+func NormalizeE164(phone, defaultRegion string) (string, error) {
+	e164, ok := normalizeToE164(phone, resolveCountryCode(defaultRegion))
+	if !ok {
+		return "", NewValidationError(ErrorTypeInvalidFormat, "cannot normalize phone number to E.164", phone, defaultRegion, nil)
+	}
+	return e164, nil
+}
+
 // AddCountry добавляет новую страну в валидатор
 func AddCountry(countryCode, prefix, pattern string, minLen, maxLen int) error {
 	// Валидация входных параметров
@@ -409,25 +550,40 @@ func AddCountry(countryCode, prefix, pattern string, minLen, maxLen int) error {
 		CountryName: strings.ToUpper(countryCode),
 		Description: fmt.Sprintf("%s phone numbers", strings.ToUpper(countryCode)),
 	}
+	bumpRegistryVersion()
 
 	return nil
 }
 
 // AddCustomCountry добавляет кастомную страну с полной информацией
 func AddCustomCountry(country *CustomCountry) error {
-	return AddCountry(
+	err := AddCountry(
 		country.Code,
 		country.Prefix,
 		country.Pattern,
 		country.MinLength,
 		country.MaxLength,
 	)
+	if err != nil {
+		return err
+	}
+
+	if len(country.NumberRanges) > 0 {
+		normalizedCode := normalizeCountryCode(country.Code)
+		info := CountryPhoneCodes[normalizedCode]
+		info.NumberRanges = country.NumberRanges
+		CountryPhoneCodes[normalizedCode] = info
+		bumpRegistryVersion()
+	}
+
+	return nil
 }
 
 // RemoveCountry удаляет страну из валидатора
 func RemoveCountry(countryCode string) {
 	normalizedCode := normalizeCountryCode(countryCode)
 	delete(CountryPhoneCodes, normalizedCode)
+	bumpRegistryVersion()
 }
 
 // GetSupportedCountries возвращает список поддерживаемых стран
@@ -441,5 +597,5 @@ func GetSupportedCountries() []string {
 
 // IsPhoneValid простая проверка валидности номера
 func IsPhoneValid(phone, countryCode string) bool {
-	return validatePhoneForCountry(cleanPhoneNumber(phone), normalizeCountryCode(countryCode))
+	return validatePhoneForCountry(cleanPhoneNumber(phone), resolveCountryCode(countryCode))
 }
@@ -0,0 +1,260 @@
+package mnvgin
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvhttp"
+)
+
+// maxFieldSuggestions количество предложений по исправлению в ошибке поля
+const maxFieldSuggestions = 3
+
+// RegisterValidator регистрирует валидаторы mnv в движке валидации Gin (binding.Validator)
+func RegisterValidator() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("mnvgin: gin validator engine is not go-playground/validator")
+	}
+	return mnv.RegisterValidators(v)
+}
+
+// BindErrorResponse ответ на ошибку привязки запроса
+type BindErrorResponse struct {
+	// Error краткое описание ошибки
+	Error string `json:"error"`
+
+	// Details текст исходной ошибки (для ошибок, не связанных с полями)
+	Details string `json:"details,omitempty"`
+
+	// Language язык сообщений
+	Language string `json:"language"`
+
+	// Errors ошибки по полям. Для номеров телефонов содержат поля
+	// ValidationError.ToLocalizedJSON, а также field и tag
+	Errors []map[string]interface{} `json:"errors,omitempty"`
+}
+
+// Bind связывает запрос с obj, проверяет его по тегам binding и нормализует поля
+// с тегом mnv:"normalize,...". При ошибке отправляет ответ 400 и возвращает false
+func Bind(c *gin.Context, obj any) bool {
+	if err := c.ShouldBind(obj); err != nil {
+		AbortWithBindError(c, obj, err)
+		return false
+	}
+	if err := mnv.NormalizeStruct(obj); err != nil {
+		AbortWithBindError(c, obj, err)
+		return false
+	}
+	return true
+}
+
+// AbortWithBindError прерывает запрос с ответом 400. Ошибки validator.ValidationErrors
+// и *mnv.NormalizeError переводятся в локализованные ошибки полей с предложениями
+// по исправлению; язык определяется по запросу. obj - структура, с которой связывался
+// запрос (может быть nil)
+func AbortWithBindError(c *gin.Context, obj any, err error) {
+	lang := mnvhttp.RequestLanguage(c.Request)
+	response := BindErrorResponse{Error: "Validation failed", Language: lang}
+
+	var validationErrs validator.ValidationErrors
+	var normalizeErr *mnv.NormalizeError
+
+	switch {
+	case errors.As(err, &validationErrs):
+		response.Errors = FieldErrors(validationErrs, obj, lang)
+	case errors.As(err, &normalizeErr):
+		for _, fe := range normalizeErr.Fields {
+			response.Errors = append(response.Errors, normalizeFieldJSON(fe, lang))
+		}
+	default:
+		response.Error = "Invalid request"
+		response.Details = err.Error()
+	}
+
+	c.AbortWithStatusJSON(http.StatusBadRequest, response)
+}
+
+// FieldErrors переводит ошибки валидатора в JSON-совместимые ошибки полей на языке lang.
+// Для тегов mnv номер проверяется повторно, чтобы указать причину ошибки и предложения;
+// obj - проверенная структура, из которой тег phonebycountry берет поле со страной
+func FieldErrors(errs validator.ValidationErrors, obj any, lang string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(errs))
+	for _, fe := range errs {
+		var item map[string]interface{}
+		if phoneErr := phoneFieldError(fe, obj); phoneErr != nil {
+			item = phoneErr.ToLocalizedJSON(lang)
+		} else {
+			item = map[string]interface{}{"message": fe.Error()}
+		}
+		item["field"] = fe.Field()
+		item["tag"] = fe.Tag()
+		result = append(result, item)
+	}
+	return result
+}
+
+// normalizeFieldJSON переводит ошибку нормализации поля в JSON-совместимую структуру
+func normalizeFieldJSON(fe *mnv.NormalizeFieldError, lang string) map[string]interface{} {
+	var item map[string]interface{}
+	if validationErr, ok := mnv.GetValidationError(fe.Err); ok {
+		item = validationErr.ToLocalizedJSON(lang)
+	} else {
+		item = map[string]interface{}{"message": fe.Err.Error()}
+	}
+	item["field"] = fe.Field
+	item["tag"] = "normalize"
+	return item
+}
+
+// phoneTags теги mnv, не являющиеся кодами стран
+var phoneTags = map[string]bool{
+	"phone":          true,
+	"mobile":         true,
+	"phonebycountry": true,
+	"phone_type":     true,
+	"e164":           true,
+}
+
+// phoneFieldError проверяет значение поля с тегом mnv и возвращает причину ошибки.
+// Для остальных тегов возвращает nil
+func phoneFieldError(fe validator.FieldError, obj any) *mnv.ValidationError {
+	tag, param := fe.Tag(), fe.Param()
+	_, isCountryTag := mnv.CountryPhoneCodes[tag]
+	if !isCountryTag && !phoneTags[tag] {
+		return nil
+	}
+
+	phone := fieldString(fe.Value())
+	country := ""
+	opts := &mnv.ValidationOptions{ReturnSuggestions: true, MaxSuggestions: maxFieldSuggestions}
+
+	switch {
+	case isCountryTag:
+		country = tag
+	case tag == "phone" && param != "":
+		opts.AllowedCountries = strings.Fields(param)
+	case tag == "phonebycountry":
+		country = countryField(obj, fe)
+	case tag == "mobile":
+		opts.AllowedTypes = []mnv.PhoneType{mnv.PhoneTypeMobile}
+	case tag == "phone_type":
+		for _, phoneType := range strings.Fields(param) {
+			opts.AllowedTypes = append(opts.AllowedTypes, mnv.PhoneType(strings.ToLower(phoneType)))
+		}
+	}
+
+	if err := mnv.ValidatePhone(phone, country, opts).ToError(); err != nil {
+		return err
+	}
+	// Номер прошел общую проверку, но не требования тега (например, e164 без разделителей)
+	return mnv.NewInvalidFormatError(phone, country)
+}
+
+// countryField возвращает страну тега phonebycountry: значение поля структуры obj,
+// указанного в параметре тега (по умолчанию Country), рядом с полем ошибки fe
+func countryField(obj any, fe validator.FieldError) string {
+	name := fe.Param()
+	if name == "" {
+		name = "Country"
+	}
+
+	parent, ok := parentStruct(reflect.ValueOf(obj), fe.StructNamespace())
+	if !ok {
+		return ""
+	}
+	field := parent.FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return ""
+	}
+
+	country := fieldString(field.Interface())
+	if code, found := mnv.ResolveCountry(country); found {
+		return code
+	}
+	return country
+}
+
+// parentStruct находит в value структуру, содержащую поле с пространством имен namespace
+// (Signup.Contacts[0].Phone), как его формирует validator
+func parentStruct(value reflect.Value, namespace string) (reflect.Value, bool) {
+	parts := strings.Split(namespace, ".")
+	if len(parts) < 2 {
+		return reflect.Value{}, false
+	}
+
+	// Первая часть - имя типа структуры, последняя - имя поля
+	for _, part := range parts[1 : len(parts)-1] {
+		if value = indirect(value); value.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		name, indexes, _ := strings.Cut(part, "[")
+		value = value.FieldByName(name)
+		for indexes != "" {
+			var key string
+			key, indexes, _ = strings.Cut(indexes, "]")
+			indexes = strings.TrimPrefix(indexes, "[")
+			value = elementAt(indirect(value), key)
+		}
+	}
+
+	value = indirect(value)
+	return value, value.Kind() == reflect.Struct
+}
+
+// indirect разыменовывает указатели и интерфейсы; для nil возвращает нулевое значение
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// elementAt возвращает элемент среза, массива или карты со строковыми ключами
+func elementAt(value reflect.Value, key string) reflect.Value {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= value.Len() {
+			return reflect.Value{}
+		}
+		return value.Index(index)
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}
+		}
+		return value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+	default:
+		return reflect.Value{}
+	}
+}
+
+// fieldString возвращает строковое значение поля
+func fieldString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case *string:
+		if v != nil {
+			return *v
+		}
+		return ""
+	case sql.NullString:
+		return v.String
+	default:
+		return fmt.Sprint(v)
+	}
+}
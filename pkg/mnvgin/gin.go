@@ -0,0 +1,210 @@
+// Package mnvgin интегрирует валидацию номеров телефонов с Gin: маршруты API,
+// привязку запросов с локализованными ошибками и middleware страны запроса
+package mnvgin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvhttp"
+)
+
+// Options настройки маршрутов
+type Options struct {
+	// Admin монтирует также маршруты, изменяющие реестр стран и глобальную конфигурацию.
+	// Реестр стран не синхронизирован с выполняемыми проверками, поэтому эти маршруты
+	// предназначены для закрытых от внешних клиентов групп и не должны вызываться
+	// одновременно с валидацией
+	Admin bool
+
+	// MaxBatchSize максимальное количество номеров в пакетном запросе (по умолчанию 1000)
+	MaxBatchSize int
+
+	// MaxBodyBytes максимальный размер тела запроса в байтах (по умолчанию 1 МиБ)
+	MaxBodyBytes int64
+
+	// StreamIdleTimeout см. mnvhttp.Options.StreamIdleTimeout
+	StreamIdleTimeout time.Duration
+
+	// RegionHeader заголовок со страной по умолчанию (по умолчанию DefaultRegionHeader)
+	RegionHeader string
+
+	// ValidationOptions базовые опции валидации (ограничения стран и типов номеров)
+	ValidationOptions *mnv.ValidationOptions
+}
+
+// ConfigUpdate частичное обновление конфигурации; незаданные поля не изменяются
+type ConfigUpdate struct {
+	AllowSpaces      *bool `json:"allow_spaces"`
+	AllowDashes      *bool `json:"allow_dashes"`
+	AllowParentheses *bool `json:"allow_parentheses"`
+	AllowDots        *bool `json:"allow_dots"`
+	StrictMode       *bool `json:"strict_mode"`
+	RequirePlusSign  *bool `json:"require_plus_sign"`
+}
+
+// Apply применяет обновление к конфигурации
+func (u ConfigUpdate) Apply(cfg mnv.ValidatorConfig) mnv.ValidatorConfig {
+	if u.AllowSpaces != nil {
+		cfg.AllowSpaces = *u.AllowSpaces
+	}
+	if u.AllowDashes != nil {
+		cfg.AllowDashes = *u.AllowDashes
+	}
+	if u.AllowParentheses != nil {
+		cfg.AllowParentheses = *u.AllowParentheses
+	}
+	if u.AllowDots != nil {
+		cfg.AllowDots = *u.AllowDots
+	}
+	if u.StrictMode != nil {
+		cfg.StrictMode = *u.StrictMode
+	}
+	if u.RequirePlusSign != nil {
+		cfg.RequirePlusSign = *u.RequirePlusSign
+	}
+	return cfg
+}
+
+// Register монтирует маршруты API валидации в router (движок или группу). Маршруты API
+// обслуживает mnvhttp.NewHandler, поэтому таймауты, лимит одновременных проверок
+// и ответы 503/504 такие же, как у net/http:
+//
+//	POST   /validate                - валидация номера (mnvhttp.ValidateRequest)
+//	POST   /validate/batch          - пакетная валидация (mnv.BatchValidationRequest)
+//	POST   /validate/stream         - потоковая валидация NDJSON (mnv.BatchItem -> mnv.StreamResult)
+//	GET    /phone/:phone/info       - информация о номере
+//	GET    /format/:phone/:country  - форматирование (?format=e164|international|national|rfc3966)
+//	GET    /detect/:phone           - определение страны
+//	GET    /countries               - список стран
+//	GET    /countries/:code         - информация о стране
+//	GET    /config                  - текущая конфигурация
+//	GET    /config/presets          - список пресетов
+//
+// Если установлен Options.Admin, также монтируются изменяющие маршруты:
+//
+//	POST   /countries               - добавление страны (mnv.CustomCountry)
+//	DELETE /countries/:code         - удаление страны
+//	PUT    /config                  - обновление конфигурации (ConfigUpdate)
+//	PUT    /config/preset/:preset   - применение пресета
+//
+// Маршруты используют RegionMiddleware с заголовком Options.RegionHeader: национальные
+// номера без указанной страны относятся к стране запроса
+func Register(router gin.IRouter, opts Options) {
+	group := router.Group("", RegionMiddleware(opts.RegionHeader))
+
+	api := gin.WrapH(http.StripPrefix(strings.TrimSuffix(group.BasePath(), "/"), mnvhttp.NewHandler(mnvhttp.Options{
+		MaxBatchSize:      opts.MaxBatchSize,
+		MaxBodyBytes:      opts.MaxBodyBytes,
+		StreamIdleTimeout: opts.StreamIdleTimeout,
+		ValidationOptions: opts.ValidationOptions,
+		Region:            requestRegion,
+	})))
+	group.POST("/validate", api)
+	group.POST("/validate/batch", api)
+	group.POST("/validate/stream", api)
+	group.GET("/phone/:phone/info", api)
+	group.GET("/format/:phone/:country", api)
+	group.GET("/detect/:phone", api)
+	group.GET("/countries", api)
+	group.GET("/countries/:code", api)
+	group.GET("/config", config)
+	group.GET("/config/presets", presets)
+
+	if !opts.Admin {
+		return
+	}
+	group.POST("/countries", addCountry)
+	group.DELETE("/countries/:code", removeCountry)
+	group.PUT("/config", updateConfig)
+	group.PUT("/config/preset/:preset", setPreset)
+}
+
+// AbortWithProblem прерывает запрос с ответом application/problem+json (RFC 7807)
+func AbortWithProblem(c *gin.Context, status int, err error) {
+	problem := mnvhttp.NewProblem(err, status, mnvhttp.RequestLanguage(c.Request))
+	problem.Instance = c.Request.URL.RequestURI()
+
+	c.Header("Content-Type", mnvhttp.ProblemContentType)
+	c.AbortWithStatusJSON(status, problem)
+}
+
+// addCountry добавляет страну в реестр
+func addCountry(c *gin.Context) {
+	var country mnv.CustomCountry
+	if err := c.ShouldBindJSON(&country); err != nil {
+		AbortWithProblem(c, http.StatusBadRequest, fmt.Errorf("invalid country data: %w", err))
+		return
+	}
+
+	if err := mnv.AddCustomCountry(&country); err != nil {
+		AbortWithProblem(c, http.StatusBadRequest, fmt.Errorf("failed to add country: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Country added successfully",
+		"country": country,
+	})
+}
+
+// removeCountry удаляет страну из реестра
+func removeCountry(c *gin.Context) {
+	code := c.Param("code")
+	if _, exists := mnv.GetCountryInfo(code); !exists {
+		AbortWithProblem(c, http.StatusNotFound, mnv.NewUnsupportedCountryError(code))
+		return
+	}
+
+	mnv.RemoveCountry(code)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Country removed successfully",
+		"code":    code,
+	})
+}
+
+// config возвращает текущую конфигурацию
+func config(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"config": mnv.GetConfig()})
+}
+
+// presets возвращает список пресетов конфигурации
+func presets(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"presets": mnv.ListPresets()})
+}
+
+// updateConfig обновляет переданные поля конфигурации
+func updateConfig(c *gin.Context) {
+	var update ConfigUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		AbortWithProblem(c, http.StatusBadRequest, fmt.Errorf("invalid configuration: %w", err))
+		return
+	}
+
+	config := update.Apply(mnv.GetConfig())
+	mnv.SetConfig(config)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Configuration updated successfully",
+		"config":  config,
+	})
+}
+
+// setPreset применяет пресет конфигурации
+func setPreset(c *gin.Context) {
+	preset := c.Param("preset")
+	if err := mnv.SetPresetConfig(preset); err != nil {
+		AbortWithProblem(c, http.StatusBadRequest, fmt.Errorf("failed to set preset: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Preset applied successfully",
+		"preset":  preset,
+		"config":  mnv.GetConfig(),
+	})
+}
@@ -0,0 +1,64 @@
+package mnvgin
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// DefaultRegionHeader заголовок со страной по умолчанию для номеров в национальном формате
+const DefaultRegionHeader = "X-Region"
+
+// regionKey ключ контекста Gin со страной запроса
+const regionKey = "mnv.region"
+
+// regionContextKey ключ контекста запроса со страной для обработчиков net/http
+type regionContextKey struct{}
+
+// RegionMiddleware читает страну по умолчанию из заголовка header (пустой - DefaultRegionHeader)
+// и сохраняет ее в контексте Gin и контексте запроса. Неизвестные страны игнорируются
+func RegionMiddleware(header string) gin.HandlerFunc {
+	if header == "" {
+		header = DefaultRegionHeader
+	}
+
+	return func(c *gin.Context) {
+		if value := strings.TrimSpace(c.GetHeader(header)); value != "" {
+			if code, ok := mnv.ResolveCountry(value); ok {
+				c.Set(regionKey, code)
+				c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), regionContextKey{}, code))
+			}
+		}
+		c.Next()
+	}
+}
+
+// Region возвращает страну запроса, установленную RegionMiddleware
+func Region(c *gin.Context) string {
+	return c.GetString(regionKey)
+}
+
+// requestRegion возвращает страну запроса net/http, установленную RegionMiddleware
+func requestRegion(r *http.Request) string {
+	region, _ := r.Context().Value(regionContextKey{}).(string)
+	return region
+}
+
+// CORS разрешает кросс-доменные запросы к API и отвечает на предварительные запросы OPTIONS
+func CORS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept-Language, "+DefaultRegionHeader)
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,396 @@
+// Package mnvhttp предоставляет обработчики и middleware net/http для валидации
+// номеров телефонов. Пакет не зависит от веб-фреймворков и подходит для chi,
+// gorilla/mux и стандартного http.ServeMux
+package mnvhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// Значения Options по умолчанию
+const (
+	defaultMaxBatchSize   = 1000
+	defaultMaxBodyBytes   = 1 << 20
+	defaultMaxSuggestions = 5
+	defaultStreamIdle     = 30 * time.Second
+)
+
+// Options настройки обработчика
+type Options struct {
+	// MaxBatchSize максимальное количество номеров в пакетном запросе (по умолчанию 1000)
+	MaxBatchSize int
+
+	// MaxBodyBytes максимальный размер тела запроса в байтах (по умолчанию 1 МиБ)
+	MaxBodyBytes int64
+
+	// StreamIdleTimeout максимальное время ожидания очередной строки запроса или записи
+	// результата потоковой валидации (по умолчанию 30 секунд). Заменяет таймауты сервера
+	// для /validate/stream, чтобы длина потока не была ограничена
+	StreamIdleTimeout time.Duration
+
+	// ValidationOptions базовые опции валидации (ограничения стран и типов номеров)
+	ValidationOptions *mnv.ValidationOptions
+
+	// Region возвращает страну запроса по умолчанию (необязательно). К ней относятся
+	// номера в национальном формате без указанной страны в /validate, /validate/batch
+	// и /validate/stream
+	Region func(r *http.Request) string
+}
+
+// withDefaults заполняет незаданные настройки значениями по умолчанию
+func (o Options) withDefaults() Options {
+	if o.MaxBatchSize <= 0 {
+		o.MaxBatchSize = defaultMaxBatchSize
+	}
+	if o.MaxBodyBytes <= 0 {
+		o.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if o.StreamIdleTimeout <= 0 {
+		o.StreamIdleTimeout = defaultStreamIdle
+	}
+	return o
+}
+
+// ValidateRequest запрос на валидацию номера
+type ValidateRequest struct {
+	// Phone номер телефона
+	Phone string `json:"phone"`
+
+	// Country код страны; если не задан, страна определяется по номеру
+	Country string `json:"country,omitempty"`
+
+	// ReturnInfo добавить в ответ информацию о номере
+	ReturnInfo bool `json:"return_info,omitempty"`
+
+	// ReturnSuggestions добавить в ответ предложения по исправлению
+	ReturnSuggestions bool `json:"return_suggestions,omitempty"`
+}
+
+// ValidateResponse ответ на валидацию номера
+type ValidateResponse struct {
+	*mnv.ValidationResult
+
+	// Info информация о номере (если запрошена и номер валиден)
+	Info *mnv.PhoneInfo `json:"info,omitempty"`
+}
+
+// FormatResponse ответ на форматирование номера
+type FormatResponse struct {
+	// Original исходный номер
+	Original string `json:"original"`
+
+	// Country код страны
+	Country string `json:"country"`
+
+	// Format формат записи
+	Format mnv.NumberFormat `json:"format"`
+
+	// Formatted отформатированный номер
+	Formatted string `json:"formatted"`
+}
+
+// DetectResponse ответ на определение страны номера
+type DetectResponse struct {
+	// Phone исходный номер
+	Phone string `json:"phone"`
+
+	// Found определена ли страна
+	Found bool `json:"found"`
+
+	// Country код страны
+	Country string `json:"country,omitempty"`
+
+	// CountryName название страны на языке запроса
+	CountryName string `json:"country_name,omitempty"`
+
+	// Prefix телефонный префикс страны
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// CountriesResponse список поддерживаемых стран
+type CountriesResponse struct {
+	// Countries коды стран
+	Countries []string `json:"countries"`
+
+	// Count количество стран
+	Count int `json:"count"`
+
+	// Language язык названий
+	Language string `json:"language"`
+
+	// Details информация о странах
+	Details map[string]mnv.PhoneCodeInfo `json:"details"`
+}
+
+// CountryResponse информация о стране
+type CountryResponse struct {
+	// CountryCode код страны
+	CountryCode string `json:"country_code"`
+
+	// Language язык названий
+	Language string `json:"language"`
+
+	// Info информация о стране
+	Info mnv.PhoneCodeInfo `json:"info"`
+}
+
+// handler обработчики API валидации
+type handler struct {
+	opts Options
+}
+
+// NewHandler создает http.Handler с маршрутами API валидации:
+//
+//	POST /validate                  - валидация номера (ValidateRequest)
+//	POST /validate/batch            - пакетная валидация (mnv.BatchValidationRequest)
+//	POST /validate/stream           - потоковая валидация NDJSON (mnv.BatchItem -> mnv.StreamResult)
+//	GET  /phone/{phone}/info        - информация о номере
+//	GET  /format/{phone}/{country}  - форматирование (?format=e164|international|national|rfc3966)
+//	GET  /detect/{phone}            - определение страны
+//	GET  /countries                 - список стран
+//	GET  /countries/{code}          - информация о стране
+//
+// Для монтирования под префиксом используйте http.StripPrefix
+func NewHandler(opts Options) http.Handler {
+	h := &handler{opts: opts.withDefaults()}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /validate", h.validate)
+	mux.HandleFunc("POST /validate/batch", h.batch)
+	mux.HandleFunc("POST /validate/stream", h.stream)
+	mux.HandleFunc("GET /phone/{phone}/info", h.info)
+	mux.HandleFunc("GET /format/{phone}/{country}", h.format)
+	mux.HandleFunc("GET /detect/{phone}", h.detect)
+	mux.HandleFunc("GET /countries", h.countries)
+	mux.HandleFunc("GET /countries/{code}", h.country)
+	return mux
+}
+
+// validationOptions возвращает копию базовых опций валидации
+func (h *handler) validationOptions() *mnv.ValidationOptions {
+	opts := &mnv.ValidationOptions{}
+	if h.opts.ValidationOptions != nil {
+		*opts = *h.opts.ValidationOptions
+	}
+	return opts
+}
+
+// region возвращает страну запроса по умолчанию (см. Options.Region)
+func (h *handler) region(r *http.Request) string {
+	if h.opts.Region == nil {
+		return ""
+	}
+	return h.opts.Region(r)
+}
+
+// decodeJSON читает тело запроса в dst с ограничением размера
+func (h *handler) decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		WriteProblem(w, r, status, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+// validate проверяет один номер
+func (h *handler) validate(w http.ResponseWriter, r *http.Request) {
+	var req ValidateRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Phone == "" {
+		WriteProblem(w, r, http.StatusBadRequest, errors.New("phone number is required"))
+		return
+	}
+
+	opts := h.validationOptions()
+	opts.ReturnSuggestions = req.ReturnSuggestions
+	if opts.MaxSuggestions <= 0 {
+		opts.MaxSuggestions = defaultMaxSuggestions
+	}
+
+	phone, country := req.Phone, req.Country
+	if region := h.region(r); country == "" && region != "" && !isInternational(phone) {
+		if e164, err := mnv.NormalizeE164(phone, region); err == nil {
+			phone, country = e164, region
+		}
+	}
+
+	result, err := mnv.ValidatePhoneContext(r.Context(), phone, country, opts)
+	if err != nil {
+		writeUnavailable(w, r, err)
+		return
+	}
+
+	response := ValidateResponse{ValidationResult: result}
+	response.OriginalNumber = req.Phone
+	if req.ReturnInfo && response.IsValid {
+		if response.Info, err = mnv.GetPhoneInfoContext(r.Context(), response.FormattedNumber); err != nil {
+			writeUnavailable(w, r, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// isInternational проверяет, записан ли номер в международном формате
+func isInternational(phone string) bool {
+	phone = strings.TrimSpace(phone)
+	return strings.HasPrefix(phone, "+") || strings.HasPrefix(phone, "00")
+}
+
+// batch проверяет список номеров; по умолчанию национальные номера относятся к стране запроса
+func (h *handler) batch(w http.ResponseWriter, r *http.Request) {
+	var req mnv.BatchValidationRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+
+	switch total := len(req.Phones) + len(req.Items); {
+	case total == 0:
+		WriteProblem(w, r, http.StatusBadRequest, errors.New("phones are required"))
+		return
+	case total > h.opts.MaxBatchSize:
+		WriteProblem(w, r, http.StatusRequestEntityTooLarge,
+			fmt.Errorf("too many phone numbers: maximum %d per request", h.opts.MaxBatchSize))
+		return
+	}
+
+	// Клиент может настроить только предложения: ограничения стран и типов номеров
+	// и конфигурация валидатора задаются сервером
+	opts := h.validationOptions()
+	if req.Options != nil {
+		opts.ReturnSuggestions = req.Options.ReturnSuggestions
+		opts.MaxSuggestions = req.Options.MaxSuggestions
+	}
+	req.Options = opts
+
+	if req.DefaultRegion == "" {
+		req.DefaultRegion = h.region(r)
+	}
+	response, err := mnv.BatchValidatePhonesContext(r.Context(), &req)
+	if err != nil {
+		writeUnavailable(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// info возвращает информацию о номере
+func (h *handler) info(w http.ResponseWriter, r *http.Request) {
+	info, err := mnv.GetPhoneInfoContext(r.Context(), r.PathValue("phone"))
+	if err != nil {
+		writeUnavailable(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// writeUnavailable отправляет ошибку таймаута (504) или перегрузки (503) валидатора.
+// Если клиент отменил запрос, ответ не отправляется
+func writeUnavailable(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	status := http.StatusGatewayTimeout
+	if errors.Is(err, mnv.ErrOverloaded) {
+		status = http.StatusServiceUnavailable
+		w.Header().Set("Retry-After", "1")
+	}
+	WriteProblem(w, r, status, err)
+}
+
+// format форматирует номер
+func (h *handler) format(w http.ResponseWriter, r *http.Request) {
+	phone, country := r.PathValue("phone"), r.PathValue("country")
+
+	format := mnv.NumberFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = mnv.FormatInternational
+	}
+
+	formatted, err := mnv.FormatNumber(phone, country, format)
+	if err != nil {
+		WriteProblem(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, FormatResponse{
+		Original:  phone,
+		Country:   country,
+		Format:    format,
+		Formatted: formatted,
+	})
+}
+
+// detect определяет страну номера с учетом базовых опций
+func (h *handler) detect(w http.ResponseWriter, r *http.Request) {
+	phone := r.PathValue("phone")
+	response := DetectResponse{Phone: phone}
+
+	if country, found := mnv.DetectCountry(phone, h.opts.ValidationOptions); found {
+		info, _ := mnv.LocalizedCountryInfo(country, RequestLanguage(r))
+		response.Found = true
+		response.Country = country
+		response.CountryName = info.CountryName
+		response.Prefix = info.Prefix
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// countries возвращает список поддерживаемых стран
+func (h *handler) countries(w http.ResponseWriter, r *http.Request) {
+	lang := RequestLanguage(r)
+	countries := mnv.GetSupportedCountries()
+
+	details := make(map[string]mnv.PhoneCodeInfo, len(countries))
+	for _, code := range countries {
+		if info, exists := mnv.LocalizedCountryInfo(code, lang); exists {
+			details[code] = info
+		}
+	}
+
+	setLanguageHeaders(w, lang)
+	writeJSON(w, http.StatusOK, CountriesResponse{
+		Countries: countries,
+		Count:     len(countries),
+		Language:  lang,
+		Details:   details,
+	})
+}
+
+// country возвращает информацию о стране
+func (h *handler) country(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	lang := RequestLanguage(r)
+
+	info, exists := mnv.LocalizedCountryInfo(code, lang)
+	if !exists {
+		WriteProblem(w, r, http.StatusNotFound, mnv.NewUnsupportedCountryError(code))
+		return
+	}
+
+	setLanguageHeaders(w, lang)
+	writeJSON(w, http.StatusOK, CountryResponse{CountryCode: code, Language: lang, Info: info})
+}
+
+// setLanguageHeaders указывает язык ответа и его зависимость от Accept-Language
+func setLanguageHeaders(w http.ResponseWriter, lang string) {
+	w.Header().Set("Content-Language", lang)
+	w.Header().Set("Vary", "Accept-Language")
+}
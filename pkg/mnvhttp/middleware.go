@@ -0,0 +1,294 @@
+package mnvhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// defaultMultipartMemory объем памяти для разбора multipart-форм
+const defaultMultipartMemory = 32 << 20
+
+// MiddlewareConfig настройки middleware валидации номеров в запросе
+type MiddlewareConfig struct {
+	// QueryParams параметры строки запроса с номерами
+	QueryParams []string
+
+	// FormFields поля формы (application/x-www-form-urlencoded, multipart/form-data) с номерами
+	FormFields []string
+
+	// JSONPaths пути к номерам в JSON-теле через точку, например "contact.phone".
+	// Массивы на пути обходятся поэлементно. Непустое тело с типом, отличным от JSON,
+	// отклоняется с 415 Unsupported Media Type
+	JSONPaths []string
+
+	// Region страна по умолчанию для номеров в национальном формате
+	Region string
+
+	// RegionHeader заголовок со страной по умолчанию; имеет приоритет над Region
+	RegionHeader string
+
+	// Required считать отсутствие номера ошибкой
+	Required bool
+
+	// Options опции валидации (ограничения стран и типов номеров)
+	Options *mnv.ValidationOptions
+
+	// ErrorStatus HTTP статус ответа при ошибке (по умолчанию 422)
+	ErrorStatus int
+
+	// MaxBodyBytes максимальный размер тела запроса в байтах (по умолчанию 1 МиБ)
+	MaxBodyBytes int64
+}
+
+// resultsKey ключ контекста с результатами проверки
+type resultsKey struct{}
+
+// Results возвращает результаты проверки номеров, выполненной Middleware, по именам полей
+// ("phone", "contact.phone", "contacts[1].phone")
+func Results(r *http.Request) map[string]*mnv.ValidationResult {
+	results, _ := r.Context().Value(resultsKey{}).(map[string]*mnv.ValidationResult)
+	return results
+}
+
+// fieldError ошибка проверки одного поля
+type fieldError struct {
+	name string
+	err  *mnv.ValidationError
+}
+
+// Middleware проверяет номера из параметров запроса, полей формы и JSON-тела.
+// При ошибке отвечает application/problem+json со списком invalid_params, иначе
+// передает запрос дальше; тело запроса остается доступным для чтения.
+// Результаты проверки доступны через Results
+func Middleware(cfg MiddlewareConfig) func(http.Handler) http.Handler {
+	if cfg.ErrorStatus == 0 {
+		cfg.ErrorStatus = http.StatusUnprocessableEntity
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = defaultMaxBodyBytes
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			region := cfg.Region
+			if cfg.RegionHeader != "" {
+				if header := r.Header.Get(cfg.RegionHeader); header != "" {
+					region = header
+				}
+			}
+
+			v := &requestValidator{cfg: cfg, region: region, results: make(map[string]*mnv.ValidationResult)}
+
+			for _, name := range cfg.QueryParams {
+				v.checkValues(name, r.URL.Query()[name])
+			}
+
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if len(cfg.FormFields) > 0 {
+				if err := parseForm(r, mediaType); err != nil {
+					WriteProblem(w, r, http.StatusBadRequest, fmt.Errorf("invalid form: %w", err))
+					return
+				}
+				for _, name := range cfg.FormFields {
+					v.checkValues(name, r.PostForm[name])
+				}
+			}
+
+			if len(cfg.JSONPaths) > 0 {
+				body, err := readBody(w, r, cfg.MaxBodyBytes)
+				if err != nil {
+					WriteProblem(w, r, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+					return
+				}
+				if len(bytes.TrimSpace(body)) > 0 && !isJSONMediaType(mediaType) {
+					// Иначе то же тело с другим Content-Type прошло бы без проверки номеров
+					WriteProblem(w, r, http.StatusUnsupportedMediaType,
+						fmt.Errorf("unsupported media type %q: phone numbers are read from a JSON body", mediaType))
+					return
+				}
+				if err := v.checkJSON(body); err != nil {
+					WriteProblem(w, r, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+					return
+				}
+			}
+
+			if len(v.errors) > 0 {
+				writeFieldErrors(w, r, cfg.ErrorStatus, v.errors)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), resultsKey{}, v.results)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// parseForm разбирает тело формы в зависимости от типа содержимого
+func parseForm(r *http.Request, mediaType string) error {
+	if mediaType == "multipart/form-data" {
+		return r.ParseMultipartForm(defaultMultipartMemory)
+	}
+	return r.ParseForm()
+}
+
+// readBody читает тело запроса и восстанавливает его для следующих обработчиков
+func readBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// requestValidator состояние проверки одного запроса
+type requestValidator struct {
+	cfg     MiddlewareConfig
+	region  string
+	results map[string]*mnv.ValidationResult
+	errors  []fieldError
+}
+
+// checkValues проверяет значения параметра или поля формы
+func (v *requestValidator) checkValues(name string, values []string) {
+	if len(values) == 0 {
+		v.missing(name)
+		return
+	}
+	for i, value := range values {
+		fieldName := name
+		if len(values) > 1 {
+			fieldName = fmt.Sprintf("%s[%d]", name, i)
+		}
+		v.check(fieldName, value)
+	}
+}
+
+// isJSONMediaType проверяет, что тело запроса с типом mediaType разбирается как JSON.
+// Тело без Content-Type также разбирается как JSON
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "" || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// checkJSON проверяет номера по путям в JSON-теле; пустое тело не содержит номеров
+func (v *requestValidator) checkJSON(body []byte) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		for _, path := range v.cfg.JSONPaths {
+			v.missing(path)
+		}
+		return nil
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return err
+	}
+
+	for _, path := range v.cfg.JSONPaths {
+		v.walkJSON(document, strings.Split(path, "."), "")
+	}
+	return nil
+}
+
+// walkJSON спускается по пути segments и проверяет найденные строки
+func (v *requestValidator) walkJSON(node interface{}, segments []string, name string) {
+	if items, ok := node.([]interface{}); ok {
+		for i, item := range items {
+			v.walkJSON(item, segments, fmt.Sprintf("%s[%d]", name, i))
+		}
+		return
+	}
+
+	if len(segments) == 0 {
+		switch value := node.(type) {
+		case string:
+			v.check(name, value)
+		case nil:
+			v.missing(name)
+		default:
+			v.fail(name, mnv.NewValidationError(mnv.ErrorTypeInvalidFormat,
+				"phone number must be a string", fmt.Sprint(value), "", nil))
+		}
+		return
+	}
+
+	object, _ := node.(map[string]interface{})
+	if name != "" {
+		name += "."
+	}
+	v.walkJSON(object[segments[0]], segments[1:], name+segments[0])
+}
+
+// check проверяет один номер. Номера без знака + и международного выхода "00"
+// дополняются кодом страны по умолчанию
+func (v *requestValidator) check(name, phone string) {
+	if strings.TrimSpace(phone) == "" {
+		v.missing(name)
+		return
+	}
+
+	e164, err := mnv.NormalizeE164(phone, v.region)
+	if err != nil {
+		var validationErr *mnv.ValidationError
+		if errors.As(err, &validationErr) {
+			v.fail(name, validationErr)
+		}
+		return
+	}
+
+	country := ""
+	trimmed := strings.TrimSpace(phone)
+	if !strings.HasPrefix(trimmed, "+") && !strings.HasPrefix(trimmed, "00") {
+		country = v.region
+	}
+
+	result := mnv.ValidatePhone(e164, country, v.cfg.Options)
+	if validationErr := result.ToError(); validationErr != nil {
+		validationErr.Phone = phone
+		v.fail(name, validationErr)
+		return
+	}
+	v.results[name] = result
+}
+
+// missing регистрирует отсутствие номера, если он обязателен
+func (v *requestValidator) missing(name string) {
+	if v.cfg.Required {
+		v.fail(name, mnv.NewValidationError(mnv.ErrorTypeInvalidFormat, "phone number is required", "", "", nil))
+	}
+}
+
+// fail добавляет ошибку поля
+func (v *requestValidator) fail(name string, err *mnv.ValidationError) {
+	v.errors = append(v.errors, fieldError{name: name, err: err})
+}
+
+// writeFieldErrors отправляет ошибки полей одним документом RFC 7807: основная
+// проблема строится по первой ошибке, все ошибки перечисляются в invalid_params
+func writeFieldErrors(w http.ResponseWriter, r *http.Request, status int, errs []fieldError) {
+	lang := RequestLanguage(r)
+
+	problem := NewProblem(errs[0].err, status, lang)
+	problem.Instance = requestInstance(r)
+
+	params := make([]map[string]interface{}, len(errs))
+	for i, fe := range errs {
+		params[i] = fe.err.ToLocalizedJSON(lang)
+		params[i]["name"] = fe.name
+	}
+	problem.Extensions["invalid_params"] = params
+
+	writeProblem(w, problem)
+}
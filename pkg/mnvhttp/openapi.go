@@ -0,0 +1,358 @@
+package mnvhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// openAPIVersion версия спецификации OpenAPI
+const openAPIVersion = "3.0.3"
+
+// OpenAPIInfo сведения об API для документа OpenAPI
+type OpenAPIInfo struct {
+	// Title название API
+	Title string
+
+	// Version версия API
+	Version string
+
+	// ServerURL базовый URL, под которым смонтирован NewHandler (например, "/api/v1")
+	ServerURL string
+}
+
+// OpenAPISpec формирует документ OpenAPI 3 для маршрутов NewHandler.
+// Схемы ответов строятся по типам Go (ValidationResult, PhoneInfo,
+// BatchValidationResponse и др.) через их JSON-теги
+func OpenAPISpec(info OpenAPIInfo) map[string]interface{} {
+	if info.Title == "" {
+		info.Title = "Mobile Number Validator API"
+	}
+	if info.Version == "" {
+		info.Version = "1.0.0"
+	}
+
+	g := &schemaGenerator{schemas: map[string]interface{}{"Problem": problemSchema()}}
+	validateRequest := g.ref(reflect.TypeOf(ValidateRequest{}))
+	validateResponse := g.ref(reflect.TypeOf(ValidateResponse{}))
+	batchRequest := g.ref(reflect.TypeOf(mnv.BatchValidationRequest{}))
+	batchResponse := g.ref(reflect.TypeOf(mnv.BatchValidationResponse{}))
+	batchItem := g.ref(reflect.TypeOf(mnv.BatchItem{}))
+	streamResult := g.ref(reflect.TypeOf(mnv.StreamResult{}))
+	phoneInfo := g.ref(reflect.TypeOf(mnv.PhoneInfo{}))
+	formatResponse := g.ref(reflect.TypeOf(FormatResponse{}))
+	detectResponse := g.ref(reflect.TypeOf(DetectResponse{}))
+	countriesResponse := g.ref(reflect.TypeOf(CountriesResponse{}))
+	countryResponse := g.ref(reflect.TypeOf(CountryResponse{}))
+
+	phoneParam := pathParameter("phone", "Phone number, URL-encoded")
+	langParam := map[string]interface{}{
+		"name": "lang", "in": "query", "required": false,
+		"description": "Response language; overrides Accept-Language",
+		"schema":      map[string]interface{}{"type": "string"},
+	}
+
+	spec := map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":   info.Title,
+			"version": info.Version,
+		},
+		"paths": map[string]interface{}{
+			"/validate": map[string]interface{}{
+				"post": operation("validatePhone", "Validate a phone number", requestBody(validateRequest), nil,
+					jsonResponse("Validation result", validateResponse), http.StatusBadRequest,
+					http.StatusServiceUnavailable, http.StatusGatewayTimeout),
+			},
+			"/validate/batch": map[string]interface{}{
+				"post": operation("validatePhones", "Validate a batch of phone numbers", requestBody(batchRequest), nil,
+					jsonResponse("Batch validation result", batchResponse), http.StatusBadRequest, http.StatusRequestEntityTooLarge,
+					http.StatusServiceUnavailable, http.StatusGatewayTimeout),
+			},
+			"/validate/stream": map[string]interface{}{
+				"post": operation("validatePhoneStream", "Validate a stream of phone numbers",
+					ndjsonBody(batchItem), []interface{}{
+						queryParameter("ordered", "Return results in input order", map[string]interface{}{"type": "boolean", "default": false}),
+						queryParameter("default_region", "Country for numbers in national format", map[string]interface{}{"type": "string"}),
+						queryParameter("stats_every", "Emit a statistics snapshot after every N results", map[string]interface{}{"type": "integer", "minimum": 0}),
+					},
+					ndjsonResponse("Validation results and statistics snapshots, one per line", streamResult), http.StatusBadRequest),
+			},
+			"/phone/{phone}/info": map[string]interface{}{
+				"get": operation("getPhoneInfo", "Get phone number details", nil,
+					[]interface{}{phoneParam}, jsonResponse("Phone number details", phoneInfo),
+					http.StatusServiceUnavailable, http.StatusGatewayTimeout),
+			},
+			"/format/{phone}/{country}": map[string]interface{}{
+				"get": operation("formatPhone", "Format a phone number", nil,
+					[]interface{}{
+						phoneParam,
+						pathParameter("country", "Country code"),
+						map[string]interface{}{
+							"name": "format", "in": "query", "required": false,
+							"schema": map[string]interface{}{
+								"type":    "string",
+								"enum":    []string{string(mnv.FormatE164), string(mnv.FormatInternational), string(mnv.FormatNational), string(mnv.FormatRFC3966)},
+								"default": string(mnv.FormatInternational),
+							},
+						},
+					},
+					jsonResponse("Formatted phone number", formatResponse), http.StatusUnprocessableEntity),
+			},
+			"/detect/{phone}": map[string]interface{}{
+				"get": operation("detectCountry", "Detect the country of a phone number", nil,
+					[]interface{}{phoneParam, langParam}, jsonResponse("Detected country", detectResponse)),
+			},
+			"/countries": map[string]interface{}{
+				"get": operation("listCountries", "List supported countries", nil,
+					[]interface{}{langParam}, jsonResponse("Supported countries", countriesResponse)),
+			},
+			"/countries/{code}": map[string]interface{}{
+				"get": operation("getCountry", "Get country details", nil,
+					[]interface{}{pathParameter("code", "Country code"), langParam},
+					jsonResponse("Country details", countryResponse), http.StatusNotFound),
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": g.schemas,
+		},
+	}
+
+	if info.ServerURL != "" {
+		spec["servers"] = []interface{}{map[string]interface{}{"url": info.ServerURL}}
+	}
+	return spec
+}
+
+// OpenAPIHandler отдает документ OpenAPISpec в формате JSON
+func OpenAPIHandler(info OpenAPIInfo) http.Handler {
+	document, err := json.MarshalIndent(OpenAPISpec(info), "", "  ")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			WriteProblem(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(document)
+	})
+}
+
+// operation описывает операцию; errorStatuses - коды ответов с ошибкой RFC 7807
+func operation(id, summary string, body map[string]interface{}, params []interface{},
+	ok map[string]interface{}, errorStatuses ...int) map[string]interface{} {
+	responses := map[string]interface{}{"200": ok}
+	for _, status := range errorStatuses {
+		responses[strconv.Itoa(status)] = map[string]interface{}{
+			"description": http.StatusText(status),
+			"content": map[string]interface{}{
+				ProblemContentType: map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/Problem"},
+				},
+			},
+		}
+	}
+
+	op := map[string]interface{}{
+		"operationId": id,
+		"summary":     summary,
+		"responses":   responses,
+	}
+	if body != nil {
+		op["requestBody"] = body
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	return op
+}
+
+// requestBody описывает JSON-тело запроса
+func requestBody(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"required": true,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// jsonResponse описывает успешный JSON-ответ
+func jsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// ndjsonBody описывает тело запроса NDJSON; schema - схема одной строки
+func ndjsonBody(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"required": true,
+		"content": map[string]interface{}{
+			NDJSONContentType: map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// ndjsonResponse описывает успешный ответ NDJSON; schema - схема одной строки
+func ndjsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			NDJSONContentType: map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// queryParameter описывает необязательный параметр запроса
+func queryParameter(name, description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"in":          "query",
+		"required":    false,
+		"description": description,
+		"schema":      schema,
+	}
+}
+
+// pathParameter описывает параметр пути
+func pathParameter(name, description string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"in":          "path",
+		"required":    true,
+		"description": description,
+		"schema":      map[string]interface{}{"type": "string"},
+	}
+}
+
+// problemSchema схема ошибки RFC 7807 с расширениями ValidationError.ToJSON()
+func problemSchema() map[string]interface{} {
+	str := map[string]interface{}{"type": "string"}
+	list := map[string]interface{}{"type": "array", "items": str}
+
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"type", "title", "status"},
+		"properties": map[string]interface{}{
+			"type":                str,
+			"title":               str,
+			"status":              map[string]interface{}{"type": "integer"},
+			"detail":              str,
+			"instance":            str,
+			"message":             str,
+			"phone":               str,
+			"country_code":        str,
+			"suggestions":         list,
+			"suggestion_messages": list,
+			"error_code":          map[string]interface{}{"type": "integer"},
+			"retryable":           map[string]interface{}{"type": "boolean"},
+			"invalid_params": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type":                 "object",
+					"properties":           map[string]interface{}{"name": str, "type": str, "message": str},
+					"additionalProperties": true,
+				},
+			},
+		},
+	}
+}
+
+// schemaGenerator строит схемы JSON по типам Go; именованные структуры
+// выносятся в components/schemas
+type schemaGenerator struct {
+	schemas map[string]interface{}
+}
+
+// timeType тип time.Time, сериализуемый строкой
+var timeType = reflect.TypeOf(time.Time{})
+
+// ref возвращает схему типа, регистрируя именованные структуры
+func (g *schemaGenerator) ref(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		if _, exists := g.schemas[t.Name()]; !exists {
+			g.schemas[t.Name()] = nil // защита от рекурсии
+			g.schemas[t.Name()] = g.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.ref(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.ref(t.Elem())}
+	case reflect.Struct:
+		return g.object(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// object строит схему объекта по экспортируемым полям структуры
+func (g *schemaGenerator) object(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	g.collectFields(t, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// collectFields добавляет поля структуры; поля встроенных структур без тега поднимаются на уровень выше
+func (g *schemaGenerator) collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.collectFields(embedded, properties, required)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = g.ref(field.Type)
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			*required = append(*required, name)
+		}
+	}
+}
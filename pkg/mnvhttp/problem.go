@@ -0,0 +1,134 @@
+package mnvhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// ProblemContentType тип содержимого ответа с ошибкой по RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix префикс URI типа проблемы для ошибок валидации
+const problemTypePrefix = "urn:mnv:error:"
+
+// Problem описание ошибки по RFC 7807. Поля ValidationError.ToJSON() передаются
+// как члены-расширения на верхнем уровне документа
+type Problem struct {
+	// Type URI типа проблемы
+	Type string
+
+	// Title краткое описание типа проблемы
+	Title string
+
+	// Status HTTP статус ответа
+	Status int
+
+	// Detail описание конкретной ошибки
+	Detail string
+
+	// Instance URI запроса, вызвавшего ошибку
+	Instance string
+
+	// Extensions дополнительные члены документа
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON сериализует проблему в плоский JSON-объект
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	document := make(map[string]interface{}, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		document[key] = value
+	}
+
+	problemType := p.Type
+	if problemType == "" {
+		problemType = "about:blank"
+	}
+	document["type"] = problemType
+	document["title"] = p.Title
+	document["status"] = p.Status
+	if p.Detail != "" {
+		document["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		document["instance"] = p.Instance
+	}
+
+	return json.Marshal(document)
+}
+
+// NewProblem создает проблему из ошибки. Для *mnv.ValidationError тип и расширения
+// берутся из ToJSON(). Заголовок одинаков для всех ошибок одного типа (RFC 7807),
+// подробности конкретной ошибки - в Detail; оба локализуются на язык lang
+func NewProblem(err error, status int, lang string) *Problem {
+	problem := &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	var validationErr *mnv.ValidationError
+	if !errors.As(err, &validationErr) {
+		if err != nil {
+			problem.Detail = err.Error()
+		}
+		return problem
+	}
+
+	problem.Type = problemTypePrefix + string(validationErr.Type)
+	problem.Title = problemTitle(validationErr.Type, lang)
+	problem.Detail = validationErr.GetLocalizedMessage(lang)
+	if problem.Detail == problem.Title && validationErr.Message != "" {
+		// В каталоге нет подробного сообщения: подробности есть только в исходном тексте
+		problem.Detail = validationErr.Message
+	}
+	problem.Extensions = validationErr.ToJSON()
+	problem.Extensions["suggestion_messages"] = validationErr.LocalizedSuggestions(lang)
+	return problem
+}
+
+// problemTitle возвращает заголовок типа проблемы: общее сообщение типа ошибки без параметров
+func problemTitle(errorType mnv.ErrorType, lang string) string {
+	return (&mnv.ValidationError{Type: errorType}).GetLocalizedMessage(lang)
+}
+
+// WriteProblem отправляет ошибку в формате application/problem+json.
+// Язык сообщения определяется по запросу (см. RequestLanguage)
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, err error) {
+	problem := NewProblem(err, status, RequestLanguage(r))
+	problem.Instance = requestInstance(r)
+	writeProblem(w, problem)
+}
+
+// requestInstance возвращает URI запроса для Problem.Instance: исходный URI запроса
+// к серверу, чтобы при монтировании через http.StripPrefix префикс сохранялся
+func requestInstance(r *http.Request) string {
+	if r.RequestURI != "" {
+		return r.RequestURI
+	}
+	return r.URL.RequestURI()
+}
+
+// writeProblem отправляет готовую проблему
+func writeProblem(w http.ResponseWriter, problem *Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// writeJSON отправляет ответ в формате JSON
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// RequestLanguage определяет язык ответа: параметр ?lang= имеет приоритет над Accept-Language
+func RequestLanguage(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" && mnv.IsSupportedLanguage(lang) {
+		return mnv.NormalizeLanguage(lang)
+	}
+	return mnv.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+}
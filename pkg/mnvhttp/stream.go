@@ -0,0 +1,135 @@
+package mnvhttp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// NDJSONContentType тип содержимого потоков JSON, разделенных переводом строки
+const NDJSONContentType = "application/x-ndjson"
+
+// maxStreamLineBytes максимальная длина строки потока
+const maxStreamLineBytes = 64 << 10
+
+// StreamError последнее сообщение потока, прерванного ошибкой чтения запроса
+type StreamError struct {
+	// Error описание ошибки
+	Error string `json:"error"`
+}
+
+// stream проверяет поток номеров. Тело запроса - строки NDJSON с mnv.BatchItem или номерами
+// без JSON; ответ - строки NDJSON с mnv.StreamResult по мере готовности. Параметры запроса:
+// ordered, default_region (по умолчанию - страна запроса) и stats_every (см. mnv.StreamOptions). MaxBatchSize и MaxBodyBytes
+// к потоку не применяются, длина строки ограничена 64 КиБ
+func (h *handler) stream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := &mnv.StreamOptions{
+		DefaultRegion: query.Get("default_region"),
+		Options:       h.validationOptions(),
+	}
+
+	var err error
+	if value := query.Get("ordered"); value != "" {
+		if opts.Ordered, err = strconv.ParseBool(value); err != nil {
+			WriteProblem(w, r, http.StatusBadRequest, fmt.Errorf("invalid ordered parameter: %w", err))
+			return
+		}
+	}
+	if value := query.Get("stats_every"); value != "" {
+		if opts.StatsEvery, err = strconv.Atoi(value); err != nil || opts.StatsEvery < 0 {
+			WriteProblem(w, r, http.StatusBadRequest, fmt.Errorf("invalid stats_every parameter: %q", value))
+			return
+		}
+	}
+	if opts.DefaultRegion == "" {
+		opts.DefaultRegion = h.region(r)
+	}
+
+	// Тело читается одновременно с записью ответа; сроки чтения и записи продлеваются
+	// на StreamIdleTimeout для каждой строки, поэтому длина потока не ограничена
+	rc := http.NewResponseController(w)
+	_ = rc.EnableFullDuplex()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	in := make(chan mnv.BatchItem)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(make([]byte, 0, 4096), maxStreamLineBytes)
+		for {
+			_ = rc.SetReadDeadline(time.Now().Add(h.opts.StreamIdleTimeout))
+			if !scanner.Scan() {
+				break
+			}
+
+			item, ok := parseStreamLine(scanner.Bytes())
+			if !ok {
+				continue
+			}
+			select {
+			case in <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		// При ошибке чтения поток прерывается без итоговой статистики
+		if err := scanner.Err(); err != nil {
+			readErr <- err
+			cancel()
+			return
+		}
+		close(in)
+	}()
+
+	w.Header().Set("Content-Type", NDJSONContentType)
+	w.WriteHeader(http.StatusOK)
+
+	results := mnv.ValidateStream(ctx, in, opts)
+	encoder := json.NewEncoder(w)
+	for message := range results {
+		_ = rc.SetWriteDeadline(time.Now().Add(h.opts.StreamIdleTimeout))
+		if err := encoder.Encode(message); err != nil {
+			return
+		}
+		// Сбрасываем ответ, когда готовых результатов больше нет
+		if len(results) == 0 {
+			_ = rc.Flush()
+		}
+	}
+
+	select {
+	case err := <-readErr:
+		_ = encoder.Encode(StreamError{Error: fmt.Sprintf("invalid request body: %v", err)})
+		_ = rc.Flush()
+	default:
+	}
+}
+
+// parseStreamLine разбирает строку потока: объект JSON mnv.BatchItem или номер телефона.
+// Пустые строки пропускаются
+func parseStreamLine(line []byte) (mnv.BatchItem, bool) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return mnv.BatchItem{}, false
+	}
+
+	if line[0] == '{' {
+		var item mnv.BatchItem
+		if err := json.Unmarshal(line, &item); err == nil {
+			return item, true
+		}
+	}
+	// Строка, не являющаяся объектом BatchItem, проверяется как номер и будет отклонена валидатором
+	return mnv.BatchItem{Phone: string(line)}, true
+}
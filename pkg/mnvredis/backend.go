@@ -0,0 +1,255 @@
+// Package mnvredis реализует mnv.CacheBackend поверх протокола Redis (RESP2) без внешних
+// зависимостей. Подходит для Redis, KeyDB, Valkey и других совместимых хранилищ
+package mnvredis
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+)
+
+// Значения Options по умолчанию
+const (
+	defaultAddr        = "127.0.0.1:6379"
+	defaultDialTimeout = 5 * time.Second
+	defaultIOTimeout   = 3 * time.Second
+	defaultPoolSize    = 10
+)
+
+// ErrClosed ошибка обращения к закрытому бэкенду
+var ErrClosed = errors.New("mnvredis: backend is closed")
+
+// Options настройки подключения
+type Options struct {
+	// Addr адрес сервера host:port (по умолчанию 127.0.0.1:6379)
+	Addr string
+
+	// Username имя пользователя ACL (необязательно)
+	Username string
+
+	// Password пароль; если задан, после подключения выполняется AUTH
+	Password string
+
+	// DB номер базы данных; если больше нуля, выполняется SELECT
+	DB int
+
+	// DialTimeout таймаут подключения (по умолчанию 5 секунд)
+	DialTimeout time.Duration
+
+	// IOTimeout таймаут одной команды (по умолчанию 3 секунды)
+	IOTimeout time.Duration
+
+	// PoolSize количество простаивающих соединений в пуле (по умолчанию 10)
+	PoolSize int
+}
+
+// withDefaults заполняет незаданные настройки значениями по умолчанию
+func (o Options) withDefaults() Options {
+	if o.Addr == "" {
+		o.Addr = defaultAddr
+	}
+	if o.DialTimeout <= 0 {
+		o.DialTimeout = defaultDialTimeout
+	}
+	if o.IOTimeout <= 0 {
+		o.IOTimeout = defaultIOTimeout
+	}
+	if o.PoolSize <= 0 {
+		o.PoolSize = defaultPoolSize
+	}
+	return o
+}
+
+// Backend бэкенд кеша валидации, хранящий записи в Redis
+type Backend struct {
+	opts Options
+	idle chan *conn
+
+	mu     sync.Mutex
+	closed bool
+}
+
+var _ mnv.CacheBackend = (*Backend)(nil)
+
+// New создает бэкенд. Соединения открываются при первом обращении
+func New(opts Options) *Backend {
+	opts = opts.withDefaults()
+	return &Backend{
+		opts: opts,
+		idle: make(chan *conn, opts.PoolSize),
+	}
+}
+
+// Get возвращает значение по ключу
+func (b *Backend) Get(key string) ([]byte, bool, error) {
+	reply, err := b.do("GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, ErrProtocol
+	}
+	return value, value != nil, nil
+}
+
+// MGet возвращает значения по списку ключей одной командой MGET
+func (b *Backend) MGet(keys []string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	reply, err := b.do(append([]string{"MGET"}, keys...)...)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := reply.([]interface{})
+	if !ok || len(items) != len(keys) {
+		return nil, ErrProtocol
+	}
+
+	values := make([][]byte, len(items))
+	for i, item := range items {
+		value, ok := item.([]byte)
+		if !ok {
+			return nil, ErrProtocol
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Set сохраняет значение; ttl больше нуля передается как PX в миллисекундах
+func (b *Backend) Set(key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		ms := ttl.Milliseconds()
+		if ms < 1 {
+			ms = 1
+		}
+		args = append(args, "PX", strconv.FormatInt(ms, 10))
+	}
+	_, err := b.do(args...)
+	return err
+}
+
+// Delete удаляет значение
+func (b *Backend) Delete(key string) error {
+	_, err := b.do("DEL", key)
+	return err
+}
+
+// Ping проверяет доступность сервера
+func (b *Backend) Ping() error {
+	reply, err := b.do("PING")
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("mnvredis: unexpected PING reply %v", reply)
+	}
+	return nil
+}
+
+// Close закрывает простаивающие соединения; последующие вызовы возвращают ErrClosed
+func (b *Backend) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.idle)
+	b.mu.Unlock()
+
+	for c := range b.idle {
+		c.Close()
+	}
+	return nil
+}
+
+// do выполняет команду на соединении из пула
+func (b *Backend) do(args ...string) (interface{}, error) {
+	c, err := b.get()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.do(args...)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		// Сетевая ошибка или ошибка протокола: состояние соединения неизвестно
+		c.Close()
+		return nil, err
+	}
+	b.put(c)
+	return reply, err
+}
+
+// get берет соединение из пула или открывает новое
+func (b *Backend) get() (*conn, error) {
+	b.mu.Lock()
+	closed := b.closed
+	b.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+
+	select {
+	case c, ok := <-b.idle:
+		if ok {
+			return c, nil
+		}
+		return nil, ErrClosed
+	default:
+	}
+	return b.dial()
+}
+
+// put возвращает соединение в пул или закрывает его, если пул заполнен
+func (b *Backend) put(c *conn) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		c.Close()
+		return
+	}
+	select {
+	case b.idle <- c:
+	default:
+		c.Close()
+	}
+}
+
+// dial открывает соединение и выполняет AUTH и SELECT
+func (b *Backend) dial() (*conn, error) {
+	netConn, err := net.DialTimeout("tcp", b.opts.Addr, b.opts.DialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	c := newConn(netConn, b.opts.IOTimeout)
+
+	if b.opts.Password != "" {
+		args := []string{"AUTH", b.opts.Password}
+		if b.opts.Username != "" {
+			args = []string{"AUTH", b.opts.Username, b.opts.Password}
+		}
+		if _, err := c.do(args...); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if b.opts.DB > 0 {
+		if _, err := c.do("SELECT", strconv.Itoa(b.opts.DB)); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,147 @@
+package mnvredis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// ErrProtocol ошибка разбора ответа сервера
+var ErrProtocol = errors.New("mnvredis: protocol error")
+
+// Error ошибка, возвращенная сервером (ответ RESP с префиксом '-')
+type Error string
+
+// Error реализует интерфейс error
+func (e Error) Error() string {
+	return "mnvredis: " + string(e)
+}
+
+// conn соединение с сервером и его буферы
+type conn struct {
+	netConn net.Conn
+	reader  *bufio.Reader
+	writer  *bufio.Writer
+	timeout time.Duration
+}
+
+// newConn оборачивает сетевое соединение
+func newConn(netConn net.Conn, timeout time.Duration) *conn {
+	return &conn{
+		netConn: netConn,
+		reader:  bufio.NewReader(netConn),
+		writer:  bufio.NewWriter(netConn),
+		timeout: timeout,
+	}
+}
+
+// do отправляет команду и читает ответ. Ответ сервера с ошибкой возвращается как Error
+// и не делает соединение непригодным
+func (c *conn) do(args ...string) (interface{}, error) {
+	if c.timeout > 0 {
+		if err := c.netConn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.writeCommand(args); err != nil {
+		return nil, err
+	}
+	reply, err := c.readReply()
+	if err != nil {
+		return nil, err
+	}
+	if replyErr, ok := reply.(Error); ok {
+		return nil, replyErr
+	}
+	return reply, nil
+}
+
+// writeCommand записывает команду как массив bulk-строк
+func (c *conn) writeCommand(args []string) error {
+	fmt.Fprintf(c.writer, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(c.writer, "$%d\r\n", len(arg))
+		c.writer.WriteString(arg)
+		c.writer.WriteString("\r\n")
+	}
+	return c.writer.Flush()
+}
+
+// readReply читает ответ: string для простых строк, int64 для целых, []byte для
+// bulk-строк (nil - отсутствующее значение), []interface{} для массивов, Error для ошибок
+func (c *conn) readReply() (interface{}, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, ErrProtocol
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return Error(line[1:]), nil
+	case ':':
+		n, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, ErrProtocol
+		}
+		return n, nil
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < -1 {
+			return nil, ErrProtocol
+		}
+		if size == -1 {
+			return []byte(nil), nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, data); err != nil {
+			return nil, err
+		}
+		if data[size] != '\r' || data[size+1] != '\n' {
+			return nil, ErrProtocol
+		}
+		return data[:size], nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil || count < -1 {
+			return nil, ErrProtocol
+		}
+		if count == -1 {
+			return []interface{}(nil), nil
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			if items[i], err = c.readReply(); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, ErrProtocol
+	}
+}
+
+// readLine читает строку ответа без завершающего CRLF
+func (c *conn) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", ErrProtocol
+	}
+	return line[:len(line)-2], nil
+}
+
+// Close закрывает соединение
+func (c *conn) Close() error {
+	return c.netConn.Close()
+}
@@ -0,0 +1,140 @@
+package mnv_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchValidationCountryResolution(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	response := mnv.BatchValidatePhones(&mnv.BatchValidationRequest{
+		Phones:        []string{"+79991234567", "0700 123 456"},
+		DefaultRegion: "kg",
+		Items: []mnv.BatchItem{
+			{Phone: "8 999 123 45 67", Country: "ru"},
+			{Phone: "+996700123456"},
+			{Phone: "+996700123456", Country: "kz"},
+			{Phone: "0555 123 456"},
+		},
+	})
+
+	tests := []struct {
+		valid   bool
+		country string
+	}{
+		{valid: true, country: "ru"},  // определена по номеру
+		{valid: true, country: "kg"},  // DefaultRegion
+		{valid: true, country: "ru"},  // подсказка страны
+		{valid: true, country: "kg"},  // определена по номеру
+		{valid: false, country: "kz"}, // подсказка не совпадает с номером
+		{valid: true, country: "kg"},  // DefaultRegion
+	}
+
+	require.Len(t, response.Results, len(tests))
+	for i, tt := range tests {
+		result := response.Results[i]
+		assert.Equal(t, tt.valid, result.IsValid, "item %d: %s", i, result.ErrorMessage)
+		assert.Equal(t, tt.country, result.CountryCode, "item %d", i)
+	}
+
+	assert.Equal(t, "0700 123 456", response.Results[1].OriginalNumber)
+	assert.Equal(t, "+996700123456", response.Results[1].FormattedNumber)
+	assert.Equal(t, 5, response.Stats.Valid)
+	assert.Equal(t, 1, response.Stats.Invalid)
+	assert.Equal(t, 0, response.Stats.Errors, "invalid numbers are not errors")
+	assert.Equal(t, 3, response.Stats.ByCountry["kg"])
+}
+
+func TestBatchValidationProgress(t *testing.T) {
+	phones := make([]string, 50)
+	for i := range phones {
+		phones[i] = fmt.Sprintf("+99670012%04d", i)
+	}
+
+	var calls []int
+	response := mnv.BatchValidatePhones(&mnv.BatchValidationRequest{
+		Phones:   phones,
+		Parallel: 4,
+		Progress: func(done, total int) {
+			assert.Equal(t, len(phones), total)
+			calls = append(calls, done)
+		},
+	})
+
+	require.Len(t, calls, len(phones))
+	for i, done := range calls {
+		assert.Equal(t, i+1, done)
+	}
+	assert.Equal(t, len(phones), response.Stats.Valid)
+}
+
+func TestBatchValidationBoundedWorkers(t *testing.T) {
+	phones := make([]string, 500)
+	for i := range phones {
+		phones[i] = fmt.Sprintf("+99670%07d", i)
+	}
+
+	baseline := runtime.NumGoroutine()
+	peak := 0
+	mnv.BatchValidatePhones(&mnv.BatchValidationRequest{
+		Phones:   phones,
+		Parallel: 8,
+		Progress: func(done, total int) {
+			if n := runtime.NumGoroutine(); n > peak {
+				peak = n
+			}
+		},
+	})
+
+	// Воркеры, раздача номеров и закрытие канала результатов
+	assert.LessOrEqual(t, peak-baseline, 8+2)
+}
+
+func TestBatchValidationCancel(t *testing.T) {
+	phones := make([]string, 1000)
+	for i := range phones {
+		phones[i] = "+996700123456"
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed := 0
+	response, err := mnv.BatchValidatePhonesContext(ctx, &mnv.BatchValidationRequest{
+		Phones:   phones,
+		Parallel: 2,
+		Progress: func(done, total int) {
+			processed = done
+			if done == 10 {
+				cancel()
+			}
+		},
+	})
+
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, processed, len(phones))
+}
+
+func TestBatchValidationDeadline(t *testing.T) {
+	mnv.SetPerformanceConfig(mnv.PerformanceConfig{MaxConcurrentValidations: 1})
+	defer mnv.SetPerformanceConfig(mnv.DefaultPerformanceConfig())
+
+	release, err := mnv.AcquireValidationSlot(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	_, err = mnv.BatchValidatePhonesContext(ctx, &mnv.BatchValidationRequest{Phones: []string{"+996700123456"}})
+	assert.ErrorIs(t, err, mnv.ErrTimeout)
+}
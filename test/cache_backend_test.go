@@ -0,0 +1,398 @@
+package mnv_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvredis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRESP минимальный сервер протокола Redis для тестов
+type fakeRESP struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	data     map[string]string
+	expires  map[string]time.Time
+	commands []string
+}
+
+// startFakeRESP запускает сервер на случайном порту
+func startFakeRESP(t *testing.T, password string) *fakeRESP {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeRESP{
+		listener: listener,
+		password: password,
+		data:     make(map[string]string),
+		expires:  make(map[string]time.Time),
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeRESP) addr() string {
+	return s.listener.Addr().String()
+}
+
+// commandCount возвращает количество выполненных команд name
+func (s *fakeRESP) commandCount(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, command := range s.commands {
+		if command == name {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *fakeRESP) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authed := s.password == ""
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		name := strings.ToUpper(args[0])
+
+		if name == "AUTH" {
+			if args[len(args)-1] == s.password {
+				authed = true
+				io.WriteString(conn, "+OK\r\n")
+			} else {
+				io.WriteString(conn, "-WRONGPASS invalid password\r\n")
+			}
+			continue
+		}
+		if !authed {
+			io.WriteString(conn, "-NOAUTH Authentication required\r\n")
+			continue
+		}
+		io.WriteString(conn, s.execute(name, args[1:]))
+	}
+}
+
+func (s *fakeRESP) execute(name string, args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands = append(s.commands, name)
+
+	get := func(key string) string {
+		value, ok := s.data[key]
+		if expiresAt, has := s.expires[key]; ok && has && time.Now().After(expiresAt) {
+			delete(s.data, key)
+			ok = false
+		}
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	}
+
+	switch name {
+	case "PING":
+		return "+PONG\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		return get(args[0])
+	case "MGET":
+		reply := fmt.Sprintf("*%d\r\n", len(args))
+		for _, key := range args {
+			reply += get(key)
+		}
+		return reply
+	case "SET":
+		s.data[args[0]] = args[1]
+		delete(s.expires, args[0])
+		if len(args) == 4 && strings.ToUpper(args[2]) == "PX" {
+			ms, _ := strconv.Atoi(args[3])
+			s.expires[args[0]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "DEL":
+		_, ok := s.data[args[0]]
+		delete(s.data, args[0])
+		if ok {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	default:
+		return "-ERR unknown command '" + name + "'\r\n"
+	}
+}
+
+// readCommand читает команду в виде массива bulk-строк
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+// recordingBackend запоминает ключи, записанные кешем валидации
+type recordingBackend struct {
+	*mnv.MemoryBackend
+
+	mu   sync.Mutex
+	keys []string
+}
+
+func (b *recordingBackend) Set(key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	b.keys = append(b.keys, key)
+	b.mu.Unlock()
+	return b.MemoryBackend.Set(key, value, ttl)
+}
+
+// useBackend подключает общий бэкенд на время теста при отключенном кеше процесса
+func useBackend(t *testing.T, backend mnv.CacheBackend) {
+	t.Helper()
+	mnv.SetConfig(mnv.DefaultConfig())
+	mnv.SetCacheConfig(mnv.DefaultCacheConfig())
+	mnv.SetCacheBackend(backend)
+	t.Cleanup(func() { mnv.SetCacheBackend(nil) })
+}
+
+func TestMemoryBackend(t *testing.T) {
+	backend := mnv.NewMemoryBackend(10)
+
+	require.NoError(t, backend.Set("a", []byte("1"), 0))
+	require.NoError(t, backend.Set("b", []byte("2"), 50*time.Millisecond))
+
+	value, found, err := backend.Get("a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+
+	values, err := backend.MGet([]string{"a", "missing", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), nil, []byte("2")}, values)
+
+	time.Sleep(80 * time.Millisecond)
+	_, found, _ = backend.Get("b")
+	assert.False(t, found, "entry must expire after its TTL")
+
+	require.NoError(t, backend.Delete("a"))
+	assert.Equal(t, 0, backend.Len())
+}
+
+func TestRedisBackend(t *testing.T) {
+	server := startFakeRESP(t, "secret")
+	backend := mnvredis.New(mnvredis.Options{Addr: server.addr(), Password: "secret", DB: 2})
+	defer backend.Close()
+
+	require.NoError(t, backend.Ping())
+	require.NoError(t, backend.Set("a", []byte("value\r\nwith crlf"), 0))
+	require.NoError(t, backend.Set("b", []byte("2"), 20*time.Millisecond))
+
+	value, found, err := backend.Get("a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("value\r\nwith crlf"), value)
+
+	values, err := backend.MGet([]string{"a", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value\r\nwith crlf"), nil}, values)
+
+	time.Sleep(40 * time.Millisecond)
+	_, found, err = backend.Get("b")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, backend.Delete("a"))
+	_, found, _ = backend.Get("a")
+	assert.False(t, found)
+
+	require.NoError(t, backend.Close())
+	_, _, err = backend.Get("a")
+	assert.ErrorIs(t, err, mnvredis.ErrClosed)
+}
+
+func TestRedisBackendErrors(t *testing.T) {
+	server := startFakeRESP(t, "secret")
+
+	backend := mnvredis.New(mnvredis.Options{Addr: server.addr(), Password: "wrong"})
+	defer backend.Close()
+	var replyErr mnvredis.Error
+	assert.ErrorAs(t, backend.Ping(), &replyErr)
+
+	unreachable := mnvredis.New(mnvredis.Options{Addr: "127.0.0.1:1", DialTimeout: 200 * time.Millisecond})
+	defer unreachable.Close()
+	_, _, err := unreachable.Get("a")
+	assert.Error(t, err)
+}
+
+func TestValidatePhoneSharedBackend(t *testing.T) {
+	server := startFakeRESP(t, "")
+	backend := mnvredis.New(mnvredis.Options{Addr: server.addr()})
+	defer backend.Close()
+	useBackend(t, backend)
+
+	first := mnv.ValidatePhone("+996700123456", "kg")
+	second := mnv.ValidatePhone("+996700123456", "kg")
+	assert.Equal(t, first, second)
+
+	stats := mnv.GetCacheStats()
+	assert.Equal(t, uint64(1), stats.BackendHits)
+	assert.Equal(t, uint64(1), stats.BackendMisses)
+	assert.Equal(t, uint64(0), stats.BackendErrors)
+	assert.Equal(t, 1, server.commandCount("SET"))
+
+	info := mnv.GetPhoneInfo("+996700123456")
+	assert.Equal(t, info, mnv.GetPhoneInfo("+996700123456"))
+
+	country, found := mnv.GetCountryByPhone("+996700123456")
+	assert.True(t, found)
+	assert.Equal(t, "kg", country)
+	country, _ = mnv.GetCountryByPhone("+996700123456")
+	assert.Equal(t, "kg", country)
+}
+
+func TestBatchValidateUsesMGet(t *testing.T) {
+	server := startFakeRESP(t, "")
+	backend := mnvredis.New(mnvredis.Options{Addr: server.addr()})
+	defer backend.Close()
+	useBackend(t, backend)
+
+	phones := []string{"+996700123456", "+79991234567", "invalid"}
+	request := &mnv.BatchValidationRequest{Phones: phones}
+
+	cold := mnv.BatchValidatePhones(request)
+	assert.Equal(t, 1, server.commandCount("MGET"))
+	assert.Equal(t, 0, server.commandCount("GET"), "misses must not be looked up again")
+	assert.Equal(t, len(phones), server.commandCount("SET"))
+
+	warm := mnv.BatchValidatePhones(request)
+	assert.Equal(t, 2, server.commandCount("MGET"))
+	assert.Equal(t, len(phones), server.commandCount("SET"))
+	assert.Equal(t, cold.Results, warm.Results)
+	assert.Equal(t, cold.Stats, warm.Stats)
+	assert.Equal(t, uint64(len(phones)), mnv.GetCacheStats().BackendHits)
+}
+
+func TestBackendIgnoresIncompatibleValues(t *testing.T) {
+	backend := &recordingBackend{MemoryBackend: mnv.NewMemoryBackend(100)}
+	useBackend(t, backend)
+
+	mnv.ValidatePhone("+996700123456", "kg")
+	require.Len(t, backend.keys, 1)
+	key := backend.keys[0]
+	assert.True(t, strings.HasPrefix(key, mnv.CacheKeyPrefix))
+
+	for _, raw := range []string{
+		`{"v":0,"s":"","d":{"is_valid":false}}`,
+		`{"v":1,"s":"other-shape","d":{"is_valid":false}}`,
+		`not json`,
+	} {
+		require.NoError(t, backend.MemoryBackend.Set(key, []byte(raw), 0))
+		result := mnv.ValidatePhone("+996700123456", "kg")
+		assert.True(t, result.IsValid, "incompatible entry %q must be ignored", raw)
+	}
+	assert.Equal(t, uint64(0), mnv.GetCacheStats().BackendHits)
+}
+
+func TestBackendErrorsDoNotFailValidation(t *testing.T) {
+	backend := mnvredis.New(mnvredis.Options{Addr: "127.0.0.1:1", DialTimeout: 200 * time.Millisecond})
+	defer backend.Close()
+	useBackend(t, backend)
+
+	assert.True(t, mnv.ValidatePhone("+996700123456", "kg").IsValid)
+	assert.Equal(t, uint64(2), mnv.GetCacheStats().BackendErrors)
+}
+
+func TestBackendKeyDependsOnRegistryContents(t *testing.T) {
+	backend := &recordingBackend{MemoryBackend: mnv.NewMemoryBackend(100)}
+	useBackend(t, backend)
+	defer mnv.RemoveCountry("zz")
+
+	addCountry := func() {
+		require.NoError(t, mnv.AddCountry("zz", "+999", `^\+999[0-9]{9}$`, 9, 9))
+	}
+
+	// Экземпляр, изменявший реестр иначе, но пришедший к тому же содержимому,
+	// использует те же ключи общего бэкенда
+	addCountry()
+	mnv.ValidatePhone("+999123456789", "zz")
+	version := mnv.RegistryVersion()
+	mnv.RemoveCountry("zz")
+	addCountry()
+	require.Greater(t, mnv.RegistryVersion(), version)
+
+	mnv.ClearCache()
+	assert.True(t, mnv.ValidatePhone("+999123456789", "zz").IsValid)
+	require.Len(t, backend.keys, 1)
+	assert.Equal(t, uint64(1), mnv.GetCacheStats().BackendHits)
+
+	// Другое содержимое реестра - другой ключ
+	require.NoError(t, mnv.AddCountry("zz", "+999", `^\+999[0-9]{8,9}$`, 8, 9))
+	mnv.ValidatePhone("+999123456789", "zz")
+	require.Len(t, backend.keys, 2)
+	assert.NotEqual(t, backend.keys[0], backend.keys[1])
+}
+
+func TestSetCacheBackendPurgesLocalCache(t *testing.T) {
+	enableCache(t, mnv.CacheConfig{MaxSize: 100})
+	t.Cleanup(func() { mnv.SetCacheBackend(nil) })
+
+	mnv.ValidatePhone("+996700123456", "kg")
+	require.Equal(t, 1, mnv.GetCacheStats().Size)
+
+	backend := &recordingBackend{MemoryBackend: mnv.NewMemoryBackend(100)}
+	mnv.SetCacheBackend(backend)
+	assert.Equal(t, 0, mnv.GetCacheStats().Size)
+
+	// Результат вычисляется заново и записывается в новый бэкенд
+	assert.True(t, mnv.ValidatePhone("+996700123456", "kg").IsValid)
+	assert.Len(t, backend.keys, 1)
+}
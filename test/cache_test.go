@@ -0,0 +1,182 @@
+package mnv_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enableCache включает глобальный кеш на время теста
+func enableCache(t *testing.T, cfg mnv.CacheConfig) {
+	t.Helper()
+	mnv.SetConfig(mnv.DefaultConfig())
+	cfg.Enabled = true
+	mnv.SetCacheConfig(cfg)
+	t.Cleanup(func() { mnv.SetCacheConfig(mnv.DefaultCacheConfig()) })
+}
+
+func TestResultCacheLRU(t *testing.T) {
+	cache := mnv.NewResultCache(mnv.CacheConfig{MaxSize: 2})
+	defer cache.Close()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	cache.Set("c", 3)
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "least recently used entry must be evicted")
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 2, stats.Size)
+	assert.InDelta(t, 2.0/3.0, stats.HitRate, 0.001)
+}
+
+func TestResultCacheBounded(t *testing.T) {
+	cache := mnv.NewResultCache(mnv.CacheConfig{MaxSize: 1000})
+	defer cache.Close()
+
+	for i := 0; i < 5000; i++ {
+		cache.Set(fmt.Sprintf("key-%d", i), i)
+	}
+	assert.LessOrEqual(t, cache.Len(), 1000)
+	assert.Equal(t, uint64(5000-cache.Len()), cache.Stats().Evictions)
+}
+
+func TestResultCacheTTL(t *testing.T) {
+	cache := mnv.NewResultCache(mnv.CacheConfig{MaxSize: 10, TTL: 1})
+	defer cache.Close()
+
+	cache.Set("a", 1)
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	time.Sleep(1100 * time.Millisecond)
+	cache.Set("b", 2)
+
+	assert.Equal(t, 1, cache.Cleanup())
+	_, ok = cache.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), cache.Stats().Expirations)
+}
+
+func TestResultCacheCleanupLoop(t *testing.T) {
+	cache := mnv.NewResultCache(mnv.CacheConfig{MaxSize: 10, TTL: 1, CleanupInterval: 1})
+	cache.Set("a", 1)
+
+	assert.Eventually(t, func() bool { return cache.Len() == 0 }, 3*time.Second, 100*time.Millisecond)
+
+	cache.Close()
+	cache.Close()
+}
+
+func TestResultCacheConcurrent(t *testing.T) {
+	cache := mnv.NewResultCache(mnv.CacheConfig{MaxSize: 64})
+	defer cache.Close()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprintf("%d-%d", g, i%100)
+				cache.Set(key, i)
+				cache.Get(key)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, cache.Len(), 64)
+}
+
+func TestValidatePhoneCache(t *testing.T) {
+	enableCache(t, mnv.CacheConfig{MaxSize: 100})
+
+	first := mnv.ValidatePhone("+996700123456", "kg")
+	require.True(t, first.IsValid)
+	assert.Equal(t, uint64(1), mnv.GetCacheStats().Misses)
+
+	second := mnv.ValidatePhone("+996700123456", "kg")
+	assert.Equal(t, first, second)
+	assert.Equal(t, uint64(1), mnv.GetCacheStats().Hits)
+
+	// Изменение результата не влияет на запись кеша
+	second.OriginalNumber = "changed"
+	assert.Equal(t, "+996700123456", mnv.ValidatePhone("+996700123456", "kg").OriginalNumber)
+
+	// Другие опции - другой ключ
+	opts := &mnv.ValidationOptions{ForbiddenCountries: []string{"kg"}}
+	assert.False(t, mnv.ValidatePhone("+996700123456", "kg", opts).IsValid)
+}
+
+func TestCacheInvalidatedByConfig(t *testing.T) {
+	enableCache(t, mnv.CacheConfig{MaxSize: 100})
+
+	mnv.ValidatePhone("+996700123456", "kg")
+	mnv.ValidatePhone("+996700123456", "kg")
+	require.Equal(t, uint64(1), mnv.GetCacheStats().Hits)
+
+	mnv.SetConfig(mnv.RelaxedConfig())
+	defer mnv.SetConfig(mnv.DefaultConfig())
+	mnv.ValidatePhone("+996700123456", "kg")
+
+	stats := mnv.GetCacheStats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+}
+
+func TestCacheInvalidatedByRegistry(t *testing.T) {
+	enableCache(t, mnv.CacheConfig{MaxSize: 100})
+	defer mnv.RemoveCountry("zz")
+
+	version := mnv.RegistryVersion()
+	assert.False(t, mnv.ValidatePhone("+999123456789", "zz").IsValid)
+
+	require.NoError(t, mnv.AddCountry("zz", "+999", `^\+999[0-9]{9}$`, 9, 9))
+	assert.Greater(t, mnv.RegistryVersion(), version)
+	assert.True(t, mnv.ValidatePhone("+999123456789", "zz").IsValid)
+
+	country, found := mnv.GetCountryByPhone("+999123456789")
+	assert.True(t, found)
+	assert.Equal(t, "zz", country)
+
+	mnv.RemoveCountry("zz")
+	_, found = mnv.GetCountryByPhone("+999123456789")
+	assert.False(t, found)
+}
+
+func TestGetPhoneInfoCache(t *testing.T) {
+	enableCache(t, mnv.CacheConfig{MaxSize: 100})
+
+	info := mnv.GetPhoneInfo("+996700123456")
+	info.CountryName = "changed"
+
+	cached := mnv.GetPhoneInfo("+996700123456")
+	assert.Equal(t, "Kyrgyzstan", cached.CountryName)
+	assert.GreaterOrEqual(t, mnv.GetCacheStats().Hits, uint64(1))
+
+	mnv.ClearCache()
+	assert.Equal(t, 0, mnv.GetCacheStats().Size)
+}
+
+func TestCacheDisabled(t *testing.T) {
+	mnv.SetCacheConfig(mnv.DefaultCacheConfig())
+
+	mnv.ValidatePhone("+996700123456", "kg")
+	assert.Equal(t, mnv.CacheStats{}, mnv.GetCacheStats())
+}
@@ -0,0 +1,138 @@
+package mnv_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultCatalogLanguages(t *testing.T) {
+	for _, lang := range mnv.SupportedLanguages {
+		assert.True(t, mnv.DefaultCatalog.HasLanguage(lang), lang)
+	}
+
+	assert.Equal(t, []string{"kg", "ru", "en"}, mnv.DefaultCatalog.FallbackChain("ky-KG"))
+	assert.Equal(t, []string{"kz", "ru", "en"}, mnv.DefaultCatalog.FallbackChain("kz"))
+	assert.Equal(t, []string{"ru", "en"}, mnv.DefaultCatalog.FallbackChain("ru"))
+	assert.Equal(t, []string{"fr", "en"}, mnv.DefaultCatalog.FallbackChain("fr"))
+}
+
+func TestLocalizedLengthMessage(t *testing.T) {
+	err := mnv.NewInvalidLengthError("+9967001234", "kg", 9, 7)
+
+	tests := []struct {
+		lang     string
+		expected string
+	}{
+		{"en", "Invalid phone number length: expected 9 digits, got 7"},
+		{"ru", "Неверная длина номера телефона: ожидалось 9 цифр, получено 7"},
+		{"kg", "Телефон номерунун узундугу туура эмес: 9 сан күтүлгөн, 7 берилди"},
+		{"kz", "Телефон нөмірінің ұзындығы қате: 9 цифр күтілді, 7 берілді"},
+		{"uz", "Telefon raqami uzunligi noto‘g‘ri: 9 ta raqam kutilgan, 7 ta kiritildi"},
+		{"de", "Invalid phone number length: expected 9 digits, got 7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			assert.Equal(t, tt.expected, err.GetLocalizedMessage(tt.lang))
+		})
+	}
+}
+
+func TestCatalogPluralRules(t *testing.T) {
+	tests := []struct {
+		expected int
+		message  string
+	}{
+		{1, "Неверная длина номера телефона: ожидалась 1 цифра, получено 3"},
+		{3, "Неверная длина номера телефона: ожидалось 3 цифры, получено 3"},
+		{11, "Неверная длина номера телефона: ожидалось 11 цифр, получено 3"},
+		{12, "Неверная длина номера телефона: ожидалось 12 цифр, получено 3"},
+		{22, "Неверная длина номера телефона: ожидалось 22 цифры, получено 3"},
+	}
+
+	for _, tt := range tests {
+		err := mnv.NewInvalidLengthError("+123", "kg", tt.expected, 3)
+		assert.Equal(t, tt.message, err.GetLocalizedMessage("ru"))
+	}
+
+	err := mnv.NewInvalidLengthError("+123", "kg", 1, 3)
+	assert.Equal(t, "Invalid phone number length: expected 1 digit, got 3", err.GetLocalizedMessage("en"))
+}
+
+func TestLocalizedMessageParameters(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	err := mnv.NewInvalidPrefixError("+77011234567", "kg", "+996", "+7")
+	assert.Equal(t, "Неверный код страны: для Кыргызстан ожидается +996, получено +7", err.GetLocalizedMessage("ru"))
+	assert.Equal(t, "Invalid country prefix: expected +996 for Kyrgyzstan, got +7", err.GetLocalizedMessage("en"))
+
+	err = mnv.NewInvalidCharactersError("+996abc", []rune{'a', 'b'})
+	assert.Equal(t, "Номер содержит недопустимые символы: 'a', 'b'", err.GetLocalizedMessage("ru"))
+
+	// Ошибка без параметров использует общее сообщение
+	assert.Equal(t, "Номер должен начинаться со знака +", mnv.NewMissingPlusError("996700123456").GetLocalizedMessage("ru"))
+}
+
+func TestLocalizedSuggestions(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	err := mnv.NewUnsupportedCountryError("Kyrgystan")
+	assert.Contains(t, err.LocalizedSuggestions("ru"), "Возможно, вы имели в виду 'kg'?")
+	assert.Contains(t, err.LocalizedSuggestions("kg"), "Балким, 'kg' дегениңиз?")
+
+	err = mnv.NewMissingPlusError("996700123456")
+	assert.Equal(t, []string{"Возможно, вы имели в виду +996700123456?"}, err.LocalizedSuggestions("ru"))
+	assert.Equal(t,
+		"Номер должен начинаться со знака +. Возможно, вы имели в виду +996700123456?",
+		err.Localize("ru"))
+
+	data := err.ToLocalizedJSON("ky")
+	assert.Equal(t, "Номер + белгиси менен башталышы керек", data["message"])
+	assert.Equal(t, "kg", data["language"])
+	assert.Equal(t, []string{"+996700123456"}, data["suggestions"])
+}
+
+func TestCatalogLoadFileAndFallback(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "de.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"language": "de",
+		"fallback": ["en"],
+		"messages": {
+			"invalid_length.exact": {"one": "Erwartet {expected} Ziffer", "other": "Erwartet {expected} Ziffern, erhalten {actual}"}
+		}
+	}`), 0o600))
+
+	catalog := mnv.NewCatalog()
+	require.NoError(t, catalog.LoadFS(os.DirFS("../pkg/mnv"), "locales/*.json"))
+	require.NoError(t, catalog.LoadFile(path))
+	assert.Contains(t, catalog.Languages(), "de")
+
+	err := mnv.NewInvalidLengthError("+9967001234", "kg", 9, 7)
+	assert.Equal(t, "Erwartet 9 Ziffern, erhalten 7", err.LocalizedMessage(catalog, "de"))
+
+	// Отсутствующее сообщение берется из цепочки отката
+	assert.Equal(t, "Phone number must start with + sign", mnv.NewMissingPlusError("1").LocalizedMessage(catalog, "de"))
+
+	// Цепочку можно переопределить
+	catalog.SetFallback("de", "ru")
+	assert.Equal(t, "Номер должен начинаться со знака +", mnv.NewMissingPlusError("1").LocalizedMessage(catalog, "de"))
+
+	message, ok := catalog.Translate("de", "no.such.key", nil)
+	assert.False(t, ok)
+	assert.Empty(t, message)
+}
+
+func TestCatalogLoadErrors(t *testing.T) {
+	catalog := mnv.NewCatalog()
+
+	assert.Error(t, catalog.LoadJSON([]byte(`{`)))
+	assert.Error(t, catalog.LoadJSON([]byte(`{"messages": {"a": "b"}}`)))
+	assert.Error(t, catalog.LoadJSON([]byte(`{"language": "de", "messages": {"a": {"one": "b"}}}`)))
+	assert.Error(t, catalog.LoadFile(filepath.Join(t.TempDir(), "missing.json")))
+}
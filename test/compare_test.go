@@ -0,0 +1,41 @@
+package mnv_test
+
+import (
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		region   string
+		expected mnv.MatchType
+	}{
+		{"Same E.164", "+996700123456", "+996700123456", "", mnv.MatchTypeExact},
+		{"Formatting differences", "+996 700 123 456", "00996-700-123-456", "", mnv.MatchTypeExact},
+		{"National with region", "+996700123456", "0700 123 456", "kg", mnv.MatchTypeExact},
+		{"NSN with region", "+996700123456", "700123456", "kg", mnv.MatchTypeExact},
+		{"National without region", "+996700123456", "0700 123 456", "", mnv.MatchTypeNSN},
+		{"NSN without region", "+996700123456", "700123456", "", mnv.MatchTypeNSN},
+		{"Both national", "0700123456", "700 123 456", "", mnv.MatchTypeNSN},
+		{"Short NSN", "+996700123456", "123456", "", mnv.MatchTypeShortNSN},
+		{"Different country", "+996700123456", "+998700123456", "", mnv.MatchTypeNone},
+		{"Different number", "+996700123456", "+996700123457", "", mnv.MatchTypeNone},
+		{"Same extension", "+996700123456 ext. 12", "+996700123456 x12", "", mnv.MatchTypeExact},
+		{"Extension on one side", "+996700123456 ext. 12", "+996700123456", "", mnv.MatchTypeShortNSN},
+		{"Extension on one side without country", "0700123456 ext. 12", "+996700123456", "", mnv.MatchTypeShortNSN},
+		{"Different extensions", "+996700123456 ext. 12", "+996700123456 #13", "", mnv.MatchTypeNone},
+		{"Not a number", "hello", "+996700123456", "", mnv.MatchTypeNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mnv.CompareNumbers(tt.a, tt.b, tt.region))
+			assert.Equal(t, tt.expected, mnv.CompareNumbers(tt.b, tt.a, tt.region))
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package mnv_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setPerformance устанавливает конфигурацию производительности на время теста
+func setPerformance(t *testing.T, maxConcurrent int, timeout time.Duration) {
+	t.Helper()
+	mnv.SetConfig(mnv.DefaultConfig())
+	mnv.SetPerformanceConfig(mnv.PerformanceConfig{
+		MaxConcurrentValidations: maxConcurrent,
+		ValidationTimeout:        timeout,
+	})
+	t.Cleanup(func() { mnv.SetPerformanceConfig(mnv.DefaultPerformanceConfig()) })
+}
+
+// holdSlot занимает единственное место лимитера до конца теста или вызова возвращенной функции
+func holdSlot(t *testing.T) func() {
+	t.Helper()
+	release, err := mnv.AcquireValidationSlot(context.Background())
+	require.NoError(t, err)
+
+	var once sync.Once
+	free := func() { once.Do(release) }
+	t.Cleanup(free)
+	return free
+}
+
+func TestValidatePhoneContext(t *testing.T) {
+	setPerformance(t, 10, time.Second)
+
+	result, err := mnv.ValidatePhoneContext(context.Background(), "+996700123456", "kg")
+	require.NoError(t, err)
+	assert.True(t, result.IsValid)
+
+	info, err := mnv.GetPhoneInfoContext(context.Background(), "+996700123456")
+	require.NoError(t, err)
+	assert.Equal(t, "kg", info.CountryCode)
+}
+
+func TestValidatePhoneContextErrors(t *testing.T) {
+	setPerformance(t, 1, 50*time.Millisecond)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		hold      bool
+		errorType mnv.ErrorType
+		target    error
+	}{
+		{name: "canceled", ctx: canceled, target: context.Canceled},
+		{name: "deadline exceeded", ctx: expired, errorType: mnv.ErrorTypeTimeout, target: context.DeadlineExceeded},
+		{name: "overloaded", ctx: context.Background(), hold: true, errorType: mnv.ErrorTypeOverloaded, target: mnv.ErrOverloaded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.hold {
+				defer holdSlot(t)()
+			}
+
+			result, err := mnv.ValidatePhoneContext(tt.ctx, "+996700123456", "kg")
+			assert.Nil(t, result)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.target)
+
+			ve, ok := mnv.GetValidationError(err)
+			if tt.errorType == "" {
+				assert.False(t, ok, "cancellation is returned as is")
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.errorType, ve.Type)
+			assert.Equal(t, "+996700123456", ve.Phone)
+			assert.True(t, ve.IsRetryable())
+		})
+	}
+
+	// После освобождения места валидация снова проходит
+	_, err := mnv.ValidatePhoneContext(context.Background(), "+996700123456", "kg")
+	assert.NoError(t, err)
+}
+
+func TestValidatePhoneContextWaitsForSlot(t *testing.T) {
+	setPerformance(t, 1, time.Second)
+	release := holdSlot(t)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		release()
+	}()
+
+	result, err := mnv.ValidatePhoneContext(context.Background(), "+996700123456", "kg")
+	require.NoError(t, err)
+	assert.True(t, result.IsValid)
+}
+
+func TestTimeoutErrorLocalized(t *testing.T) {
+	err := mnv.NewTimeoutError("+996700123456", "kg", context.DeadlineExceeded)
+
+	assert.True(t, errors.Is(err, mnv.ErrTimeout))
+	assert.False(t, errors.Is(err, mnv.ErrOverloaded))
+	assert.True(t, errors.Is(mnv.NewOverloadedError("", "", 1), mnv.ErrOverloaded))
+
+	// Остальные ошибки-образцы сравниваются по идентичности, а не по типу
+	assert.True(t, errors.Is(mnv.ErrInvalidFormat, mnv.ErrInvalidFormat))
+	assert.False(t, errors.Is(mnv.NewInvalidFormatError("+996123", "kg"), mnv.ErrInvalidFormat))
+	assert.False(t, errors.Is(mnv.NewUnsupportedCountryError("xx"), mnv.ErrUnsupportedCountry))
+	assert.Equal(t, 1009, err.ErrorCode())
+	assert.Equal(t, "Превышено время валидации", err.GetLocalizedMessage("ru"))
+	assert.Equal(t, 1010, mnv.NewOverloadedError("", "", 1).ErrorCode())
+}
+
+func TestBatchValidatePhonesContext(t *testing.T) {
+	setPerformance(t, 10, time.Second)
+	request := &mnv.BatchValidationRequest{Phones: []string{"+996700123456", "+79991234567", "invalid"}}
+
+	response, err := mnv.BatchValidatePhonesContext(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, mnv.BatchValidatePhones(request).Results, response.Results)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	response, err = mnv.BatchValidatePhonesContext(canceled, request)
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBatchValidatePhonesContextOverloaded(t *testing.T) {
+	setPerformance(t, 1, 20*time.Millisecond)
+	holdSlot(t)
+
+	response, err := mnv.BatchValidatePhonesContext(context.Background(), &mnv.BatchValidationRequest{
+		Phones: []string{"+996700123456", "+79991234567"},
+	})
+	require.NoError(t, err)
+	for _, result := range response.Results {
+		assert.False(t, result.IsValid)
+		assert.Equal(t, mnv.ErrorTypeOverloaded, result.ErrorType)
+	}
+	assert.Equal(t, 2, response.Stats.Errors)
+	assert.Equal(t, 0, response.Stats.Invalid)
+}
+
+func TestHTTPHandlerOverloaded(t *testing.T) {
+	setPerformance(t, 1, 20*time.Millisecond)
+	holdSlot(t)
+	h := mnvhttp.NewHandler(mnvhttp.Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"phone": "+996700123456"}`))
+	rec, body := serveJSON(t, h, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, mnvhttp.ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "urn:mnv:error:overloaded", body["type"])
+	assert.Equal(t, float64(1010), body["error_code"])
+}
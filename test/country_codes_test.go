@@ -1 +1 @@
-package test
+package mnv_test
@@ -0,0 +1,78 @@
+package mnv_test
+
+import (
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountryName(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	tests := []struct {
+		code     string
+		lang     string
+		expected string
+	}{
+		{"kg", "en", "Kyrgyzstan"},
+		{"kg", "ru", "Кыргызстан"},
+		{"ru", "kg", "Орусия"},
+		{"ru", "ky", "Орусия"},
+		{"uk", "kz", "Ұлыбритания"},
+		{"GB", "kk-KZ", "Ұлыбритания"},
+		{"us", "uz", "AQSH"},
+		{"de", "ru-RU", "Германия"},
+		{"kg", "fr", "Kyrgyzstan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code+"_"+tt.lang, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mnv.CountryName(tt.code, tt.lang))
+		})
+	}
+
+	// Каждая страна переведена на все поддерживаемые языки
+	for _, code := range mnv.GetSupportedCountries() {
+		for _, lang := range mnv.SupportedLanguages {
+			assert.NotEmpty(t, mnv.CountryNames[code][lang], "%s/%s", code, lang)
+		}
+	}
+}
+
+func TestLocalizedCountryInfo(t *testing.T) {
+	info, exists := mnv.LocalizedCountryInfo("kz", "ru")
+	require.True(t, exists)
+	assert.Equal(t, "Казахстан", info.CountryName)
+	assert.Equal(t, "Мобильные номера: Казахстан (6xx, 7xx)", info.Description)
+	assert.Equal(t, "+7", info.Prefix)
+
+	info, exists = mnv.LocalizedCountryInfo("kg", "en")
+	require.True(t, exists)
+	assert.Equal(t, "Kyrgyzstan mobile numbers", info.Description)
+
+	_, exists = mnv.LocalizedCountryInfo("xx", "ru")
+	assert.False(t, exists)
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"", "en"},
+		{"ru-RU,ru;q=0.9,en;q=0.8", "ru"},
+		{"ky-KG", "kg"},
+		{"fr-FR,kk;q=0.7,en;q=0.5", "kz"},
+		{"en;q=0.3,uz;q=0.9", "uz"},
+		{"de,fr", "en"},
+		{"ru;q=0", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mnv.ParseAcceptLanguage(tt.header))
+		})
+	}
+}
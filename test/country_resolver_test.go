@@ -0,0 +1,93 @@
+package mnv_test
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCountry(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	tests := []struct {
+		input    string
+		expected string
+		found    bool
+	}{
+		{"kg", "kg", true},
+		{"KG", "kg", true},
+		{"KGZ", "kg", true},
+		{"417", "kg", true},
+		{"Kyrgyzstan", "kg", true},
+		{"  kyrgyz republic ", "kg", true},
+		{"Кыргызстан", "kg", true},
+		{"Киргизия", "kg", true},
+		{"GB", "uk", true},
+		{"GBR", "uk", true},
+		{"Great Britain", "uk", true},
+		{"Улуу Британия", "uk", true},
+		{"U.S.A.", "us", true},
+		{"Соединённые Штаты", "us", true},
+		{"36", "au", true},
+		{"Kyrgystan", "kg", true},
+		{"Узбекистон", "uz", true},
+		{"Germny", "de", true},
+		{"xx", "", false},
+		{"", "", false},
+		{"Atlantis", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, found := mnv.ResolveCountry(tt.input)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
+
+func TestValidatePhoneResolvesCountry(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	for _, country := range []string{"kg", "KGZ", "417", "Kyrgyzstan", "Кыргызстан"} {
+		result := mnv.ValidatePhone("+996700123456", country)
+		assert.True(t, result.IsValid, country)
+		assert.Equal(t, "kg", result.CountryCode, country)
+	}
+
+	result := mnv.ValidatePhone("+447911123456", "GB")
+	assert.True(t, result.IsValid)
+	assert.Equal(t, "uk", result.CountryCode)
+
+	// В режиме чувствительности к регистру код используется как есть
+	mnv.SetConfig(mnv.StrictConfig())
+	defer mnv.SetConfig(mnv.DefaultConfig())
+	assert.False(t, mnv.ValidatePhone("+996700123456", "KGZ").IsValid)
+}
+
+func TestPhoneByCountryResolvesCountry(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	validate := validator.New()
+	require.NoError(t, mnv.RegisterValidators(validate))
+
+	type User struct {
+		Phone   string `validate:"required,phonebycountry"`
+		Country string `validate:"required"`
+	}
+
+	assert.NoError(t, validate.Struct(User{Phone: "+996700123456", Country: "Kyrgyzstan"}))
+	assert.NoError(t, validate.Struct(User{Phone: "+447911123456", Country: "GB"}))
+	assert.Error(t, validate.Struct(User{Phone: "+996700123456", Country: "GBR"}))
+}
+
+func TestUnsupportedCountrySuggestions(t *testing.T) {
+	err := mnv.NewUnsupportedCountryError("Kyrgystan")
+	assert.Contains(t, err.Suggestions, "Did you mean 'kg'?")
+
+	err = mnv.NewUnsupportedCountryError("kgg")
+	assert.Contains(t, err.Suggestions, "Did you mean 'kg'?")
+}
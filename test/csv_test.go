@@ -0,0 +1,196 @@
+package mnv_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enrichCSV обрабатывает таблицу и возвращает разобранный результат
+func enrichCSV(t *testing.T, input string, opts *mnv.CSVOptions) ([][]string, *mnv.BatchStats, string) {
+	t.Helper()
+
+	var out bytes.Buffer
+	stats, err := mnv.EnrichCSV(context.Background(), strings.NewReader(input), &out, opts)
+	require.NoError(t, err)
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(out.Bytes(), []byte("\xEF\xBB\xBF"))))
+	reader.FieldsPerRecord = -1
+	if opts != nil && opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	rows, err := reader.ReadAll()
+	require.NoError(t, err)
+	return rows, stats, out.String()
+}
+
+func TestEnrichCSV(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	input := "\xEF\xBB\xBFName,Mobile,Region\n" +
+		"\"Doe, John\",+996700123456,\n" +
+		"Anna,8 999 123 45 67,ru\n" +
+		"Bob,0555 123 456,\n" +
+		"\"Quoted \"\"Q\"\"\",+99670012345,kg\n"
+
+	rows, stats, raw := enrichCSV(t, input, &mnv.CSVOptions{
+		PhoneColumn:   "mobile",
+		CountryColumn: "3",
+		DefaultRegion: "kg",
+		Parallel:      2,
+	})
+
+	assert.True(t, strings.HasPrefix(raw, "\xEF\xBB\xBFName,"), "BOM is preserved")
+	require.Len(t, rows, 5)
+	assert.Equal(t, append([]string{"Name", "Mobile", "Region"}, mnv.EnrichColumns...), rows[0])
+
+	column := func(row []string, name string) string {
+		for i, header := range rows[0] {
+			if header == name {
+				return row[i]
+			}
+		}
+		t.Fatalf("no column %s", name)
+		return ""
+	}
+
+	tests := []struct {
+		name      string
+		valid     string
+		e164      string
+		national  string
+		country   string
+		errorCode string
+	}{
+		{name: "Doe, John", valid: "true", e164: "+996700123456", national: "0700 123 456", country: "kg"},
+		{name: "Anna", valid: "true", e164: "+79991234567", country: "ru"},
+		{name: "Bob", valid: "true", e164: "+996555123456", national: "0555 123 456", country: "kg"},
+		{name: `Quoted "Q"`, valid: "false", country: "kg", errorCode: "1002"},
+	}
+	for i, tt := range tests {
+		row := rows[i+1]
+		assert.Equal(t, tt.name, row[0], "row order and quoted fields are kept")
+		assert.Equal(t, tt.valid, column(row, mnv.ColumnIsValid), tt.name)
+		assert.Equal(t, tt.e164, column(row, mnv.ColumnE164), tt.name)
+		assert.Equal(t, tt.country, column(row, mnv.ColumnCountry), tt.name)
+		assert.Equal(t, tt.errorCode, column(row, mnv.ColumnErrorCode), tt.name)
+		if tt.national != "" {
+			assert.Equal(t, tt.national, column(row, mnv.ColumnNational), tt.name)
+		}
+	}
+	assert.Equal(t, "mobile", column(rows[1], mnv.ColumnType))
+	assert.NotEmpty(t, column(rows[4], mnv.ColumnSuggestion))
+
+	assert.Equal(t, 4, stats.Total)
+	assert.Equal(t, 3, stats.Valid)
+	assert.Equal(t, 1, stats.Invalid)
+}
+
+func TestEnrichCSVTSVWithoutHeader(t *testing.T) {
+	input := "a\t+79991234567\nb\n"
+
+	rows, stats, raw := enrichCSV(t, input, &mnv.CSVOptions{
+		Comma:       '\t',
+		NoHeader:    true,
+		PhoneColumn: "2",
+		Columns:     []string{mnv.ColumnIsValid, mnv.ColumnE164},
+	})
+
+	assert.False(t, strings.HasPrefix(raw, "\xEF\xBB\xBF"))
+	assert.Equal(t, [][]string{
+		{"a", "+79991234567", "true", "+79991234567"},
+		{"b", "", "false", ""}, // короткая строка дополняется до ширины таблицы
+	}, rows)
+	assert.Equal(t, 2, stats.Total)
+}
+
+func TestEnrichCSVDefaultPhoneColumn(t *testing.T) {
+	var results []string
+	rows, _, _ := enrichCSV(t, "id,Phone\n1,+996700123456\n", &mnv.CSVOptions{
+		Columns:  []string{mnv.ColumnIsValid},
+		OnResult: func(result *mnv.ValidationResult) { results = append(results, result.FormattedNumber) },
+	})
+	assert.Equal(t, []string{"1", "+996700123456", "true"}, rows[1])
+	assert.Equal(t, []string{"+996700123456"}, results)
+
+	// Пустая таблица
+	rows, stats, _ := enrichCSV(t, "", nil)
+	assert.Empty(t, rows)
+	assert.Equal(t, 0, stats.Total)
+}
+
+func TestEnrichCSVLargeKeepsOrder(t *testing.T) {
+	var input strings.Builder
+	input.WriteString("n,phone\n")
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&input, "%d,+99670%07d\n", i, i)
+	}
+
+	rows, stats, _ := enrichCSV(t, input.String(), &mnv.CSVOptions{Columns: []string{mnv.ColumnE164}, Parallel: 8})
+	require.Len(t, rows, 2001)
+	for i, row := range rows[1:] {
+		require.Equal(t, fmt.Sprint(i), row[0])
+		require.Equal(t, row[1], row[2])
+	}
+	assert.Equal(t, 2000, stats.Valid)
+}
+
+func TestEnrichCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  *mnv.CSVOptions
+		err   string
+	}{
+		{name: "phone column not found", input: "id,name\n1,a\n", err: "phone column"},
+		{name: "unknown column name", input: "phone\n1\n", opts: &mnv.CSVOptions{CountryColumn: "region"}, err: `"region" not found`},
+		{name: "invalid column number", input: "phone\n1\n", opts: &mnv.CSVOptions{PhoneColumn: "0"}, err: "numbers start at 1"},
+		{name: "phone column out of range", input: "name,phone\na,1\n", opts: &mnv.CSVOptions{PhoneColumn: "5"}, err: "out of range: the table has 2 columns"},
+		{name: "country column out of range", input: "+996700123456\tkg\n", opts: &mnv.CSVOptions{Comma: '\t', NoHeader: true, CountryColumn: "3"}, err: "country column"},
+		{name: "unknown enrichment column", input: "phone\n1\n", opts: &mnv.CSVOptions{Columns: []string{"operator"}}, err: `"operator"`},
+		{name: "malformed quotes", input: "phone\n+996700123456\n\"+7999\"1234567\n", err: "line 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			stats, err := mnv.EnrichCSV(context.Background(), strings.NewReader(tt.input), &out, tt.opts)
+			assert.Nil(t, stats)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	// Ошибку выбора колонки можно отличить от ошибок чтения
+	_, err := mnv.EnrichCSV(context.Background(), strings.NewReader("name,phone\na,1\n"), io.Discard, &mnv.CSVOptions{CountryColumn: "region"})
+	var columnErr *mnv.CSVColumnError
+	require.ErrorAs(t, err, &columnErr)
+	assert.Equal(t, "country", columnErr.Column)
+}
+
+func TestColumnValue(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	result := mnv.ValidatePhone("+996700123456", "kg")
+
+	for column, expected := range map[string]string{
+		mnv.ColumnIsValid: "true",
+		mnv.ColumnE164:    "+996700123456",
+		mnv.ColumnCountry: "kg",
+		mnv.ColumnType:    "mobile",
+	} {
+		value, ok := mnv.ColumnValue(result, column)
+		assert.True(t, ok, column)
+		assert.Equal(t, expected, value, column)
+	}
+
+	_, ok := mnv.ColumnValue(result, "operator")
+	assert.False(t, ok)
+}
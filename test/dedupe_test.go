@@ -0,0 +1,147 @@
+package mnv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicate(t *testing.T) {
+	phones := []string{
+		"+996 700 123 456",
+		"0700123456",
+		"abc",
+		"996700123456",
+		"+79991234567",
+		"(0700) 12-34-56",
+	}
+
+	clusters, unmatched, err := mnv.Deduplicate(phones, mnv.DedupeOptions{DefaultRegion: "kg"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []mnv.DedupeCluster{
+		{Representative: "+996700123456", Match: mnv.MatchTypeExact, Indices: []int{0, 1, 3, 5}},
+		{Representative: "+79991234567", Match: mnv.MatchTypeExact, Indices: []int{4}},
+	}, clusters)
+	assert.Equal(t, []int{2}, unmatched)
+}
+
+func TestDeduplicateExtensions(t *testing.T) {
+	phones := []string{"+996700123456;ext=12", "0700123456 ext 13", "0700123456", "+996700123456 x12"}
+
+	tests := []struct {
+		name       string
+		extensions bool
+		expected   []mnv.DedupeCluster
+	}{
+		{
+			name: "ignored",
+			expected: []mnv.DedupeCluster{
+				{Representative: "+996700123456", Match: mnv.MatchTypeExact, Indices: []int{0, 1, 2, 3}},
+			},
+		},
+		{
+			name:       "distinct",
+			extensions: true,
+			expected: []mnv.DedupeCluster{
+				{Representative: "+996700123456;ext=12", Match: mnv.MatchTypeExact, Indices: []int{0, 3}},
+				{Representative: "+996700123456;ext=13", Match: mnv.MatchTypeExact, Indices: []int{1}},
+				{Representative: "+996700123456", Match: mnv.MatchTypeExact, Indices: []int{2}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusters, _, err := mnv.Deduplicate(phones, mnv.DedupeOptions{DefaultRegion: "kg", Extensions: tt.extensions})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, clusters)
+		})
+	}
+
+	// С учетом добавочных номеров уровень группы совпадает с CompareNumbers для любой пары записей
+	clusters, _, err := mnv.Deduplicate(phones, mnv.DedupeOptions{DefaultRegion: "kg", Extensions: true})
+	require.NoError(t, err)
+	for i, cluster := range clusters {
+		for _, a := range cluster.Indices {
+			for _, b := range cluster.Indices {
+				assert.Equal(t, cluster.Match, mnv.CompareNumbers(phones[a], phones[b], "kg"))
+			}
+		}
+		for _, other := range clusters[i+1:] {
+			assert.NotEqual(t, mnv.MatchTypeExact, mnv.CompareNumbers(phones[cluster.Indices[0]], phones[other.Indices[0]], "kg"))
+		}
+	}
+}
+
+func TestDeduplicateNSNLevel(t *testing.T) {
+	// Без страны по умолчанию национальные номера не сравниваются на уровне Exact
+	phones := []string{"0700123456", "+996700123456", "700123456"}
+	clusters, _, err := mnv.Deduplicate(phones, mnv.DedupeOptions{})
+	require.NoError(t, err)
+	assert.Len(t, clusters, 3)
+
+	clusters, _, err = mnv.Deduplicate(phones, mnv.DedupeOptions{Level: mnv.MatchTypeNSN})
+	require.NoError(t, err)
+	assert.Equal(t, []mnv.DedupeCluster{
+		{Representative: "+996700123456", Match: mnv.MatchTypeNSN, Indices: []int{0, 1, 2}},
+	}, clusters)
+	for _, index := range clusters[0].Indices[1:] {
+		assert.Equal(t, mnv.MatchTypeNSN, mnv.CompareNumbers(phones[0], phones[index], ""), "levels agree with CompareNumbers")
+	}
+
+	// Национальный номер совпадает с номерами двух стран: объединяются только номера без страны
+	phones = []string{"0700123456", "+996700123456", "+7700123456", "700123456"}
+	clusters, _, err = mnv.Deduplicate(phones, mnv.DedupeOptions{Level: mnv.MatchTypeNSN})
+	require.NoError(t, err)
+	assert.Equal(t, []mnv.DedupeCluster{
+		{Representative: "700123456", Match: mnv.MatchTypeNSN, Indices: []int{0, 3}},
+		{Representative: "+996700123456", Match: mnv.MatchTypeExact, Indices: []int{1}},
+		{Representative: "+7700123456", Match: mnv.MatchTypeExact, Indices: []int{2}},
+	}, clusters)
+}
+
+func TestDeduplicatorAddItem(t *testing.T) {
+	dedupe, err := mnv.NewDeduplicator(mnv.DedupeOptions{DefaultRegion: "kg"})
+	require.NoError(t, err)
+
+	index, ok := dedupe.AddItem(mnv.BatchItem{Phone: "8 999 123 45 67", Country: "ru"})
+	assert.True(t, ok)
+	assert.Equal(t, 0, index)
+	dedupe.Add("+79991234567")
+	_, ok = dedupe.Add("")
+	assert.False(t, ok)
+
+	assert.Equal(t, 3, dedupe.Len())
+	assert.Equal(t, []int{2}, dedupe.Unmatched())
+	assert.Equal(t, []mnv.DedupeCluster{
+		{Representative: "+79991234567", Match: mnv.MatchTypeExact, Indices: []int{0, 1}},
+	}, dedupe.Clusters())
+}
+
+func TestDeduplicatorInvalidLevel(t *testing.T) {
+	for _, level := range []mnv.MatchType{mnv.MatchTypeShortNSN, mnv.MatchTypeNone, "exact"} {
+		_, err := mnv.NewDeduplicator(mnv.DedupeOptions{Level: level})
+		assert.Error(t, err, level)
+	}
+}
+
+func TestCSVItems(t *testing.T) {
+	input := "\xEF\xBB\xBFname;tel;country\nAnna;0700123456;kg\nBob;+79991234567\n"
+
+	var items []mnv.BatchItem
+	for item, err := range mnv.CSVItems(strings.NewReader(input), &mnv.CSVOptions{Comma: ';', PhoneColumn: "tel", CountryColumn: "country"}) {
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+	assert.Equal(t, []mnv.BatchItem{{Phone: "0700123456", Country: "kg"}, {Phone: "+79991234567"}}, items)
+
+	var lastErr error
+	for _, err := range mnv.CSVItems(strings.NewReader("id\n1\n"), nil) {
+		lastErr = err
+	}
+	assert.ErrorContains(t, lastErr, "phone column")
+}
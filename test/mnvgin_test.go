@@ -0,0 +1,273 @@
+package mnv_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvgin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGinEngine создает движок Gin с маршрутами mnvgin под /api/v1
+func newGinEngine(t *testing.T, opts mnvgin.Options) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	require.NoError(t, mnvgin.RegisterValidator())
+
+	engine := gin.New()
+	mnvgin.Register(engine.Group("/api/v1"), opts)
+	return engine
+}
+
+func TestGinRegisterRoutes(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	engine := newGinEngine(t, mnvgin.Options{})
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodPost, "/api/v1/validate", `{"phone":"+996700123456"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/validate/batch", `{"phones":["+996700123456"]}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/phone/+996700123456/info", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/format/+996700123456/kg", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/detect/+996700123456", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/countries", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/countries/kg", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/countries/xx", "", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/config", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/config/presets", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rec, _ := serveJSON(t, engine, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestGinRegisterReadOnlyByDefault(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	engine := newGinEngine(t, mnvgin.Options{})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPut, "/api/v1/config", strings.NewReader(`{}`)),
+		httptest.NewRequest(http.MethodPut, "/api/v1/config/preset/strict", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/countries", strings.NewReader(`{}`)),
+		httptest.NewRequest(http.MethodDelete, "/api/v1/countries/kg", nil),
+	} {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code, req.Method+" "+req.URL.Path)
+	}
+
+	_, exists := mnv.GetCountryInfo("kg")
+	assert.True(t, exists)
+}
+
+func TestGinRegisterAdmin(t *testing.T) {
+	defer mnv.SetConfig(mnv.DefaultConfig())
+	engine := newGinEngine(t, mnvgin.Options{Admin: true})
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodPut, "/api/v1/config", `{"allow_dashes":true}`, http.StatusOK},
+		{http.MethodPut, "/api/v1/config/preset/default", "", http.StatusOK},
+		{http.MethodDelete, "/api/v1/countries/xx", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rec, _ := serveJSON(t, engine, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestGinBatchPolicy(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	engine := newGinEngine(t, mnvgin.Options{
+		ValidationOptions: &mnv.ValidationOptions{AllowedCountries: []string{"kg"}},
+	})
+
+	// Опции запроса не отменяют ограничения сервера
+	for _, body := range []string{
+		`{"phones":["+79991234567"]}`,
+		`{"phones":["+79991234567"],"options":{}}`,
+		`{"phones":["+79991234567"],"options":{"allowed_countries":["ru"]}}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/validate/batch", strings.NewReader(body))
+		rec, response := serveJSON(t, engine, req)
+
+		require.Equal(t, http.StatusOK, rec.Code, body)
+		stats := response["stats"].(map[string]interface{})
+		assert.Equal(t, float64(0), stats["valid"], body)
+	}
+}
+
+func TestGinValidateWithRegionHeader(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	engine := newGinEngine(t, mnvgin.Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/validate", strings.NewReader(`{"phone":"0700 123 456"}`))
+	req.Header.Set(mnvgin.DefaultRegionHeader, "Kyrgyzstan")
+
+	rec, body := serveJSON(t, engine, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, true, body["is_valid"])
+	assert.Equal(t, "kg", body["country_code"])
+	assert.Equal(t, "0700 123 456", body["original_number"])
+}
+
+func TestGinBatchAndStreamWithRegionHeader(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	engine := newGinEngine(t, mnvgin.Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/validate/batch", strings.NewReader(`{"phones":["0700123456"]}`))
+	req.Header.Set(mnvgin.DefaultRegionHeader, "kg")
+	rec, body := serveJSON(t, engine, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, float64(1), body["stats"].(map[string]interface{})["valid"])
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/validate/stream?ordered=true", strings.NewReader("0700123456\n+79991234567\n"))
+	req.Header.Set(mnvgin.DefaultRegionHeader, "kg")
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"is_valid":true`)
+	assert.Contains(t, lines[2], `"final":true`)
+}
+
+func TestGinOverloaded(t *testing.T) {
+	setPerformance(t, 1, 20*time.Millisecond)
+	holdSlot(t)
+	engine := newGinEngine(t, mnvgin.Options{})
+
+	// Маршруты Gin соблюдают лимит одновременных проверок так же, как mnvhttp
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/validate", strings.NewReader(`{"phone":"+996700123456"}`))
+	rec, body := serveJSON(t, engine, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "urn:mnv:error:overloaded", body["type"])
+	assert.Equal(t, "/api/v1/validate", body["instance"])
+
+	rec, _ = serveJSON(t, engine, httptest.NewRequest(http.MethodGet, "/api/v1/phone/+996700123456/info", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	// В пакетной проверке перегрузка возвращается как ошибка номера
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/validate/batch", strings.NewReader(`{"phones":["+996700123456"]}`))
+	rec, body = serveJSON(t, engine, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	result := body["results"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, string(mnv.ErrorTypeOverloaded), result["error_type"])
+}
+
+func TestGinProblemResponse(t *testing.T) {
+	engine := newGinEngine(t, mnvgin.Options{MaxBatchSize: 1})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/validate/batch", strings.NewReader(`{"phones":["+1","+2"]}`))
+	rec, body := serveJSON(t, engine, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "/api/v1/validate/batch", body["instance"])
+}
+
+func TestGinBind(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	gin.SetMode(gin.TestMode)
+	require.NoError(t, mnvgin.RegisterValidator())
+
+	type signup struct {
+		Name  string `json:"name" binding:"required"`
+		Phone string `json:"phone" binding:"required,kg" mnv:"normalize,e164"`
+	}
+
+	engine := gin.New()
+	engine.POST("/signup", func(c *gin.Context) {
+		var req signup
+		if !mnvgin.Bind(c, &req) {
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"name":"Aibek","phone":"+996 700 123 456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec, body := serveJSON(t, engine, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "+996700123456", body["phone"])
+
+	req = httptest.NewRequest(http.MethodPost, "/signup?lang=ru", strings.NewReader(`{"phone":"996700123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec, body = serveJSON(t, engine, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "ru", body["language"])
+
+	errs := body["errors"].([]interface{})
+	require.Len(t, errs, 2)
+
+	nameErr := errs[0].(map[string]interface{})
+	assert.Equal(t, "Name", nameErr["field"])
+	assert.Equal(t, "required", nameErr["tag"])
+
+	phoneErr := errs[1].(map[string]interface{})
+	assert.Equal(t, "Phone", phoneErr["field"])
+	assert.Equal(t, "kg", phoneErr["tag"])
+	assert.Equal(t, "ru", phoneErr["language"])
+	assert.Contains(t, phoneErr["suggestions"], "+996700123456")
+}
+
+func TestGinBindPhoneByCountry(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	gin.SetMode(gin.TestMode)
+	require.NoError(t, mnvgin.RegisterValidator())
+
+	type contact struct {
+		Region string `json:"region"`
+		Phone  string `json:"phone" binding:"phonebycountry=Region"`
+	}
+	type profile struct {
+		Country  string    `json:"country"`
+		Phone    string    `json:"phone" binding:"phonebycountry"`
+		Contacts []contact `json:"contacts" binding:"dive"`
+	}
+
+	engine := gin.New()
+	engine.POST("/profile", func(c *gin.Context) {
+		var req profile
+		if !mnvgin.Bind(c, &req) {
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+
+	// Ошибка описывает страну из поля структуры, а не из заголовка запроса
+	body := `{"country":"Russia","phone":"+996700123456","contacts":[{"region":"kg","phone":"+996700123456"},{"region":"kz","phone":"+79991234567"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/profile", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(mnvgin.DefaultRegionHeader, "kg")
+	rec, response := serveJSON(t, engine, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	errs := response["errors"].([]interface{})
+	require.Len(t, errs, 2)
+	assert.Equal(t, "Phone", errs[0].(map[string]interface{})["field"])
+	assert.Equal(t, "ru", errs[0].(map[string]interface{})["country_code"])
+	assert.Equal(t, "Phone", errs[1].(map[string]interface{})["field"])
+	assert.Equal(t, "kz", errs[1].(map[string]interface{})["country_code"])
+}
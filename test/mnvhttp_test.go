@@ -0,0 +1,319 @@
+package mnv_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveJSON выполняет запрос к обработчику и декодирует JSON-ответ
+func serveJSON(t *testing.T, h http.Handler, req *http.Request) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body), rec.Body.String())
+	return rec, body
+}
+
+func TestHTTPHandlerValidate(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.NewHandler(mnvhttp.Options{})
+
+	tests := []struct {
+		name    string
+		body    string
+		valid   bool
+		country string
+	}{
+		{"with country", `{"phone":"+996700123456","country":"kg"}`, true, "kg"},
+		{"detected", `{"phone":"+79991234567"}`, true, "ru"},
+		{"invalid", `{"phone":"+996123","country":"kg"}`, false, "kg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(tt.body))
+			rec, body := serveJSON(t, h, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.valid, body["is_valid"])
+			assert.Equal(t, tt.country, body["country_code"])
+		})
+	}
+}
+
+func TestHTTPHandlerValidateWithInfo(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.NewHandler(mnvhttp.Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"phone":"+996700123456","return_info":true}`))
+	_, body := serveJSON(t, h, req)
+
+	info, ok := body["info"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "mobile", info["type"])
+}
+
+func TestHTTPHandlerBadRequest(t *testing.T) {
+	h := mnvhttp.NewHandler(mnvhttp.Options{MaxBatchSize: 2})
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+	}{
+		{"malformed json", "/validate", `{`, http.StatusBadRequest},
+		{"missing phone", "/validate", `{}`, http.StatusBadRequest},
+		{"empty batch", "/validate/batch", `{"phones":[]}`, http.StatusBadRequest},
+		{"batch too large", "/validate/batch", `{"phones":["+1","+2","+3"]}`, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			rec, body := serveJSON(t, h, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, mnvhttp.ProblemContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, float64(tt.status), body["status"])
+			assert.Equal(t, tt.path, body["instance"])
+		})
+	}
+}
+
+func TestHTTPHandlerBatch(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.NewHandler(mnvhttp.Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/validate/batch", strings.NewReader(`{"phones":["+996700123456","+996123"]}`))
+	rec, body := serveJSON(t, h, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	stats := body["stats"].(map[string]interface{})
+	assert.Equal(t, float64(2), stats["total"])
+	assert.Equal(t, float64(1), stats["valid"])
+}
+
+func TestHTTPHandlerBatchPolicy(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.NewHandler(mnvhttp.Options{
+		ValidationOptions: &mnv.ValidationOptions{AllowedCountries: []string{"kg"}},
+	})
+
+	// Опции запроса не отменяют ограничения сервера
+	for _, body := range []string{
+		`{"phones":["+79991234567"]}`,
+		`{"phones":["+79991234567"],"options":{}}`,
+		`{"phones":["+79991234567"],"options":{"allowed_countries":["ru"]}}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/validate/batch", strings.NewReader(body))
+		rec, response := serveJSON(t, h, req)
+
+		require.Equal(t, http.StatusOK, rec.Code, body)
+		stats := response["stats"].(map[string]interface{})
+		assert.Equal(t, float64(0), stats["valid"], body)
+	}
+}
+
+func TestHTTPHandlerGetEndpoints(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.NewHandler(mnvhttp.Options{})
+
+	tests := []struct {
+		path  string
+		key   string
+		value interface{}
+	}{
+		{"/phone/+996700123456/info", "country_code", "kg"},
+		{"/format/+996700123456/kg", "formatted", "+996 700 123 456"},
+		{"/format/+996700123456/kg?format=national", "formatted", "0700 123 456"},
+		{"/detect/+79991234567", "country", "ru"},
+		{"/detect/+79991234567?lang=ru", "country_name", "Россия"},
+		{"/countries/kg", "country_code", "kg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec, body := serveJSON(t, h, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.value, body[tt.key])
+		})
+	}
+}
+
+func TestHTTPHandlerCountries(t *testing.T) {
+	h := mnvhttp.NewHandler(mnvhttp.Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/countries", nil)
+	req.Header.Set("Accept-Language", "ru-RU,ru;q=0.9")
+	rec, body := serveJSON(t, h, req)
+
+	assert.Equal(t, "ru", rec.Header().Get("Content-Language"))
+	assert.Equal(t, float64(len(mnv.GetSupportedCountries())), body["count"])
+
+	rec, body = serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/countries/xx", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "urn:mnv:error:unsupported_country", body["type"])
+}
+
+func TestHTTPHandlerFormatError(t *testing.T) {
+	h := mnvhttp.NewHandler(mnvhttp.Options{})
+
+	rec, body := serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/format/+996700123456/kg?format=fancy", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "urn:mnv:error:invalid_format", body["type"])
+	assert.Equal(t, float64(1001), body["error_code"])
+}
+
+// okHandler отвечает 200 и возвращает результаты проверки Middleware
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	results := mnvhttp.Results(r)
+	numbers := make(map[string]string, len(results))
+	for name, result := range results {
+		numbers[name] = result.FormattedNumber
+	}
+	_ = json.NewEncoder(w).Encode(numbers)
+})
+
+func TestHTTPMiddlewareQuery(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.Middleware(mnvhttp.MiddlewareConfig{QueryParams: []string{"phone"}, Region: "kg"})(okHandler)
+
+	rec, body := serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/?phone=0700123456", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "+996700123456", body["phone"])
+
+	rec, body = serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, body)
+
+	rec, body = serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/?phone=12", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	params := body["invalid_params"].([]interface{})
+	require.Len(t, params, 1)
+	assert.Equal(t, "phone", params[0].(map[string]interface{})["name"])
+}
+
+func TestHTTPMiddlewareForm(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.Middleware(mnvhttp.MiddlewareConfig{
+		FormFields:   []string{"phone"},
+		RegionHeader: "X-Region",
+		Required:     true,
+	})(okHandler)
+
+	form := url.Values{"phone": {"8 999 123 45 67"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Region", "ru")
+
+	rec, body := serveJSON(t, h, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "+79991234567", body["phone"])
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(""))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec, _ = serveJSON(t, h, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestHTTPMiddlewareJSON(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	var downstreamBody string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		downstreamBody = string(data)
+		okHandler(w, r)
+	})
+
+	h := mnvhttp.Middleware(mnvhttp.MiddlewareConfig{
+		JSONPaths: []string{"contact.phone", "backups"},
+	})(next)
+
+	payload := `{"contact":{"phone":"+996700123456"},"backups":["+79991234567"]}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec, body := serveJSON(t, h, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "+996700123456", body["contact.phone"])
+	assert.Equal(t, "+79991234567", body["backups[0]"])
+	assert.Equal(t, payload, downstreamBody)
+
+	req = httptest.NewRequest(http.MethodPost, "/?lang=ru", strings.NewReader(`{"contact":{"phone":42},"backups":["+996123"]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec, body = serveJSON(t, h, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, mnvhttp.ProblemContentType, rec.Header().Get("Content-Type"))
+	params := body["invalid_params"].([]interface{})
+	require.Len(t, params, 2)
+	assert.Equal(t, "contact.phone", params[0].(map[string]interface{})["name"])
+	assert.Equal(t, "backups[0]", params[1].(map[string]interface{})["name"])
+	assert.Equal(t, "ru", params[1].(map[string]interface{})["language"])
+
+	// То же тело с другим типом содержимого не проходит без проверки
+	invalid := `{"contact":{"phone":"+996123"}}`
+	for _, contentType := range []string{"text/plain", "application/x-www-form-urlencoded"} {
+		req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(invalid))
+		req.Header.Set("Content-Type", contentType)
+		rec, body = serveJSON(t, h, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code, contentType)
+		assert.Equal(t, mnvhttp.ProblemContentType, rec.Header().Get("Content-Type"))
+	}
+
+	// Тело без Content-Type разбирается как JSON, пустое тело не содержит номеров
+	rec, _ = serveJSON(t, h, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(invalid)))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(""))
+	req.Header.Set("Content-Type", "text/plain")
+	rec, _ = serveJSON(t, h, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHTTPProblemTitle(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.Middleware(mnvhttp.MiddlewareConfig{QueryParams: []string{"phone"}, Region: "kg"})(okHandler)
+
+	// Заголовок одинаков для всех ошибок одного типа, подробности - в detail
+	_, first := serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/?phone=%2B9967001234", nil))
+	_, second := serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/?phone=%2B99670012345678", nil))
+	assert.Equal(t, "urn:mnv:error:invalid_length", first["type"])
+	assert.Equal(t, "Invalid phone number length", first["title"])
+	assert.Equal(t, first["title"], second["title"])
+	assert.Equal(t, "Invalid phone number length: expected 9 digits, got 7", first["detail"])
+	assert.NotEqual(t, first["detail"], second["detail"])
+
+	_, localized := serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/?phone=%2B9967001234&lang=ru", nil))
+	assert.Equal(t, "Неверная длина номера телефона", localized["title"])
+}
+
+func TestHTTPMiddlewareCountryPolicy(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.Middleware(mnvhttp.MiddlewareConfig{
+		QueryParams: []string{"phone"},
+		Options:     &mnv.ValidationOptions{AllowedCountries: []string{"central_asia"}},
+	})(okHandler)
+
+	rec, _ := serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/?phone=%2B996700123456", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec, body := serveJSON(t, h, httptest.NewRequest(http.MethodGet, "/?phone=%2B79991234567", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "urn:mnv:error:country_not_allowed", body["type"])
+}
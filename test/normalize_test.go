@@ -0,0 +1,162 @@
+package mnv_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatNumber(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	tests := []struct {
+		phone    string
+		region   string
+		format   mnv.NumberFormat
+		expected string
+	}{
+		{"+996 700 123 456", "", mnv.FormatE164, "+996700123456"},
+		{"0700 123456", "kg", mnv.FormatE164, "+996700123456"},
+		{"+996700123456", "", mnv.FormatInternational, "+996 700 123 456"},
+		{"+996700123456", "", mnv.FormatNational, "0700 123 456"},
+		{"+79991234567", "", mnv.FormatNational, "8 999 123 4567"},
+		{"+79991234567", "", mnv.FormatInternational, "+7 999 123 4567"},
+		{"+14165551234", "ca", mnv.FormatNational, "1 416 555 1234"},
+		{"+34612345678", "", mnv.FormatNational, "612 345 678"},
+		{"+99362123456", "", mnv.FormatInternational, "+993 62 123 456"},
+		{"+996700123456", "", mnv.FormatRFC3966, "tel:+996-700-123-456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format)+"_"+tt.phone, func(t *testing.T) {
+			formatted, err := mnv.FormatNumber(tt.phone, tt.region, tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, formatted)
+		})
+	}
+
+	_, err := mnv.FormatNumber("not a phone", "kg", mnv.FormatE164)
+	assert.Error(t, err)
+	_, err = mnv.FormatNumber("+996700123456", "", mnv.NumberFormat("pretty"))
+	assert.Error(t, err)
+
+	// E.164 проверяется по метаданным страны, а не только по длине 7-15 цифр
+	var validationErr *mnv.ValidationError
+	_, err = mnv.FormatNumber("+9967001234", "", mnv.FormatE164)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, mnv.ErrorTypeInvalidLength, validationErr.Type)
+	_, err = mnv.FormatNumber("07001234", "kg", mnv.FormatE164)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, mnv.ErrorTypeInvalidLength, validationErr.Type)
+	_, err = mnv.FormatNumber("+999123456789", "", mnv.FormatE164)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, mnv.ErrorTypeInvalidFormat, validationErr.Type)
+}
+
+type normalizedAddress struct {
+	Phone string `mnv:"normalize,international"`
+}
+
+type normalizedContact struct {
+	Name    string
+	Phone   string  `mnv:"normalize,e164"`
+	Local   string  `mnv:"normalize,national,region=kg"`
+	Work    *string `mnv:"normalize,e164,region_field=Country"`
+	Country string
+	Extra   []string          `mnv:"normalize,e164,region=ru"`
+	Tagged  map[string]string `mnv:"normalize,e164"`
+	Legacy  sql.NullString    `mnv:"normalize,e164"`
+	Address normalizedAddress
+	Offices []normalizedAddress
+	Branch  map[string]normalizedAddress
+	Parent  *normalizedContact
+	Ignored string
+	secret  string `mnv:"normalize,e164"`
+}
+
+func TestNormalizeStruct(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	work := "0 700 123 456"
+	contact := &normalizedContact{
+		Name:    "Азамат",
+		Phone:   "+996 700 123 456",
+		Local:   "+996-700-123-456",
+		Work:    &work,
+		Country: "Kyrgyzstan",
+		Extra:   []string{"8 (999) 123-45-67", ""},
+		Tagged:  map[string]string{"home": "00996700123456"},
+		Legacy:  sql.NullString{String: "+7 999 123 45 67", Valid: true},
+		Address: normalizedAddress{Phone: "+996700123456"},
+		Offices: []normalizedAddress{{Phone: "+79991234567"}},
+		Branch:  map[string]normalizedAddress{"osh": {Phone: "+996 555 123 456"}},
+		Parent:  &normalizedContact{Phone: "+7 (999) 123-45-67"},
+		Ignored: "+996 700 123 456",
+		secret:  "+996 700 123 456",
+	}
+	contact.Parent.Parent = contact // циклы не приводят к бесконечному обходу
+
+	require.NoError(t, mnv.NormalizeStruct(contact))
+
+	assert.Equal(t, "+996700123456", contact.Phone)
+	assert.Equal(t, "0700 123 456", contact.Local)
+	assert.Equal(t, "+996700123456", *contact.Work)
+	assert.Equal(t, []string{"+79991234567", ""}, contact.Extra)
+	assert.Equal(t, "+996700123456", contact.Tagged["home"])
+	assert.Equal(t, sql.NullString{String: "+79991234567", Valid: true}, contact.Legacy)
+	assert.Equal(t, "+996 700 123 456", contact.Address.Phone)
+	assert.Equal(t, "+7 999 123 4567", contact.Offices[0].Phone)
+	assert.Equal(t, "+996 555 123 456", contact.Branch["osh"].Phone)
+	assert.Equal(t, "+79991234567", contact.Parent.Phone)
+	assert.Equal(t, "+996 700 123 456", contact.Ignored)
+	assert.Equal(t, "+996 700 123 456", contact.secret)
+}
+
+func TestNormalizeStructAggregatesErrors(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	contact := &normalizedContact{
+		Phone:   "abc",
+		Local:   "+996 700 123 456",
+		Offices: []normalizedAddress{{Phone: "+996700123456"}, {Phone: "12"}},
+		Extra:   []string{"+7 999 123 45"},
+	}
+
+	err := mnv.NormalizeStruct(contact)
+	require.Error(t, err)
+
+	var normalizeErr *mnv.NormalizeError
+	require.True(t, errors.As(err, &normalizeErr))
+	require.Len(t, normalizeErr.Fields, 3)
+	assert.Equal(t, "Phone", normalizeErr.Fields[0].Field)
+	assert.Equal(t, "abc", normalizeErr.Fields[0].Value)
+	assert.Equal(t, "Extra[0]", normalizeErr.Fields[1].Field)
+	assert.Equal(t, "Offices[1].Phone", normalizeErr.Fields[2].Field)
+	assert.Equal(t, []string{"+7 999 123 45"}, contact.Extra, "numbers that fail country metadata are not rewritten")
+
+	// Корректные поля нормализуются, несмотря на ошибки в других
+	assert.Equal(t, "0700 123 456", contact.Local)
+	assert.Equal(t, "+996 700 123 456", contact.Offices[0].Phone)
+
+	var validationErr *mnv.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
+
+func TestNormalizeStructInvalidInput(t *testing.T) {
+	assert.Error(t, mnv.NormalizeStruct(normalizedContact{}))
+	assert.Error(t, mnv.NormalizeStruct((*normalizedContact)(nil)))
+
+	type badTag struct {
+		Phone string `mnv:"normalize,pretty"`
+	}
+	assert.Error(t, mnv.NormalizeStruct(&badTag{Phone: "+996700123456"}))
+
+	type badType struct {
+		Phone int `mnv:"normalize,e164"`
+	}
+	assert.Error(t, mnv.NormalizeStruct(&badType{Phone: 1}))
+}
@@ -0,0 +1,55 @@
+package mnv_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnvhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPISpec(t *testing.T) {
+	rec := httptest.NewRecorder()
+	mnvhttp.OpenAPIHandler(mnvhttp.OpenAPIInfo{ServerURL: "/api/v1"}).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var spec struct {
+		OpenAPI    string                            `json:"openapi"`
+		Servers    []map[string]string               `json:"servers"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{} `json:"properties"`
+				Required   []string                          `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+	assert.Equal(t, "/api/v1", spec.Servers[0]["url"])
+
+	for _, path := range []string{"/validate", "/validate/batch", "/validate/stream", "/phone/{phone}/info", "/format/{phone}/{country}", "/detect/{phone}", "/countries", "/countries/{code}"} {
+		assert.Contains(t, spec.Paths, path)
+	}
+
+	schemas := spec.Components.Schemas
+	for _, name := range []string{"ValidationResult", "PhoneInfo", "BatchValidationResponse", "Problem"} {
+		assert.Contains(t, schemas, name)
+	}
+
+	assert.Equal(t, "boolean", schemas["ValidationResult"].Properties["is_valid"]["type"])
+	assert.Contains(t, schemas["ValidationResult"].Required, "is_valid")
+	assert.NotContains(t, schemas["ValidationResult"].Required, "country_code")
+	assert.Equal(t, "#/components/schemas/CarrierInfo", schemas["PhoneInfo"].Properties["carrier"]["$ref"])
+	assert.Equal(t, "array", schemas["BatchValidationResponse"].Properties["results"]["type"])
+
+	// Встроенный ValidationResult поднимается в ValidateResponse
+	assert.Contains(t, schemas["ValidateResponse"].Properties, "formatted_number")
+	assert.Contains(t, schemas["ValidateResponse"].Properties, "info")
+	assert.Contains(t, schemas["Problem"].Properties, "error_code")
+}
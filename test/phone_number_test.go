@@ -0,0 +1,179 @@
+package mnv_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	tests := []struct {
+		phone    string
+		region   string
+		expected string
+		country  string
+	}{
+		{"+996 700 123 456", "", "+996700123456", "kg"},
+		{"0700 123 456", "kg", "+996700123456", "kg"},
+		{"8 (999) 123-45-67", "ru", "+79991234567", "ru"},
+		{"+1 (212) 555-1234", "", "+12125551234", "us"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			number, err := mnv.ParsePhoneNumber(tt.phone, tt.region)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, number.E164())
+			assert.Equal(t, tt.country, number.Country())
+			assert.False(t, number.IsZero())
+		})
+	}
+}
+
+func TestParsePhoneNumberInvalid(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	for _, phone := range []string{"+996123", "abc", "+999123456789"} {
+		t.Run(phone, func(t *testing.T) {
+			_, err := mnv.ParsePhoneNumber(phone, "")
+			require.Error(t, err)
+
+			var validationErr *mnv.ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, phone, validationErr.Phone)
+		})
+	}
+}
+
+func TestPhoneNumberJSON(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	type request struct {
+		Phone    mnv.PhoneNumber  `json:"phone"`
+		Backup   mnv.PhoneNumber  `json:"backup"`
+		Optional *mnv.PhoneNumber `json:"optional,omitempty"`
+	}
+
+	var req request
+	err := json.Unmarshal([]byte(`{"phone":"+996 700 123 456","backup":null}`), &req)
+	require.NoError(t, err)
+	assert.Equal(t, "+996700123456", req.Phone.E164())
+	assert.True(t, req.Backup.IsZero())
+
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"phone":"+996700123456","backup":null}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"phone":"+996123"}`), &req)
+	var validationErr *mnv.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+
+	err = json.Unmarshal([]byte(`{"phone":996700123456}`), &req)
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, mnv.ErrorTypeInvalidFormat, validationErr.Type)
+}
+
+func TestPhoneNumberText(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	var number mnv.PhoneNumber
+	require.NoError(t, number.UnmarshalText([]byte("+7 999 123-45-67")))
+	assert.Equal(t, "ru", number.Country())
+
+	text, err := number.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "+79991234567", string(text))
+
+	require.NoError(t, number.UnmarshalText(nil))
+	assert.True(t, number.IsZero())
+}
+
+func TestPhoneNumberSQL(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	var number mnv.PhoneNumber
+	require.NoError(t, number.Scan([]byte("+996700123456")))
+	value, err := number.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "+996700123456", value)
+
+	require.NoError(t, number.Scan(nil))
+	value, err = number.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	assert.Error(t, number.Scan(42))
+	assert.Error(t, number.Scan("+996123"))
+}
+
+func TestPhoneNumberYAML(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	var number mnv.PhoneNumber
+	err := number.UnmarshalYAML(func(v interface{}) error {
+		*(v.(*string)) = "+996 700 123 456"
+		return nil
+	})
+	require.NoError(t, err)
+
+	out, err := number.MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, "+996700123456", out)
+}
+
+func TestPhoneNumberFormat(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	number := mnv.MustParsePhoneNumber("+996700123456", "")
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%s", "+996700123456"},
+		{"%v", "+996700123456"},
+		{"%q", `"+996700123456"`},
+		{"%i", "+996 700 123 456"},
+		{"%n", "0700 123 456"},
+		{"%t", "tel:+996-700-123-456"},
+		{"%16s", "   +996700123456"},
+		{"%-16s|", "+996700123456   |"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fmt.Sprintf(tt.format, number))
+		})
+	}
+
+	assert.Equal(t, "+996 700 123 456", number.Formatted(mnv.FormatInternational))
+	assert.Equal(t, "", mnv.PhoneNumber{}.Formatted(mnv.FormatNational))
+}
+
+func TestValidationResultToError(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	assert.Nil(t, mnv.ValidatePhone("+996700123456", "kg").ToError())
+
+	err := mnv.ValidatePhone("+996700123456", "ru").ToError()
+	require.NotNil(t, err)
+	assert.Equal(t, mnv.ErrorTypeInvalidPrefix, err.Type)
+	assert.Equal(t, "Invalid country prefix: expected +7 for Russia, got +996", err.GetLocalizedMessage("en"))
+
+	err = mnv.ValidatePhone("+9967001234", "kg").ToError()
+	require.NotNil(t, err)
+	assert.Equal(t, mnv.ErrorTypeInvalidLength, err.Type)
+	assert.Equal(t, map[string]interface{}{"expected": 9, "actual": 7, "count": 9}, err.Params)
+	assert.Equal(t, "Invalid phone number length: expected 9 digits, got 7", err.GetLocalizedMessage("en"))
+
+	err = mnv.ValidatePhone("+996700123456", "", &mnv.ValidationOptions{ForbiddenCountries: []string{"kg"}}).ToError()
+	require.NotNil(t, err)
+	assert.Equal(t, mnv.ErrorTypeCountryNotAllowed, err.Type)
+}
@@ -0,0 +1,182 @@
+package mnv_test
+
+import (
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePhoneDetectsType(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	tests := []struct {
+		phone    string
+		country  string
+		expected mnv.PhoneType
+	}{
+		{"+996700123456", "kg", mnv.PhoneTypeMobile},
+		{"+996312123456", "kg", mnv.PhoneTypeLandline},
+		{"+79991234567", "ru", mnv.PhoneTypeMobile},
+		{"+74951234567", "ru", mnv.PhoneTypeLandline},
+		{"+78001234567", "ru", mnv.PhoneTypeTollFree},
+		{"+78091234567", "ru", mnv.PhoneTypePremium},
+		{"+77011234567", "kz", mnv.PhoneTypeMobile},
+		{"+77172123456", "kz", mnv.PhoneTypeLandline},
+		{"+14155552671", "us", mnv.PhoneTypeMobile},
+		{"+18881234567", "us", mnv.PhoneTypeTollFree},
+		{"+19001234567", "ca", mnv.PhoneTypePremium},
+		{"+5511912345678", "br", mnv.PhoneTypeMobile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			result := mnv.ValidatePhone(tt.phone, tt.country)
+			require.True(t, result.IsValid, result.ErrorMessage)
+			assert.Equal(t, tt.expected, result.Type)
+			assert.Empty(t, result.ErrorType)
+		})
+	}
+}
+
+func TestValidatePhoneTypeFilters(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	smsOnly := &mnv.ValidationOptions{AllowedTypes: []mnv.PhoneType{mnv.PhoneTypeMobile}}
+	noPaid := &mnv.ValidationOptions{ForbiddenTypes: []mnv.PhoneType{mnv.PhoneTypePremium, mnv.PhoneTypeTollFree}}
+
+	assert.True(t, mnv.ValidatePhone("+79991234567", "ru", smsOnly).IsValid)
+
+	result := mnv.ValidatePhone("+74951234567", "ru", smsOnly)
+	assert.False(t, result.IsValid)
+	assert.Equal(t, mnv.ErrorTypeTypeNotAllowed, result.ErrorType)
+	assert.Equal(t, mnv.PhoneTypeLandline, result.Type)
+	assert.Empty(t, result.FormattedNumber)
+
+	assert.True(t, mnv.ValidatePhone("+74951234567", "ru", noPaid).IsValid)
+	assert.False(t, mnv.ValidatePhone("+78001234567", "ru", noPaid).IsValid)
+	assert.False(t, mnv.ValidatePhone("+19001234567", "us", noPaid).IsValid)
+
+	// Номер с неизвестным типом не проходит фильтр разрешенных типов
+	result = mnv.ValidatePhone("+76001234567", "kz", smsOnly)
+	assert.False(t, result.IsValid)
+	assert.Equal(t, mnv.PhoneTypeUnknown, result.Type)
+
+	// Ошибки других видов также сообщают тип ошибки
+	assert.Equal(t, mnv.ErrorTypeUnsupportedCountry, mnv.ValidatePhone("+996700123456", "xx").ErrorType)
+	assert.Equal(t, mnv.ErrorTypeInvalidLength, mnv.ValidatePhone("+99670012", "kg").ErrorType)
+}
+
+func TestMobileTagIsTypeAware(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	validate := newTagValidator(t)
+
+	assert.NoError(t, validate.Var("+996700123456", "mobile"))
+	assert.NoError(t, validate.Var("+79991234567", "mobile"))
+	assert.Error(t, validate.Var("+74951234567", "mobile"))
+	assert.Error(t, validate.Var("+78001234567", "mobile"))
+	assert.NoError(t, validate.Var("+74951234567", "phone"))
+	assert.NoError(t, validate.Var("+74951234567", "phone_type=landline"))
+}
+
+func TestTypeNotAllowedError(t *testing.T) {
+	err := mnv.NewTypeNotAllowedError("+78001234567", "ru", mnv.PhoneTypeTollFree)
+
+	assert.Equal(t, 1007, err.ErrorCode())
+	assert.False(t, err.IsRetryable())
+	assert.Equal(t, "Phone number type is not allowed: toll-free", err.GetLocalizedMessage("en"))
+	assert.Equal(t, "Тип номера телефона не разрешен: бесплатный", err.GetLocalizedMessage("ru"))
+	assert.Equal(t, "мобилдик", mnv.PhoneTypeName(mnv.PhoneTypeMobile, "kg"))
+}
+
+func TestCustomCountryNumberRanges(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	defer mnv.RemoveCountry("zz")
+
+	require.NoError(t, mnv.AddCustomCountry(&mnv.CustomCountry{
+		Code:      "zz",
+		Name:      "Test",
+		Prefix:    "+999",
+		Pattern:   `^\+999[0-9]{8}$`,
+		MinLength: 8,
+		MaxLength: 8,
+		NumberRanges: map[mnv.PhoneType][]string{
+			mnv.PhoneTypeMobile:   {"5"},
+			mnv.PhoneTypeLandline: {"2"},
+		},
+	}))
+
+	assert.Equal(t, mnv.PhoneTypeMobile, mnv.ValidatePhone("+99951234567", "zz").Type)
+	assert.Equal(t, mnv.PhoneTypeLandline, mnv.ValidatePhone("+99921234567", "zz").Type)
+	assert.Equal(t, mnv.PhoneTypeUnknown, mnv.ValidatePhone("+99991234567", "zz").Type)
+}
+
+func TestRussiaAndKazakhstanNumberSpace(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	tests := []struct {
+		phone   string
+		country string
+		valid   bool
+	}{
+		// Россия: кроме мобильных 9xx принимаются стационарные 3xx/4xx/8xx и бесплатные 800
+		{"+79991234567", "ru", true},
+		{"+73812123456", "ru", true},
+		{"+74951234567", "ru", true},
+		{"+78121234567", "ru", true},
+		{"+78001234567", "ru", true},
+		{"+75001234567", "ru", false},
+		{"+71001234567", "ru", false},
+		{"+7999123456", "ru", false},
+		// Казахстан: 10 цифр национального номера, как в MinLength/MaxLength
+		{"+77011234567", "kz", true},
+		{"+77172123456", "kz", true},
+		{"+7701123456", "kz", false},
+		{"+78001234567", "kz", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone+"_"+tt.country, func(t *testing.T) {
+			assert.Equal(t, tt.valid, mnv.ValidatePhone(tt.phone, tt.country).IsValid)
+		})
+	}
+}
+
+func TestPhoneTypeWithoutNumberRanges(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	defer mnv.RemoveCountry("zy")
+
+	require.NoError(t, mnv.AddCountry("zy", "+979", `^\+979[0-9]{6}$`, 6, 6))
+
+	// Страны без диапазонов сохраняют прежний тип по умолчанию
+	assert.Equal(t, mnv.PhoneTypeMobile, mnv.ValidatePhone("+979123456", "zy").Type)
+	assert.Equal(t, mnv.PhoneTypeMobile, mnv.GetPhoneInfo("+979123456").Type)
+}
+
+func TestNumberRangeTiesAreDeterministic(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	defer mnv.RemoveCountry("zz")
+
+	require.NoError(t, mnv.AddCustomCountry(&mnv.CustomCountry{
+		Code:      "zz",
+		Name:      "Test",
+		Prefix:    "+999",
+		Pattern:   `^\+999[0-9]{8}$`,
+		MinLength: 8,
+		MaxLength: 8,
+		NumberRanges: map[mnv.PhoneType][]string{
+			mnv.PhoneTypeVoip:     {"5X"},
+			mnv.PhoneTypeMobile:   {"55", "7"},
+			mnv.PhoneTypeLandline: {"7"},
+		},
+	}))
+
+	for i := 0; i < 20; i++ {
+		// Диапазон без "X" точнее диапазона той же длины с "X"
+		assert.Equal(t, mnv.PhoneTypeMobile, mnv.ValidatePhone("+99955123456", "zz").Type)
+		assert.Equal(t, mnv.PhoneTypeVoip, mnv.ValidatePhone("+99956123456", "zz").Type)
+		// Одинаковые диапазоны разных типов: первый тип по алфавиту
+		assert.Equal(t, mnv.PhoneTypeLandline, mnv.ValidatePhone("+99971234567", "zz").Type)
+	}
+}
@@ -0,0 +1,153 @@
+package mnv_test
+
+import (
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandRegion(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	assert.ElementsMatch(t, []string{"kg", "kz", "tj", "tm", "uz"}, mnv.ExpandRegion("central_asia"))
+	assert.ElementsMatch(t, []string{"de", "es", "fr", "it", "nl"}, mnv.ExpandRegion("EU"))
+	assert.Equal(t, []string{"uk"}, mnv.ExpandRegion("GB"))
+	assert.Equal(t, []string{"kg"}, mnv.ExpandRegion("Kyrgyzstan"))
+	assert.Nil(t, mnv.ExpandRegion(" "))
+
+	// Группы не изменяются через возвращенный срез
+	mnv.ExpandRegion("cis")[0] = "xx"
+	assert.NotContains(t, mnv.RegionGroups["cis"], "xx")
+}
+
+func TestValidatePolicy(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	assert.NoError(t, mnv.ValidatePolicy(nil))
+	assert.NoError(t, mnv.ValidatePolicy(&mnv.ValidationOptions{
+		AllowedCountries:   []string{"CIS", "us", "Kyrgyzstan"},
+		ForbiddenCountries: []string{"RUS", "middle_east"},
+		ExpectedCountry:    "kg",
+	}))
+
+	tests := []struct {
+		name string
+		opts *mnv.ValidationOptions
+		err  string
+	}{
+		{"Typo in forbidden country", &mnv.ValidationOptions{ForbiddenCountries: []string{"ru", "ruu"}}, `forbidden countries: unknown country or region group "ruu" (did you mean ru?)`},
+		{"Unknown group", &mnv.ValidationOptions{ForbiddenCountries: []string{"europe"}}, `forbidden countries: unknown country or region group "europe"`},
+		{"Unknown allowed country", &mnv.ValidationOptions{AllowedCountries: []string{"xx"}}, `allowed countries: unknown country or region group "xx"`},
+		{"Unknown expected country", &mnv.ValidationOptions{ExpectedCountry: "atlantis"}, `expected country: unknown country or region group "atlantis"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, mnv.ValidatePolicy(tt.opts), tt.err)
+		})
+	}
+}
+
+func TestValidatePhoneCountryPolicy(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	tests := []struct {
+		name      string
+		phone     string
+		country   string
+		opts      *mnv.ValidationOptions
+		valid     bool
+		errorType mnv.ErrorType
+	}{
+		{"Allowed group", "+996700123456", "kg", &mnv.ValidationOptions{AllowedCountries: []string{"central_asia"}}, true, ""},
+		{"Not in allowed list", "+447911123456", "uk", &mnv.ValidationOptions{AllowedCountries: []string{"cis", "us"}}, false, mnv.ErrorTypeCountryNotAllowed},
+		{"Forbidden country", "+79991234567", "ru", &mnv.ValidationOptions{ForbiddenCountries: []string{"RUS"}}, false, mnv.ErrorTypeCountryNotAllowed},
+		{"Forbidden wins over allowed", "+996700123456", "kg", &mnv.ValidationOptions{AllowedCountries: []string{"cis"}, ForbiddenCountries: []string{"kg"}}, false, mnv.ErrorTypeCountryNotAllowed},
+		{"Detected forbidden country", "+79991234567", "", &mnv.ValidationOptions{ForbiddenCountries: []string{"eaeu"}}, false, mnv.ErrorTypeCountryNotAllowed},
+		{"Detected allowed country", "+998901234567", "", &mnv.ValidationOptions{AllowedCountries: []string{"uz"}}, true, ""},
+		{"No options", "+33612345678", "fr", nil, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mnv.ValidatePhone(tt.phone, tt.country, tt.opts)
+			assert.Equal(t, tt.valid, result.IsValid, result.ErrorMessage)
+			assert.Equal(t, tt.errorType, result.ErrorType)
+			assert.NotEmpty(t, result.CountryCode)
+		})
+	}
+}
+
+func TestValidatePhoneDetectsCountry(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	// +1 без подсказки - США, с ожидаемой страной - Канада
+	result := mnv.ValidatePhone("+14165551234", "")
+	require.True(t, result.IsValid)
+	assert.Equal(t, "us", result.CountryCode)
+
+	result = mnv.ValidatePhone("+14165551234", "", &mnv.ValidationOptions{ExpectedCountry: "CA"})
+	require.True(t, result.IsValid)
+	assert.Equal(t, "ca", result.CountryCode)
+
+	// Разрешенная страна предпочитается при общем коде
+	result = mnv.ValidatePhone("+14165551234", "", &mnv.ValidationOptions{AllowedCountries: []string{"ca"}})
+	require.True(t, result.IsValid)
+	assert.Equal(t, "ca", result.CountryCode)
+
+	// Неверный номер с известным кодом относится к стране по префиксу
+	result = mnv.ValidatePhone("+99670012", "")
+	assert.False(t, result.IsValid)
+	assert.Equal(t, "kg", result.CountryCode)
+	assert.Equal(t, mnv.ErrorTypeInvalidLength, result.ErrorType)
+
+	result = mnv.ValidatePhone("+999123", "")
+	assert.False(t, result.IsValid)
+	assert.Empty(t, result.CountryCode)
+	assert.Equal(t, mnv.ErrorTypeInvalidFormat, result.ErrorType)
+}
+
+func TestDetectCountry(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	code, found := mnv.DetectCountry("+77011234567", nil)
+	assert.True(t, found)
+	assert.Equal(t, "kz", code)
+
+	code, found = mnv.DetectCountry("+14165551234", &mnv.ValidationOptions{ExpectedCountry: "ca"})
+	assert.True(t, found)
+	assert.Equal(t, "ca", code)
+
+	_, found = mnv.DetectCountry("+79991234567", &mnv.ValidationOptions{AllowedCountries: []string{"central_asia"}})
+	assert.False(t, found)
+
+	_, found = mnv.DetectCountry("+99670012", nil)
+	assert.False(t, found)
+}
+
+func TestBatchValidationAppliesPolicy(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	response := mnv.BatchValidatePhones(&mnv.BatchValidationRequest{
+		Phones: []string{"+996700123456", "+79991234567", "+447911123456"},
+		Options: &mnv.ValidationOptions{
+			ForbiddenCountries: []string{"ru"},
+			AllowedCountries:   []string{"cis"},
+		},
+	})
+
+	assert.Equal(t, 1, response.Stats.Valid)
+	assert.Equal(t, mnv.ErrorTypeCountryNotAllowed, response.Results[1].ErrorType)
+	assert.Equal(t, mnv.ErrorTypeCountryNotAllowed, response.Results[2].ErrorType)
+}
+
+func TestCountryNotAllowedError(t *testing.T) {
+	err := mnv.NewCountryNotAllowedError("+79991234567", "ru")
+
+	assert.Equal(t, 1008, err.ErrorCode())
+	assert.False(t, err.IsRetryable())
+	assert.Equal(t, "Phone numbers of Russia are not allowed", err.GetLocalizedMessage("en"))
+	assert.Equal(t, "Номера страны Россия не разрешены", err.GetLocalizedMessage("ru"))
+}
@@ -0,0 +1,121 @@
+package mnv_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKeyV1 = mnv.PseudonymKey{ID: "v1", Secret: []byte("0123456789abcdef0123456789abcdef")}
+	testKeyV2 = mnv.PseudonymKey{ID: "v2", Secret: []byte("fedcba9876543210fedcba9876543210")}
+)
+
+func TestPseudonymize(t *testing.T) {
+	spellings := []string{
+		"+996700123456",
+		"+996 700 123 456",
+		"+996-700-123-456",
+		"00996700123456",
+		"(996) 700.123.456",
+	}
+
+	expected, err := mnv.Pseudonymize(spellings[0], testKeyV1)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(expected, "v1:"))
+
+	for _, phone := range spellings {
+		token, err := mnv.Pseudonymize(phone, testKeyV1)
+		require.NoError(t, err, phone)
+		assert.Equal(t, expected, token, phone)
+	}
+
+	// Национальный формат с регионом
+	token, err := mnv.PseudonymizeInRegion("0700 123 456", "kg", testKeyV1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, token)
+
+	// Другой ключ дает другой псевдоним
+	other, err := mnv.Pseudonymize(spellings[0], testKeyV2)
+	require.NoError(t, err)
+	assert.NotEqual(t, strings.TrimPrefix(expected, "v1:"), strings.TrimPrefix(other, "v2:"))
+
+	// Ошибки
+	_, err = mnv.Pseudonymize("not a phone", testKeyV1)
+	assert.True(t, mnv.IsValidationError(err))
+
+	_, err = mnv.Pseudonymize("+996700123456", mnv.PseudonymKey{ID: "v1", Secret: []byte("short")})
+	assert.Error(t, err)
+
+	_, err = mnv.Pseudonymize("+996700123456", mnv.PseudonymKey{ID: "bad:id", Secret: testKeyV1.Secret})
+	assert.Error(t, err)
+}
+
+func TestKeyringRotation(t *testing.T) {
+	keyring, err := mnv.NewKeyring(testKeyV1)
+	require.NoError(t, err)
+
+	oldToken, err := keyring.Pseudonymize("+996700123456")
+	require.NoError(t, err)
+	assert.False(t, keyring.NeedsRotation(oldToken))
+
+	require.NoError(t, keyring.Rotate(testKeyV2))
+	assert.Equal(t, "v2", keyring.PrimaryKeyID())
+	assert.True(t, keyring.NeedsRotation(oldToken))
+
+	// Старый псевдоним сопоставляется по ключу из префикса
+	matched, err := keyring.Match("+996 700 123 456", oldToken)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = keyring.Match("+996700123457", oldToken)
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	newToken, err := keyring.Pseudonymize("+996700123456")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(newToken, "v2:"))
+
+	assert.Error(t, keyring.Remove("v2"))
+	require.NoError(t, keyring.Remove("v1"))
+
+	_, err = keyring.Match("+996700123456", oldToken)
+	assert.True(t, errors.Is(err, mnv.ErrUnknownPseudonymKey))
+}
+
+func TestTokenize(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	phones := []string{"+996700123456", "+79991234567", "+14155552671", "+447911123456", "+5511987654321"}
+
+	for _, phone := range phones {
+		t.Run(phone, func(t *testing.T) {
+			token, err := mnv.Tokenize(phone, testKeyV1)
+			require.NoError(t, err)
+
+			assert.NotEqual(t, phone, token)
+			assert.Equal(t, len(phone), len(token))
+
+			original := mnv.GetPhoneInfo(phone)
+			info := mnv.GetPhoneInfo(token)
+			assert.True(t, info.IsValid, token)
+			assert.Equal(t, original.Prefix, info.Prefix)
+
+			// Токенизация детерминирована и обратима
+			again, err := mnv.Tokenize(phone, testKeyV1)
+			require.NoError(t, err)
+			assert.Equal(t, token, again)
+
+			restored, err := mnv.Detokenize(token, testKeyV1)
+			require.NoError(t, err)
+			assert.Equal(t, phone, restored)
+		})
+	}
+
+	_, err := mnv.Tokenize("+123456789", testKeyV1)
+	assert.Error(t, err)
+}
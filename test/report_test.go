@@ -0,0 +1,126 @@
+package mnv_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reportFixture результаты пакетной проверки для отчета
+func reportFixture(t *testing.T) *mnv.BatchReport {
+	t.Helper()
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	response := mnv.BatchValidatePhones(&mnv.BatchValidationRequest{
+		Items: []mnv.BatchItem{
+			{Phone: "+996700123456"},
+			{Phone: "+996 700 123 456"},            // reformatted, дубликат
+			{Phone: "0700 123 456", Country: "kg"}, // trunk prefix, дубликат
+			{Phone: "555 123 456", Country: "kg"},  // country code
+			{Phone: "8 999 123 45 67", Country: "ru"},
+			{Phone: "+99670012345"},
+			{Phone: "+9967001234567"},
+			{Phone: "12"},
+		},
+	})
+	return mnv.BuildReport(response.Results)
+}
+
+func TestBuildReport(t *testing.T) {
+	report := reportFixture(t)
+
+	assert.Equal(t, 8, report.Total)
+	assert.Equal(t, 5, report.Valid)
+	assert.Equal(t, 3, report.Invalid)
+	assert.Equal(t, 62.5, report.SuccessRate)
+	assert.Equal(t, 3, report.Unique)
+	assert.Equal(t, 2, report.Duplicates)
+	assert.Equal(t, 4, report.Fixed)
+
+	fixes := make(map[string]int)
+	for _, fix := range report.Fixes {
+		fixes[fix.Name] = fix.Count
+	}
+	assert.Equal(t, map[string]int{
+		string(mnv.FixReformatted):         1,
+		string(mnv.FixTrunkPrefixStripped): 2,
+		string(mnv.FixCountryCodeAdded):    1,
+	}, fixes)
+
+	require.NotEmpty(t, report.ByCountry)
+	kg := report.ByCountry[0]
+	assert.Equal(t, "kg", kg.CountryCode)
+	assert.Equal(t, "Kyrgyzstan", kg.CountryName)
+	assert.Equal(t, kg.ValidNumbers+kg.InvalidNumbers, kg.TotalValidated)
+	assert.InDelta(t, float64(kg.ValidNumbers)*100/float64(kg.TotalValidated), kg.SuccessRate, 0.01)
+
+	require.NotEmpty(t, report.ByType)
+	assert.Equal(t, string(mnv.PhoneTypeMobile), report.ByType[0].Name)
+
+	total := 0
+	for _, errorType := range report.ByErrorType {
+		total += errorType.Count
+	}
+	assert.Equal(t, report.Invalid, total)
+
+	require.NotEmpty(t, report.TopInvalidPrefixes)
+	assert.Equal(t, "+9967", report.TopInvalidPrefixes[0].Name)
+	assert.Equal(t, 2, report.TopInvalidPrefixes[0].Count)
+}
+
+func TestReportBuilderProcessingErrors(t *testing.T) {
+	builder := mnv.NewReportBuilder()
+	builder.Add(&mnv.ValidationResult{OriginalNumber: "+996700123456", ErrorType: mnv.ErrorTypeTimeout})
+	builder.Add(&mnv.ValidationResult{OriginalNumber: "abc"})
+
+	report := builder.Report()
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 1, report.Invalid)
+	assert.Empty(t, report.TopInvalidPrefixes, "unchecked numbers and inputs without digits have no prefix")
+	assert.Len(t, report.ByErrorType, 2)
+}
+
+func TestReportRender(t *testing.T) {
+	report := reportFixture(t)
+
+	tests := []struct {
+		format   mnv.ReportFormat
+		contains []string
+	}{
+		{format: mnv.ReportFormatMarkdown, contains: []string{"# Phone number data quality report", "| Kyrgyzstan (kg) |", "| Duplicates after normalization | 2 |", "| trunk_prefix_stripped | 2 | 40.00% |"}},
+		{format: mnv.ReportFormatHTML, contains: []string{"<!DOCTYPE html>", "<style>", "<td>Kyrgyzstan (kg)</td>", "<td>&#43;9967</td>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, report.Render(&out, tt.format))
+			for _, text := range tt.contains {
+				assert.Contains(t, out.String(), text)
+			}
+		})
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, report.Render(&out, mnv.ReportFormatJSON))
+	var decoded mnv.BatchReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, report.ByCountry, decoded.ByCountry)
+	assert.Equal(t, report.Duplicates, decoded.Duplicates)
+
+	assert.Error(t, report.Render(&out, "xml"))
+}
+
+func TestReportHTMLEscaping(t *testing.T) {
+	builder := mnv.NewReportBuilder()
+	builder.Add(&mnv.ValidationResult{OriginalNumber: "+1", ErrorType: "<script>"})
+
+	var out bytes.Buffer
+	require.NoError(t, builder.Report().Render(&out, mnv.ReportFormatHTML))
+	assert.False(t, strings.Contains(out.String(), "<script>"))
+}
@@ -0,0 +1,302 @@
+package mnv_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnvhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamPhones возвращает n валидных номеров Кыргызстана
+func streamPhones(n int) []mnv.BatchItem {
+	items := make([]mnv.BatchItem, n)
+	for i := range items {
+		items[i] = mnv.BatchItem{Phone: fmt.Sprintf("+99670%07d", i)}
+	}
+	return items
+}
+
+// feed отправляет номера в канал и закрывает его
+func feed(items []mnv.BatchItem) <-chan mnv.BatchItem {
+	in := make(chan mnv.BatchItem)
+	go func() {
+		defer close(in)
+		for _, item := range items {
+			in <- item
+		}
+	}()
+	return in
+}
+
+// collect разделяет сообщения потока на результаты и снимки статистики
+func collect(messages <-chan mnv.StreamResult) (results []mnv.StreamResult, stats []*mnv.BatchStats) {
+	for message := range messages {
+		if message.Stats != nil {
+			stats = append(stats, message.Stats)
+			continue
+		}
+		results = append(results, message)
+	}
+	return results, stats
+}
+
+func TestValidateStreamOrdered(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	items := append(streamPhones(200), mnv.BatchItem{Phone: "0555 123 456"}, mnv.BatchItem{Phone: "invalid"})
+
+	results, stats := collect(mnv.ValidateStream(context.Background(), feed(items), &mnv.StreamOptions{
+		Parallel:      4,
+		Ordered:       true,
+		DefaultRegion: "kg",
+		StatsEvery:    50,
+	}))
+
+	require.Len(t, results, len(items))
+	for i, message := range results {
+		assert.Equal(t, i, message.Index)
+		assert.Equal(t, items[i].Phone, message.Result.OriginalNumber)
+	}
+	assert.True(t, results[200].Result.IsValid, "DefaultRegion is applied")
+	assert.False(t, results[201].Result.IsValid)
+
+	// Снимки после 50, 100, 150, 200 результатов и итоговый
+	require.Len(t, stats, 5)
+	for i, snapshot := range stats[:4] {
+		assert.Equal(t, (i+1)*50, snapshot.Total)
+	}
+	final := stats[4]
+	assert.Equal(t, len(items), final.Total)
+	assert.Equal(t, 201, final.Valid)
+	assert.Equal(t, 1, final.Invalid)
+	assert.Equal(t, 201, final.ByCountry["kg"])
+}
+
+func TestValidateStreamUnordered(t *testing.T) {
+	items := streamPhones(100)
+
+	results, stats := collect(mnv.ValidateStream(context.Background(), feed(items), &mnv.StreamOptions{Parallel: 8}))
+
+	indices := make([]int, 0, len(results))
+	for _, message := range results {
+		indices = append(indices, message.Index)
+		assert.Equal(t, items[message.Index].Phone, message.Result.OriginalNumber)
+	}
+	sort.Ints(indices)
+	for i, index := range indices {
+		assert.Equal(t, i, index)
+	}
+
+	require.Len(t, stats, 1, "only the final snapshot without StatsEvery")
+	assert.Equal(t, len(items), stats[0].Total)
+}
+
+func TestValidateStreamFinalStatsNotDuplicated(t *testing.T) {
+	_, stats := collect(mnv.ValidateStream(context.Background(), feed(streamPhones(20)), &mnv.StreamOptions{StatsEvery: 10}))
+
+	require.Len(t, stats, 2)
+	assert.Equal(t, 20, stats[1].Total)
+
+	_, stats = collect(mnv.ValidateStream(context.Background(), feed(nil)))
+	require.Len(t, stats, 1)
+	assert.Equal(t, 0, stats[0].Total)
+}
+
+func TestValidateStreamBackpressure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sent := make(chan int, 1000)
+	in := make(chan mnv.BatchItem)
+	go func() {
+		defer close(in)
+		for i, item := range streamPhones(1000) {
+			select {
+			case in <- item:
+				sent <- i + 1
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := mnv.ValidateStream(ctx, in, &mnv.StreamOptions{Parallel: 2, Buffer: 4})
+
+	// Результаты не читаются: прием останавливается после заполнения окна и буфера вывода
+	time.Sleep(100 * time.Millisecond)
+	accepted := len(sent)
+	assert.LessOrEqual(t, accepted, 4+2+1)
+
+	<-results
+	time.Sleep(20 * time.Millisecond)
+	assert.LessOrEqual(t, len(sent), accepted+2, "each read result admits a bounded number of items")
+}
+
+func TestValidateStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan mnv.BatchItem)
+	results := mnv.ValidateStream(ctx, in)
+
+	in <- mnv.BatchItem{Phone: "+996700123456"}
+	message := <-results
+	assert.True(t, message.Result.IsValid)
+
+	cancel()
+	for message := range results {
+		assert.Nil(t, message.Stats, "no final snapshot after cancellation")
+	}
+}
+
+func TestValidateSeq(t *testing.T) {
+	items := streamPhones(30)
+
+	var results []mnv.StreamResult
+	var final *mnv.BatchStats
+	for message, err := range mnv.ValidateSeq(context.Background(), slices.Values(items), &mnv.StreamOptions{Ordered: true}) {
+		require.NoError(t, err)
+		if message.Stats != nil {
+			final = message.Stats
+			continue
+		}
+		results = append(results, message)
+	}
+
+	require.Len(t, results, len(items))
+	assert.Equal(t, len(items)-1, results[len(results)-1].Index)
+	require.NotNil(t, final)
+	assert.Equal(t, len(items), final.Valid)
+
+	// Прекращение перебора останавливает проверку
+	count := 0
+	for range mnv.ValidateSeq(context.Background(), slices.Values(streamPhones(1000))) {
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, count)
+}
+
+func TestValidateSeqContextError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Бесконечная последовательность прерывается сроком ctx
+	endless := func(yield func(mnv.BatchItem) bool) {
+		for yield(mnv.BatchItem{Phone: "+996700123456"}) {
+		}
+	}
+
+	var last error
+	for _, err := range mnv.ValidateSeq(ctx, endless) {
+		if err != nil {
+			last = err
+		}
+	}
+	assert.ErrorIs(t, last, mnv.ErrTimeout)
+}
+
+func TestValidateStreamFinalFlag(t *testing.T) {
+	var finals []int
+	for message := range mnv.ValidateStream(context.Background(), feed(streamPhones(20)), &mnv.StreamOptions{StatsEvery: 10}) {
+		if message.Final {
+			require.NotNil(t, message.Stats)
+			finals = append(finals, message.Stats.Total)
+		}
+	}
+	assert.Equal(t, []int{20}, finals, "only the last snapshot is final")
+}
+
+func TestValidateSeqCancelWithStats(t *testing.T) {
+	// Промежуточные снимки не считаются завершением потока
+	for run := 0; run < 20; run++ {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		results := 0
+		var last error
+		for message, err := range mnv.ValidateSeq(ctx, slices.Values(streamPhones(1000)), &mnv.StreamOptions{StatsEvery: 1}) {
+			if err != nil {
+				last = err
+				continue
+			}
+			if message.Result != nil {
+				if results++; results == 5 {
+					cancel()
+				}
+			}
+		}
+		cancel()
+
+		assert.ErrorIs(t, last, context.Canceled, "run %d", run)
+	}
+}
+
+func TestHTTPHandlerStream(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	h := mnvhttp.NewHandler(mnvhttp.Options{MaxBatchSize: 1})
+
+	body := strings.Join([]string{
+		`{"phone": "+996700123456"}`,
+		``,
+		`{"phone": "8 999 123 45 67", "country": "ru"}`,
+		`0555 123 456`,
+		`not a phone`,
+	}, "\n")
+	req := httptest.NewRequest(http.MethodPost, "/validate/stream?ordered=true&default_region=kg", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, mnvhttp.NDJSONContentType, rec.Header().Get("Content-Type"))
+
+	var messages []mnv.StreamResult
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var message mnv.StreamResult
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &message), scanner.Text())
+		messages = append(messages, message)
+	}
+
+	require.Len(t, messages, 5, "four results and the final snapshot; MaxBatchSize does not apply")
+	for i, valid := range []bool{true, true, true, false} {
+		assert.Equal(t, i, messages[i].Index)
+		assert.Equal(t, valid, messages[i].Result.IsValid, "line %d", i)
+	}
+	assert.Equal(t, "ru", messages[1].Result.CountryCode)
+	require.NotNil(t, messages[4].Stats)
+	assert.Equal(t, 4, messages[4].Stats.Total)
+	assert.Equal(t, 3, messages[4].Stats.Valid)
+}
+
+func TestHTTPHandlerStreamErrors(t *testing.T) {
+	h := mnvhttp.NewHandler(mnvhttp.Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/validate/stream?stats_every=-1", strings.NewReader(""))
+	rec, body := serveJSON(t, h, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, body["detail"], "stats_every")
+
+	// Слишком длинная строка прерывает поток сообщением об ошибке
+	long := `+996700123456` + "\n" + strings.Repeat("1", 128<<10)
+	req = httptest.NewRequest(http.MethodPost, "/validate/stream", strings.NewReader(long))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.NotEmpty(t, lines)
+	var streamErr mnvhttp.StreamError
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &streamErr))
+	assert.Contains(t, streamErr.Error, "too long")
+	assert.NotContains(t, rec.Body.String(), `"stats"`)
+}
@@ -0,0 +1,74 @@
+package mnv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePhoneSuggestions(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	defer mnv.SetConfig(mnv.DefaultConfig())
+
+	tests := []struct {
+		name       string
+		phone      string
+		country    string
+		expected   string
+		correction mnv.CorrectionKind
+	}{
+		{"Missing plus", "996700123456", "kg", "+996700123456", mnv.CorrectionMissingPlus},
+		{"National format", "0700123456", "kg", "+996700123456", mnv.CorrectionNationalFormat},
+		{"Doubled country code", "+996996700123456", "kg", "+996700123456", mnv.CorrectionDoubledCountryCode},
+		{"Trunk prefix after code", "+9960700123456", "kg", "+996700123456", mnv.CorrectionTrunkPrefix},
+		{"Missing digit", "+99670012345", "kg", "", mnv.CorrectionMissingDigit},
+		{"Extra digit", "+9967001234567", "kg", "", mnv.CorrectionExtraDigit},
+		{"OCR confusion", "+996 7OO l23 456", "kg", "+996700123456", mnv.CorrectionOCR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mnv.ValidatePhone(tt.phone, tt.country, &mnv.ValidationOptions{
+				ReturnSuggestions: true,
+				MaxSuggestions:    3,
+			})
+			require.False(t, result.IsValid)
+			require.NotEmpty(t, result.SuggestionDetails)
+			assert.LessOrEqual(t, len(result.Suggestions), 3)
+			assert.Equal(t, len(result.Suggestions), len(result.SuggestionDetails))
+
+			top := result.SuggestionDetails[0]
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, top.Number)
+			}
+			assert.Equal(t, tt.correction, top.Correction)
+			assert.Equal(t, "kg", top.CountryCode)
+			assert.True(t, mnv.IsPhoneValid(top.Number, "kg"))
+
+			// Предложения отсортированы по уверенности
+			for i := 1; i < len(result.SuggestionDetails); i++ {
+				assert.GreaterOrEqual(t, result.SuggestionDetails[i-1].Confidence, result.SuggestionDetails[i].Confidence)
+			}
+		})
+	}
+}
+
+func TestSuggestionsStayInHintedCountry(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	result := mnv.ValidatePhone("+99670012345", "kg", &mnv.ValidationOptions{
+		ReturnSuggestions: true,
+		MaxSuggestions:    10,
+	})
+
+	require.NotEmpty(t, result.SuggestionDetails)
+	for _, suggestion := range result.SuggestionDetails {
+		assert.False(t, strings.HasPrefix(suggestion.Number, "+33"), suggestion.Number)
+		assert.Greater(t, suggestion.Confidence, 0.0)
+		assert.LessOrEqual(t, suggestion.Confidence, 1.0)
+	}
+	assert.Len(t, result.SuggestionDetails, 10)
+}
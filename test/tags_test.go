@@ -0,0 +1,145 @@
+package mnv_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTagValidator(t *testing.T) *validator.Validate {
+	t.Helper()
+	validate := validator.New()
+	require.NoError(t, mnv.RegisterValidators(validate))
+	return validate
+}
+
+func TestPhoneTagWithCountries(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	validate := newTagValidator(t)
+
+	tests := []struct {
+		phone string
+		tag   string
+		valid bool
+	}{
+		{"+996700123456", "phone=kg kz uz", true},
+		{"+998901234567", "phone=kg kz uz", true},
+		{"+79001234567", "phone=kg kz uz", false},
+		{"+79001234567", "phone", true},
+		{"+919876543210", "phone=in", true},
+		{"+996700123456", "phone=KGZ", true},
+		{"+996700123456", "phone=xx", false},
+		{"+33612345678", "phone=fr", true},
+		{"+61412345678", "phone=au", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag+"_"+tt.phone, func(t *testing.T) {
+			err := validate.Var(tt.phone, tt.tag)
+			assert.Equal(t, tt.valid, err == nil, "%v", err)
+		})
+	}
+}
+
+func TestPhoneTypeAndE164Tags(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	validate := newTagValidator(t)
+
+	assert.NoError(t, validate.Var("+447911123456", "phone_type=mobile"))
+	assert.Error(t, validate.Var("+18001234567", "phone_type=mobile"))
+	assert.NoError(t, validate.Var("+18001234567", "phone_type=mobile toll_free"))
+	assert.Error(t, validate.Var("12345", "phone_type=mobile"))
+
+	assert.NoError(t, validate.Var("+996700123456", "e164"))
+	assert.Error(t, validate.Var("+996 700 123 456", "e164"))
+	assert.Error(t, validate.Var("996700123456", "e164"))
+	assert.Error(t, validate.Var("+0996700123456", "e164"))
+}
+
+func TestPhoneByCountryField(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	validate := newTagValidator(t)
+
+	type Contact struct {
+		Phone       string `validate:"phonebycountry=CountryCode"`
+		CountryCode *string
+	}
+
+	kg, ru := "kg", "ru"
+	assert.NoError(t, validate.Struct(Contact{Phone: "+996700123456", CountryCode: &kg}))
+	assert.Error(t, validate.Struct(Contact{Phone: "+996700123456", CountryCode: &ru}))
+	assert.Error(t, validate.Struct(Contact{Phone: "+996700123456"}))
+
+	// Без параметра по-прежнему используется поле Country
+	type User struct {
+		Phone   string `validate:"phonebycountry"`
+		Country string
+	}
+	assert.NoError(t, validate.Struct(&User{Phone: "+996700123456", Country: "kg"}))
+}
+
+func TestTagsOnPointerNullStringAndSlices(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	validate := newTagValidator(t)
+
+	valid, invalid := "+996700123456", "+99670012"
+
+	type Profile struct {
+		Mobile   *string        `validate:"omitempty,phone=kg"`
+		Landline sql.NullString `validate:"omitempty,e164"`
+		Others   []string       `validate:"dive,phone=kg uz"`
+		Backup   []*string      `validate:"dive,omitempty,kg"`
+	}
+
+	assert.NoError(t, validate.Struct(Profile{
+		Mobile:   &valid,
+		Landline: sql.NullString{String: "+998901234567", Valid: true},
+		Others:   []string{"+996700123456", "+998901234567"},
+		Backup:   []*string{&valid, nil},
+	}))
+
+	assert.Error(t, validate.Struct(Profile{Mobile: &invalid}))
+	assert.Error(t, validate.Struct(Profile{Landline: sql.NullString{String: "12345", Valid: true}}))
+	assert.Error(t, validate.Struct(Profile{Others: []string{"+996700123456", "+79001234567"}}))
+	assert.Error(t, validate.Struct(Profile{Backup: []*string{&invalid}}))
+
+	// Незаданные значения пропускаются благодаря omitempty
+	assert.NoError(t, validate.Struct(Profile{Landline: sql.NullString{String: "12345"}}))
+
+	// Обязательное поле sql.NullString без значения не проходит проверку
+	type Required struct {
+		Phone sql.NullString `validate:"required,phone"`
+	}
+	assert.Error(t, validate.Struct(Required{}))
+	assert.NoError(t, validate.Struct(Required{Phone: sql.NullString{String: valid, Valid: true}}))
+}
+
+func TestParameterizedTagTranslations(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	uni := ut.New(en.New(), en.New())
+	trans, _ := uni.GetTranslator("en")
+
+	validate := newTagValidator(t)
+	require.NoError(t, mnv.RegisterTranslations(validate, trans, "ru"))
+
+	type Form struct {
+		Phone  string `validate:"phone=kg kz"`
+		Type   string `validate:"phone_type=mobile"`
+		Strict string `validate:"e164"`
+		Work   string `validate:"phonebycountry=Region"`
+		Region string
+	}
+
+	messages := translateErrors(t, validate, trans, Form{Phone: "+79001234567", Type: "1", Strict: "1", Work: "1", Region: "kg"})
+	assert.Equal(t, "Phone должен быть действительным номером телефона одной из стран: Кыргызстан, Казахстан", messages["Form.Phone"])
+	assert.Equal(t, "Type должен быть номером телефона типа: мобильный", messages["Form.Type"])
+	assert.Contains(t, messages["Form.Strict"], "E.164")
+	assert.Equal(t, "Work должен быть действительным номером телефона страны, указанной в Region", messages["Form.Work"])
+}
@@ -0,0 +1,111 @@
+package mnv_test
+
+import (
+	"testing"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/jaman-bala/mnv/pkg/mnv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type translatedUser struct {
+	PhoneNumber string `validate:"kg"`
+	Contact     string `validate:"phone"`
+	Mobile      string `validate:"mobile"`
+	Work        string `validate:"phonebycountry"`
+	Country     string
+}
+
+func newTranslator(t *testing.T, lang string) (*validator.Validate, ut.Translator) {
+	t.Helper()
+
+	uni := ut.New(en.New(), en.New(), ru.New())
+	trans, found := uni.GetTranslator(lang)
+	require.True(t, found)
+
+	validate := validator.New()
+	require.NoError(t, mnv.RegisterValidators(validate))
+	require.NoError(t, mnv.RegisterTranslations(validate, trans, ""))
+	return validate, trans
+}
+
+func translateErrors(t *testing.T, validate *validator.Validate, trans ut.Translator, value interface{}) map[string]string {
+	t.Helper()
+
+	err := validate.Struct(value)
+	require.Error(t, err)
+
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	return errs.Translate(trans)
+}
+
+func TestRegisterTranslationsEnglish(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	validate, trans := newTranslator(t, "en")
+
+	messages := translateErrors(t, validate, trans, translatedUser{
+		PhoneNumber: "+99670012",
+		Contact:     "12345",
+		Mobile:      "abc",
+		Work:        "+77011234567",
+		Country:     "kg",
+	})
+
+	assert.Equal(t, "PhoneNumber must be a valid Kyrgyzstan phone number in the format +996 XXX XXX XXX",
+		messages["translatedUser.PhoneNumber"])
+	assert.Equal(t, "Contact must be a valid phone number in international format: +<country code> <number>",
+		messages["translatedUser.Contact"])
+	assert.Contains(t, messages["translatedUser.Mobile"], "valid mobile phone number")
+	assert.Equal(t, "Work must be a valid phone number for the country specified in Country",
+		messages["translatedUser.Work"])
+}
+
+func TestRegisterTranslationsRussian(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+	validate, trans := newTranslator(t, "ru")
+
+	messages := translateErrors(t, validate, trans, translatedUser{
+		PhoneNumber: "+99670012",
+		Contact:     "+996700123456",
+		Mobile:      "+996700123456",
+		Work:        "+996700123456",
+		Country:     "kg",
+	})
+
+	require.Len(t, messages, 1)
+	assert.Equal(t, "PhoneNumber должен быть номером телефона (Кыргызстан) в формате +996 XXX XXX XXX",
+		messages["translatedUser.PhoneNumber"])
+}
+
+func TestRegisterTranslationsExplicitLanguage(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	uni := ut.New(en.New(), en.New())
+	trans, _ := uni.GetTranslator("en")
+
+	validate := validator.New()
+	require.NoError(t, mnv.RegisterValidators(validate))
+	require.NoError(t, mnv.RegisterTranslations(validate, trans, "kg"))
+
+	type form struct {
+		Phone string `validate:"de"`
+	}
+	messages := translateErrors(t, validate, trans, form{Phone: "123"})
+	assert.Equal(t, "Phone +49 XXX XXX XXXX (10-12 сан) форматындагы туура телефон номери болушу керек (Германия)",
+		messages["form.Phone"])
+}
+
+func TestExpectedFormat(t *testing.T) {
+	mnv.SetConfig(mnv.DefaultConfig())
+
+	assert.Equal(t, "+996 XXX XXX XXX", mnv.ExpectedFormat("kg", "en"))
+	assert.Equal(t, "+1 XXX XXX XXXX", mnv.ExpectedFormat("US", "en"))
+	assert.Equal(t, "+49 XXX XXX XXXX (10-12 digits)", mnv.ExpectedFormat("de", "en"))
+	assert.Equal(t, "+49 XXX XXX XXXX (10-12 цифр)", mnv.ExpectedFormat("de", "ru"))
+	assert.Empty(t, mnv.ExpectedFormat("xx", "en"))
+}
@@ -4,7 +4,7 @@ import (
 	"testing"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/jaman-bala/mnv"
+	"github.com/jaman-bala/mnv/pkg/mnv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )